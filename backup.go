@@ -0,0 +1,502 @@
+package jenkins
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// format of archive written by Backup
+const backupVersion = 1
+
+// kind of BackupEntry for agent, other kinds are MigrateJob, MigrateView and
+// MigrateCredential
+const BackupNode = "node"
+
+// BackupScope selects what Backup archives, zero value archives everything.
+type BackupScope struct {
+	// full name of folder whose subtree is archived, all jobs if empty. Views
+	// and credentials of jenkins itself are archived only if it is empty
+	Folder        string `json:"folder,omitempty"`
+	NoViews       bool   `json:"noViews,omitempty"`
+	NoCredentials bool   `json:"noCredentials,omitempty"`
+	NoNodes       bool   `json:"noNodes,omitempty"`
+	NoPlugins     bool   `json:"noPlugins,omitempty"`
+}
+
+// BackupEntry is a config.xml in archive.
+type BackupEntry struct {
+	Kind string `json:"kind"`
+	// full name of folder of view or credential, empty for jenkins itself
+	Folder string `json:"folder,omitempty"`
+	// full name of job, name of view or agent, or id of credential
+	Name string `json:"name"`
+	Path string `json:"path"`
+	// paths of elements whose secret is removed from credential, see
+	// ConfigEdit for form of path
+	Secrets []string `json:"secrets,omitempty"`
+}
+
+// BackupManifest is manifest.json of archive, entries are in order of restore.
+type BackupManifest struct {
+	Version        int           `json:"version"`
+	JenkinsVersion string        `json:"jenkinsVersion"`
+	URL            string        `json:"url"`
+	Created        time.Time     `json:"created"`
+	Scope          BackupScope   `json:"scope"`
+	Entries        []BackupEntry `json:"entries"`
+}
+
+// elements of credentials which keep secret, besides any encrypted value
+var secretElements = []string{"password", "passphrase", "privateKey", "secret", "secretBytes", "token", "apiToken"}
+
+// value encrypted by jenkins, it is useless for another instance
+var encryptedSecret = regexp.MustCompile(`^\{[A-Za-z0-9+/=]{8,}\}$`)
+
+// remove secrets of credential, returns paths of removed elements
+func stripSecrets(config string) (string, []string, error) {
+	doc, err := parseXMLDoc(config)
+	if err != nil {
+		return "", nil, err
+	}
+	var stripped []string
+	var walk func(e *xmlElement, p string)
+	walk = func(e *xmlElement, p string) {
+		leaf := true
+		for _, child := range e.children {
+			if child.elem != nil {
+				leaf = false
+				childPath := qualifiedName(child.elem.name)
+				if p != "" {
+					childPath = p + "/" + childPath
+				}
+				walk(child.elem, childPath)
+			}
+		}
+		text := strings.TrimSpace(e.text())
+		if p == "" || !leaf || text == "" {
+			return
+		}
+		if slices.Contains(secretElements, e.name.Local) || encryptedSecret.MatchString(text) {
+			e.setText("")
+			if !slices.Contains(stripped, p) {
+				stripped = append(stripped, p)
+			}
+		}
+	}
+	for _, child := range doc.children {
+		if child.elem != nil {
+			walk(child.elem, "")
+		}
+	}
+	return doc.String(), stripped, nil
+}
+
+// backup writes files of archive
+type backup struct {
+	c        *Jenkins
+	tw       *tar.Writer
+	manifest *BackupManifest
+	// views are restored after all jobs since they refer jobs
+	views []BackupEntry
+}
+
+func (b *backup) add(name string, data []byte) error {
+	hdr := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(data)), ModTime: b.manifest.Created}
+	if err := b.tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err := b.tw.Write(data)
+	return err
+}
+
+func (b *backup) addEntry(entry BackupEntry, config string) error {
+	if entry.Kind == MigrateView {
+		b.views = append(b.views, entry)
+	} else {
+		b.manifest.Entries = append(b.manifest.Entries, entry)
+	}
+	return b.add(entry.Path, []byte(config))
+}
+
+func (b *backup) addViews(ctx context.Context, folder string, views *Views) error {
+	list, err := views.ListCtx(ctx)
+	if err != nil {
+		return err
+	}
+	for _, view := range list {
+		if isDefaultView(view.Name) {
+			continue
+		}
+		config, err := views.GetConfigureCtx(ctx, view.Name)
+		if err != nil {
+			return err
+		}
+		entry := BackupEntry{Kind: MigrateView, Folder: folder, Name: view.Name, Path: path.Join("views", folder, view.Name, "config.xml")}
+		if err := b.addEntry(entry, config); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *backup) addCredentials(ctx context.Context, folder string, creds *Credentials) error {
+	list, err := creds.ListCtx(ctx)
+	if err != nil {
+		return err
+	}
+	for _, cred := range list {
+		config, err := creds.GetConfigureCtx(ctx, cred.ID)
+		if err != nil {
+			return err
+		}
+		entry := BackupEntry{Kind: MigrateCredential, Folder: folder, Name: cred.ID, Path: path.Join("credentials", folder, cred.ID, "config.xml")}
+		if config, entry.Secrets, err = stripSecrets(config); err != nil {
+			return fmt.Errorf("credential %s: %w", cred.ID, err)
+		}
+		if err := b.addEntry(entry, config); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *backup) addJob(ctx context.Context, job *Job) error {
+	config, err := job.GetConfigureCtx(ctx)
+	if err != nil {
+		return err
+	}
+	entry := BackupEntry{Kind: MigrateJob, Name: job.FullName, Path: path.Join("jobs", job.FullName, "config.xml")}
+	if err := b.addEntry(entry, config); err != nil {
+		return err
+	}
+	if !isFolder(job.Class) {
+		return nil
+	}
+	if !b.manifest.Scope.NoCredentials {
+		if err := b.addCredentials(ctx, job.FullName, job.Credentials()); err != nil {
+			return err
+		}
+	}
+	if !b.manifest.Scope.NoViews {
+		return b.addViews(ctx, job.FullName, job.Views())
+	}
+	return nil
+}
+
+func (c *Jenkins) Backup(w io.Writer, scope *BackupScope) (*BackupManifest, error) {
+	return c.BackupCtx(context.Background(), w, scope)
+}
+
+// Backup writes tar.gz archive of configs to w: config.xml of folders and
+// jobs, views, credentials without secrets, agents and installed plugins,
+// with manifest.json which is written last. Builds are not archived. Jobs of
+// multibranch projects and organization folders are generated, so they are
+// not walked into:
+//
+//	f, _ := os.Create("jenkins.tar.gz")
+//	defer f.Close()
+//	manifest, err := client.Backup(f, nil)
+func (c *Jenkins) BackupCtx(ctx context.Context, w io.Writer, scope *BackupScope) (*BackupManifest, error) {
+	manifest := &BackupManifest{Version: backupVersion, URL: c.URL, Created: time.Now().UTC()}
+	if scope != nil {
+		manifest.Scope = *scope
+	}
+	manifest.Scope.Folder = strings.Trim(manifest.Scope.Folder, "/")
+	var err error
+	if manifest.JenkinsVersion, err = c.GetVersionCtx(ctx); err != nil {
+		return nil, err
+	}
+	gw := gzip.NewWriter(w)
+	b := &backup{c: c, tw: tar.NewWriter(gw), manifest: manifest}
+	if err := b.run(ctx); err != nil {
+		return nil, err
+	}
+	if err := b.tw.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, gw.Close()
+}
+
+func (b *backup) run(ctx context.Context) error {
+	c, scope := b.c, b.manifest.Scope
+	if !scope.NoPlugins {
+		plugins, err := c.Plugins().ListCtx(ctx)
+		if err != nil {
+			return err
+		}
+		data, err := json.MarshalIndent(plugins, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := b.add("plugins.json", data); err != nil {
+			return err
+		}
+	}
+	if !scope.NoNodes {
+		nodes, err := c.Nodes().ListCtx(ctx)
+		if err != nil {
+			return err
+		}
+		for _, node := range nodes {
+			// built-in node is configured with jenkins itself
+			if strings.HasSuffix(node.Class, "MasterComputer") {
+				continue
+			}
+			config, err := c.Nodes().GetConfigureCtx(ctx, node.DisplayName)
+			if err != nil {
+				return err
+			}
+			entry := BackupEntry{Kind: BackupNode, Name: node.DisplayName, Path: path.Join("nodes", node.DisplayName, "config.xml")}
+			if err := b.addEntry(entry, config); err != nil {
+				return err
+			}
+		}
+	}
+
+	root := NewJob(c.URL, "Folder", c)
+	if scope.Folder == "" {
+		if !scope.NoCredentials {
+			if err := b.addCredentials(ctx, "", c.Credentials()); err != nil {
+				return err
+			}
+		}
+		if !scope.NoViews {
+			if err := b.addViews(ctx, "", c.Views()); err != nil {
+				return err
+			}
+		}
+	} else {
+		var err error
+		if root, err = c.GetJobCtx(ctx, scope.Folder); err != nil {
+			return err
+		}
+		if err := b.addJob(ctx, root); err != nil {
+			return err
+		}
+	}
+	if isFolder(root.Class) {
+		for job, err := range root.WalkCtx(ctx, &WalkOpts{Skip: func(job *Job) bool { return job.Class != "Folder" }}) {
+			if err != nil {
+				return err
+			}
+			if err := b.addJob(ctx, job); err != nil {
+				return err
+			}
+		}
+	}
+	b.manifest.Entries = append(b.manifest.Entries, b.views...)
+	data, err := json.MarshalIndent(b.manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return b.add("manifest.json", data)
+}
+
+type RestoreOpts struct {
+	// check archive against jenkins without changing anything
+	DryRun bool
+	// secrets of credentials removed by Backup, keys of returned map must
+	// include BackupEntry.Secrets. Credentials are restored without secrets
+	// if nil
+	Secrets SecretFunc
+}
+
+// RestoreItem is result of restoring an entry, Err is nil if it is restored
+// or it would be restored in dry run.
+type RestoreItem struct {
+	BackupEntry
+	Err error
+}
+
+type RestoreReport struct {
+	Manifest *BackupManifest
+	Items    []RestoreItem
+	// short names of plugins which are referred by configs in archive but not
+	// installed on jenkins, with names of entries referring them
+	MissingPlugins map[string][]string
+}
+
+// Failures returns items failed to restore.
+func (r *RestoreReport) Failures() []RestoreItem {
+	var failures []RestoreItem
+	for _, item := range r.Items {
+		if item.Err != nil {
+			failures = append(failures, item)
+		}
+	}
+	return failures
+}
+
+// Err joins errors of failed items, nil if none is failed.
+func (r *RestoreReport) Err() error {
+	var errs []error
+	for _, item := range r.Failures() {
+		errs = append(errs, fmt.Errorf("%s %s: %w", item.Kind, path.Join(item.Folder, item.Name), item.Err))
+	}
+	return errors.Join(errs...)
+}
+
+// read files of tar.gz archive
+func readArchive(r io.Reader) (map[string]string, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gr.Close()
+	files := map[string]string{}
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		files[hdr.Name] = string(data)
+	}
+}
+
+func (c *Jenkins) Restore(r io.Reader, opts *RestoreOpts) (*RestoreReport, error) {
+	return c.RestoreCtx(context.Background(), r, opts)
+}
+
+// Restore creates items of archive written by Backup in order of manifest,
+// it is meant for empty jenkins, so existing item is reported as
+// ErrAlreadyExists and kept as is. Failure of an item is recorded in report
+// and restore goes on, the returned error is report.Err() or failure of
+// reading archive.
+func (c *Jenkins) RestoreCtx(ctx context.Context, r io.Reader, opts *RestoreOpts) (*RestoreReport, error) {
+	var o RestoreOpts
+	if opts != nil {
+		o = *opts
+	}
+	files, err := readArchive(r)
+	if err != nil {
+		return nil, err
+	}
+	data, ok := files["manifest.json"]
+	if !ok {
+		return nil, newError(ErrNotFound, "archive has no manifest.json")
+	}
+	report := &RestoreReport{Manifest: &BackupManifest{}, MissingPlugins: map[string][]string{}}
+	if err := json.Unmarshal([]byte(data), report.Manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest.json: %w", err)
+	}
+	if report.Manifest.Version != backupVersion {
+		return nil, fmt.Errorf("unsupported version %d of archive", report.Manifest.Version)
+	}
+	plugins, err := c.Plugins().ListCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	installed := map[string]bool{}
+	for _, p := range plugins {
+		installed[p.ShortName] = p.Active
+	}
+	// parents of archived folder are created as plain folders
+	if dir := path.Dir(report.Manifest.Scope.Folder); dir != "." && !o.DryRun {
+		if _, err := c.EnsureFolderCtx(ctx, dir); err != nil {
+			return report, err
+		}
+	}
+	for _, entry := range report.Manifest.Entries {
+		item := RestoreItem{BackupEntry: entry}
+		config, ok := files[entry.Path]
+		if !ok {
+			item.Err = newError(ErrNotFound, "%s is not in archive", entry.Path)
+		} else {
+			for _, name := range pluginRefs(config) {
+				if !installed[name] {
+					report.MissingPlugins[name] = append(report.MissingPlugins[name], path.Join(entry.Folder, entry.Name))
+				}
+			}
+			item.Err = c.restoreEntry(ctx, entry, config, &o)
+		}
+		report.Items = append(report.Items, item)
+	}
+	return report, report.Err()
+}
+
+// folder of view or credential
+func (c *Jenkins) restoreFolder(folder string) *Job {
+	return NewJob(c.Name2URL(folder), "Folder", c)
+}
+
+func (c *Jenkins) restoreEntry(ctx context.Context, entry BackupEntry, config string, o *RestoreOpts) error {
+	if _, err := parseXMLDoc(config); err != nil {
+		return err
+	}
+	var err error
+	switch entry.Kind {
+	case MigrateJob:
+		_, err = c.lookupJob(ctx, entry.Name)
+	case MigrateView:
+		views := c.Views()
+		if entry.Folder != "" {
+			views = c.restoreFolder(entry.Folder).Views()
+		}
+		_, err = views.GetCtx(ctx, entry.Name)
+	case MigrateCredential:
+		creds := c.Credentials()
+		if entry.Folder != "" {
+			creds = c.restoreFolder(entry.Folder).Credentials()
+		}
+		_, err = creds.GetCtx(ctx, entry.Name)
+	case BackupNode:
+		_, err = c.Nodes().GetCtx(ctx, entry.Name)
+	default:
+		return fmt.Errorf("unknown kind %q", entry.Kind)
+	}
+	// folder of entry is not created yet in dry run
+	if errors.Is(err, ErrNotFound) {
+		err = nil
+	} else if err == nil {
+		err = newError(ErrAlreadyExists, "%s already exists", path.Join(entry.Folder, entry.Name))
+	}
+	if err != nil || o.DryRun {
+		return err
+	}
+
+	switch entry.Kind {
+	case MigrateJob:
+		return closeResponse(c.CreateJobCtx(ctx, entry.Name, strings.NewReader(config)))
+	case MigrateView:
+		views := c.Views()
+		if entry.Folder != "" {
+			views = c.restoreFolder(entry.Folder).Views()
+		}
+		return closeResponse(views.CreateCtx(ctx, entry.Name, strings.NewReader(config)))
+	case MigrateCredential:
+		if o.Secrets != nil && len(entry.Secrets) > 0 {
+			secrets, err := o.Secrets(entry.Folder, &CredentialJson{ID: entry.Name})
+			if err != nil {
+				return err
+			}
+			if config, err = setSecrets(config, entry.Name, entry.Secrets, secrets); err != nil {
+				return err
+			}
+		}
+		creds := c.Credentials()
+		if entry.Folder != "" {
+			creds = c.restoreFolder(entry.Folder).Credentials()
+		}
+		return closeResponse(creds.CreateCtx(ctx, strings.NewReader(config)))
+	default:
+		return closeResponse(c.Nodes().CreateCtx(ctx, entry.Name, strings.NewReader(config)))
+	}
+}
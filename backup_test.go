@@ -0,0 +1,128 @@
+package jenkins
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/joelee2012/go-jenkins/jenkinstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func backupEntries(entries []BackupEntry) []string {
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Kind+" "+entry.Path)
+	}
+	return names
+}
+
+func TestBackupRestore(t *testing.T) {
+	source, _ := newMigrateJenkins(t)
+	dst := jenkinstest.NewServer()
+	defer dst.Close()
+	_, err := source.Credentials().Create(strings.NewReader(strings.Replace(credConf, "user-id", "root-id", 1)))
+	require.NoError(t, err)
+	_, err = source.Views().Create("apps", strings.NewReader(viewConf))
+	require.NoError(t, err)
+	_, err = source.Nodes().Create("agent", strings.NewReader("<slave><name>agent</name><numExecutors>3</numExecutors></slave>"))
+	require.NoError(t, err)
+
+	var archive bytes.Buffer
+	manifest, err := source.Backup(&archive, nil)
+	require.NoError(t, err)
+	assert.Equal(t, jenkinstest.DefaultVersion, manifest.JenkinsVersion)
+	assert.Equal(t, []string{
+		"node nodes/agent/config.xml",
+		"credential credentials/root-id/config.xml",
+		"job jobs/team/config.xml",
+		"credential credentials/team/user-id/config.xml",
+		"job jobs/team/app/config.xml",
+		"job jobs/team/lib/config.xml",
+		"job jobs/team/lib/x/config.xml",
+		"job jobs/other/config.xml",
+		"view views/apps/config.xml",
+		"view views/team/mine/config.xml",
+	}, backupEntries(manifest.Entries))
+	assert.Equal(t, []string{"password"}, manifest.Entries[1].Secrets)
+
+	files, err := readArchive(bytes.NewReader(archive.Bytes()))
+	require.NoError(t, err)
+	assert.Contains(t, files, "plugins.json")
+	assert.Contains(t, files, "manifest.json")
+	assert.Contains(t, files["credentials/team/user-id/config.xml"], "<password></password>")
+	assert.NotContains(t, files["credentials/team/user-id/config.xml"], "{AQAAABAAAAAQ}")
+	assert.Equal(t, migrateJobConf, files["jobs/team/app/config.xml"])
+
+	// only subtree of folder
+	var sub bytes.Buffer
+	manifest, err = source.Backup(&sub, &BackupScope{Folder: "team/lib", NoNodes: true, NoPlugins: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"job jobs/team/lib/config.xml", "job jobs/team/lib/x/config.xml"}, backupEntries(manifest.Entries))
+	files, err = readArchive(bytes.NewReader(sub.Bytes()))
+	require.NoError(t, err)
+	assert.Contains(t, files["manifest.json"], `"scope": {
+    "folder": "team/lib",
+    "noNodes": true,
+    "noPlugins": true
+  }`)
+
+	target, err := New(dst.URL, "admin", "1234")
+	require.NoError(t, err)
+	report, err := target.Restore(bytes.NewReader(archive.Bytes()), &RestoreOpts{DryRun: true})
+	require.NoError(t, err)
+	assert.Len(t, report.Items, 10)
+	assert.Equal(t, []string{"team/app"}, report.MissingPlugins["workflow-job"])
+	exists, err := target.JobExists("team")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	var asked []string
+	secrets := func(folder string, cred *CredentialJson) (map[string]string, error) {
+		asked = append(asked, folder+"/"+cred.ID)
+		return map[string]string{"password": "from-vault"}, nil
+	}
+	report, err = target.Restore(bytes.NewReader(archive.Bytes()), &RestoreOpts{Secrets: secrets})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/root-id", "team/user-id"}, asked)
+	config, err := target.Nodes().GetConfigure("agent")
+	require.NoError(t, err)
+	assert.Contains(t, config, "<numExecutors>3</numExecutors>")
+	app, err := target.GetJob("team/app")
+	require.NoError(t, err)
+	config, err = app.GetConfigure()
+	require.NoError(t, err)
+	assert.Equal(t, migrateJobConf, config)
+	team, err := target.GetJob("team")
+	require.NoError(t, err)
+	_, err = team.Views().Get("mine")
+	require.NoError(t, err)
+	cred, err := team.Credentials().Get("user-id")
+	require.NoError(t, err)
+	assert.Equal(t, "user id for testing", cred.Description)
+	_, err = target.Views().Get("apps")
+	require.NoError(t, err)
+
+	// secret which is not returned fails the credential
+	dst2 := jenkinstest.NewServer()
+	defer dst2.Close()
+	other, err := New(dst2.URL, "admin", "1234")
+	require.NoError(t, err)
+	report, err = other.Restore(bytes.NewReader(archive.Bytes()), &RestoreOpts{Secrets: func(folder string, cred *CredentialJson) (map[string]string, error) {
+		return map[string]string{}, nil
+	}})
+	require.Error(t, err)
+	require.Len(t, report.Failures(), 2)
+	assert.Contains(t, report.Failures()[0].Err.Error(), "secret password of credential root-id")
+	_, err = other.Credentials().Get("root-id")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// existing items are kept
+	report, err = target.Restore(bytes.NewReader(archive.Bytes()), nil)
+	assert.ErrorIs(t, err, ErrAlreadyExists)
+	assert.Len(t, report.Failures(), 10)
+
+	_, err = target.Restore(strings.NewReader("not an archive"), nil)
+	assert.Error(t, err)
+}
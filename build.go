@@ -1,6 +1,7 @@
 package jenkins
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -9,6 +10,9 @@ import (
 	"time"
 )
 
+// interval between two polls of progressive log
+var progressiveLogInterval = 3 * time.Second
+
 type Build struct {
 	*Item
 	Number int
@@ -19,45 +23,77 @@ func NewBuild(url, class string, jenkins *Jenkins) *Build {
 }
 
 func (b *Build) IsBuilding() (bool, error) {
+	return b.IsBuildingCtx(context.Background())
+}
+
+func (b *Build) IsBuildingCtx(ctx context.Context) (bool, error) {
 	var build struct {
 		Class    string `json:"_class"`
 		Building bool   `json:"building"`
 	}
-	err := b.ApiJson(&build, &ApiJsonOpts{Tree: "building"})
+	err := b.ApiJsonCtx(ctx, &build, &ApiJsonOpts{Tree: "building"})
 	return build.Building, err
 }
 
 func (b *Build) GetResult() (string, error) {
+	return b.GetResultCtx(context.Background())
+}
+
+func (b *Build) GetResultCtx(ctx context.Context) (string, error) {
 	status := make(map[string]string)
-	err := b.ApiJson(&status, &ApiJsonOpts{Tree: "result"})
+	err := b.ApiJsonCtx(ctx, &status, &ApiJsonOpts{Tree: "result"})
 	return status["result"], err
 }
 
 func (b *Build) Delete() (*http.Response, error) {
-	return b.Request("POST", "doDelete", nil)
+	return b.DeleteCtx(context.Background())
+}
+
+func (b *Build) DeleteCtx(ctx context.Context) (*http.Response, error) {
+	return b.RequestCtx(ctx, "POST", "doDelete", nil)
 }
 
 func (b *Build) Stop() (*http.Response, error) {
-	return b.Request("POST", "stop", nil)
+	return b.StopCtx(context.Background())
+}
+
+func (b *Build) StopCtx(ctx context.Context) (*http.Response, error) {
+	return b.RequestCtx(ctx, "POST", "stop", nil)
 }
 
 func (b *Build) Kill() (*http.Response, error) {
-	return b.Request("POST", "kill", nil)
+	return b.KillCtx(context.Background())
+}
+
+func (b *Build) KillCtx(ctx context.Context) (*http.Response, error) {
+	return b.RequestCtx(ctx, "POST", "kill", nil)
 }
 
 func (b *Build) Term() (*http.Response, error) {
-	return b.Request("POST", "term", nil)
+	return b.TermCtx(context.Background())
+}
+
+func (b *Build) TermCtx(ctx context.Context) (*http.Response, error) {
+	return b.RequestCtx(ctx, "POST", "term", nil)
 }
 
 var re = regexp.MustCompile(`\w+[/]?$`)
 
 func (b *Build) GetJob() (*Job, error) {
+	return b.GetJobCtx(context.Background())
+}
+
+func (b *Build) GetJobCtx(ctx context.Context) (*Job, error) {
 	jobName, _ := b.jenkins.URL2Name(re.ReplaceAllLiteralString(b.URL, ""))
-	return b.jenkins.GetJob(jobName)
+	return b.jenkins.GetJobCtx(ctx, jobName)
 }
 
 func (b *Build) LoopLog(f func(line string) error) error {
-	resp, err := b.Request("GET", "consoleText", nil)
+	return b.LoopLogCtx(context.Background(), f)
+}
+
+func (b *Build) LoopLogCtx(ctx context.Context, f func(line string) error) error {
+	resp, err := b.RequestCtx(ctx, "GET", "consoleText", nil)
 	if err != nil {
 		return err
 	}
@@ -74,6 +110,19 @@ func scanResponse(resp *http.Response, f func(line string) error) error {
 }
 
 func (b *Build) LoopProgressiveLog(kind string, f func(line string) error) error {
+	return b.StreamLog(context.Background(), kind, f)
+}
+
+// Stream progressive log of build to f until build is completed, kind must
+// be html or text. It returns ctx.Err() once ctx is done:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+//	defer cancel()
+//	err := build.StreamLog(ctx, "text", func(line string) error {
+//		fmt.Print(line)
+//		return nil
+//	})
+func (b *Build) StreamLog(ctx context.Context, kind string, f func(line string) error) error {
 	var entry string
 	switch kind {
 	case "html":
@@ -81,39 +130,49 @@ func (b *Build) LoopProgressiveLog(kind string, f func(line string) error) error
 	case "text":
 		entry = "logText/progressiveText"
 	default:
-		panic("kind must be html or text")
+		return fmt.Errorf("kind must be html or text, got %q", kind)
 	}
 	start := "0"
 	for {
-		resp, err := b.Request("GET", fmt.Sprintf("%s?start=%s", entry, start), nil)
-		time.Sleep(3 * time.Second)
+		resp, err := b.RequestCtx(ctx, "GET", fmt.Sprintf("%s?start=%s", entry, start), nil)
 		if err != nil {
 			return err
 		}
-		if start == resp.Header.Get("X-Text-Size") {
-			continue
-		}
-		if err := scanResponse(resp, f); err != nil {
+		size := resp.Header.Get("X-Text-Size")
+		more := resp.Header.Get("X-More-Data") == "true"
+		if start == size {
+			resp.Body.Close()
+		} else if err := scanResponse(resp, f); err != nil {
 			return err
 		}
-		if resp.Header.Get("X-More-Data") != "true" {
-			break
+		if !more {
+			return nil
+		}
+		start = size
+		if err := sleepCtx(ctx, progressiveLogInterval); err != nil {
+			return err
 		}
-		start = resp.Header.Get("X-Text-Size")
 	}
-	return nil
 }
 
 func (b *Build) GetDescription() (string, error) {
+	return b.GetDescriptionCtx(context.Background())
+}
+
+func (b *Build) GetDescriptionCtx(ctx context.Context) (string, error) {
 	data := make(map[string]string)
-	if err := b.ApiJson(&data, &ApiJsonOpts{Tree: "description"}); err != nil {
+	if err := b.ApiJsonCtx(ctx, &data, &ApiJsonOpts{Tree: "description"}); err != nil {
 		return "", err
 	}
 	return data["description"], nil
 }
 
 func (b *Build) SetDescription(description string) (*http.Response, error) {
+	return b.SetDescriptionCtx(context.Background(), description)
+}
+
+func (b *Build) SetDescriptionCtx(ctx context.Context, description string) (*http.Response, error) {
 	v := url.Values{}
 	v.Add("description", description)
-	return b.Request("POST", "submitDescription?"+v.Encode(), nil)
+	return b.RequestCtx(ctx, "POST", "submitDescription?"+v.Encode(), nil)
 }
@@ -1,11 +1,15 @@
 package jenkins
 
 import (
-	"os"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/joelee2012/go-jenkins/jenkinstest"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -33,7 +37,7 @@ func setupBuild(t *testing.T) *Build {
 		return nil
 	})
 	assert.Nil(t, err)
-	assert.Contains(t, strings.Join(output, ""), os.Getenv("JENKINS_VERSION"))
+	assert.Contains(t, strings.Join(output, ""), jenkinsVersion)
 	return build
 }
 
@@ -85,6 +89,12 @@ func TestStopBuildItem(t *testing.T) {
 </flow-definition>`
 	_, err := pipeline.SetConfigure(strings.NewReader(conf))
 	assert.Nil(t, err)
+	if server != nil {
+		server.HandleBuild(func(job string, params url.Values) jenkinstest.BuildScript {
+			return jenkinstest.BuildScript{Running: true}
+		})
+		defer server.HandleBuild(echoBuild)
+	}
 
 	// start build to sleep 20s
 	qitem, err := pipeline.Build(nil)
@@ -118,3 +128,34 @@ func TestStopBuildItem(t *testing.T) {
 	_, err = pipeline.SetConfigure(strings.NewReader(jobConf))
 	assert.Nil(t, err)
 }
+
+func TestStreamLogCtxCancel(t *testing.T) {
+	var polls int
+	j := newFakeJenkins(t, func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		w.Header().Set("X-More-Data", "true")
+		w.Header().Set("X-Text-Size", fmt.Sprint(polls))
+		fmt.Fprintf(w, "line %d\n", polls)
+	})
+	build := NewBuild(j.URL+"job/pipeline/1/", "WorkflowRun", j)
+	ctx, cancel := context.WithCancel(context.Background())
+	var output []string
+	err := build.StreamLog(ctx, "text", func(line string) error {
+		output = append(output, line)
+		cancel()
+		return nil
+	})
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, []string{"line 1\n"}, output)
+
+	// completed build is not polled again
+	polls = 0
+	j = newFakeJenkins(t, func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		w.Header().Set("X-Text-Size", "0")
+	})
+	build = NewBuild(j.URL+"job/pipeline/1/", "WorkflowRun", j)
+	err = build.StreamLog(context.Background(), "text", func(line string) error { return nil })
+	assert.Nil(t, err)
+	assert.Equal(t, 1, polls)
+}
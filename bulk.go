@@ -0,0 +1,334 @@
+package jenkins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Selector yields jobs to run bulk operation on, failure of selection is
+// yielded as error.
+type Selector func(ctx context.Context, c *Jenkins) iter.Seq2[*Job, error]
+
+// Select jobs by full name, e.g. "path/to/job".
+func SelectJobs(fullNames ...string) Selector {
+	return func(ctx context.Context, c *Jenkins) iter.Seq2[*Job, error] {
+		return func(yield func(*Job, error) bool) {
+			for _, name := range fullNames {
+				if !yield(c.GetJobCtx(ctx, name)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Select jobs under folder, "" for all jobs, see Job.WalkCtx. Folders are
+// walked into but not selected, use SelectItems to select them too.
+func SelectFolder(folder string, opts *WalkOpts) Selector {
+	return func(ctx context.Context, c *Jenkins) iter.Seq2[*Job, error] {
+		return func(yield func(*Job, error) bool) {
+			for job, err := range SelectItems(folder, opts)(ctx, c) {
+				if err == nil && isFolder(job.Class) {
+					continue
+				}
+				if !yield(job, err) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Select jobs and folders under folder, a folder is selected before its
+// jobs. Operations like BulkDelete on folder affect jobs in it as well.
+func SelectItems(folder string, opts *WalkOpts) Selector {
+	return func(ctx context.Context, c *Jenkins) iter.Seq2[*Job, error] {
+		return NewJob(c.Name2URL(folder), "Folder", c).WalkCtx(ctx, opts)
+	}
+}
+
+// Select jobs whose full name matches pattern as path.Match does, e.g.
+// "team/*/deploy-*". Only folders which may contain matching jobs are
+// fetched.
+func SelectGlob(pattern string) Selector {
+	pattern = strings.Trim(pattern, "/")
+	return func(ctx context.Context, c *Jenkins) iter.Seq2[*Job, error] {
+		return func(yield func(*Job, error) bool) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				yield(nil, err)
+				return
+			}
+			segments := strings.Split(pattern, "/")
+			// walk from the deepest folder without meta characters
+			n := 0
+			for n < len(segments)-1 && !hasMeta(segments[n]) {
+				n++
+			}
+			if !hasMeta(segments[n]) {
+				yield(c.GetJobCtx(ctx, pattern))
+				return
+			}
+			opts := &WalkOpts{
+				MaxDepth: len(segments) - n,
+				Skip: func(job *Job) bool {
+					depth := strings.Count(job.FullName, "/") + 1
+					ok, _ := path.Match(strings.Join(segments[:depth], "/"), job.FullName)
+					return !ok
+				},
+			}
+			folder := NewJob(c.Name2URL(strings.Join(segments[:n], "/")), "Folder", c)
+			for job, err := range folder.WalkCtx(ctx, opts) {
+				if err != nil {
+					if !yield(nil, err) {
+						return
+					}
+					continue
+				}
+				if ok, _ := path.Match(pattern, job.FullName); ok && !yield(job, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func hasMeta(s string) bool {
+	return strings.ContainsAny(s, `*?[\`)
+}
+
+// Operation is run on every selected job by Bulk.
+type Operation func(ctx context.Context, job *Job) error
+
+func BulkDisable() Operation {
+	return func(ctx context.Context, job *Job) error {
+		_, err := job.DisableCtx(ctx)
+		return err
+	}
+}
+
+func BulkEnable() Operation {
+	return func(ctx context.Context, job *Job) error {
+		_, err := job.EnableCtx(ctx)
+		return err
+	}
+}
+
+func BulkDelete() Operation {
+	return func(ctx context.Context, job *Job) error {
+		_, err := job.DeleteCtx(ctx)
+		return err
+	}
+}
+
+func BulkSetDescription(description string) Operation {
+	return func(ctx context.Context, job *Job) error {
+		_, err := job.SetDescriptionCtx(ctx, description)
+		return err
+	}
+}
+
+// Trigger build with params, the queue item is not waited for.
+func BulkBuild(params url.Values) Operation {
+	return func(ctx context.Context, job *Job) error {
+		_, err := job.BuildCtx(ctx, params)
+		return err
+	}
+}
+
+// Delete builds matched by opts, e.g. older than a week:
+//
+//	jenkins.BulkDeleteBuilds(&jenkins.HistoryOpts{Until: time.Now().AddDate(0, 0, -7)})
+//
+// Running builds are kept.
+func BulkDeleteBuilds(opts *HistoryOpts) Operation {
+	return func(ctx context.Context, job *Job) error {
+		builds, err := job.ListAllBuildsCtx(ctx, opts)
+		if err != nil {
+			return err
+		}
+		var errs []error
+		for _, b := range builds {
+			if b.Building {
+				continue
+			}
+			if _, err := NewBuild(b.URL, b.Class, job.jenkins).DeleteCtx(ctx); err != nil {
+				errs = append(errs, err)
+				if ctx.Err() != nil {
+					break
+				}
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
+
+// DefaultBulkWorkers is the number of workers of Bulk if BulkOpts.Workers
+// is 0
+const DefaultBulkWorkers = 4
+
+type BulkOpts struct {
+	Workers int
+	// stop at the first failure, jobs not run yet are skipped
+	FailFast bool
+	// select jobs without running operation, all of them are skipped
+	DryRun bool
+}
+
+// BulkResult is the result of operation on one job, Job is nil if selection
+// failed.
+type BulkResult struct {
+	Job      *Job
+	Err      error
+	Skipped  bool
+	Duration time.Duration
+}
+
+// BulkReport lists results in order of selection.
+type BulkReport struct {
+	Results   []*BulkResult
+	Succeeded int
+	Failed    int
+	Skipped   int
+}
+
+// Failures returns results with error.
+func (r *BulkReport) Failures() []*BulkResult {
+	var failures []*BulkResult
+	for _, result := range r.Results {
+		if result.Err != nil {
+			failures = append(failures, result)
+		}
+	}
+	return failures
+}
+
+// Err joins errors of failures, it is nil if there is no failure.
+func (r *BulkReport) Err() error {
+	var errs []error
+	for _, result := range r.Failures() {
+		if result.Job != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", result.Job.FullName, result.Err))
+		} else {
+			errs = append(errs, result.Err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c *Jenkins) Bulk(sel Selector, op Operation, opts *BulkOpts) (*BulkReport, error) {
+	return c.BulkCtx(context.Background(), sel, op, opts)
+}
+
+// Run op on jobs selected by sel with parallel workers, e.g. disable all
+// jobs under folder:
+//
+//	report, err := jenkins.Bulk(jenkins.SelectFolder("folder", nil), jenkins.BulkDisable(), nil)
+//	for _, failure := range report.Failures() {
+//		fmt.Println(failure.Job, failure.Err)
+//	}
+//
+// Report is always returned, error is the first failure with FailFast,
+// Report.Err() otherwise, or error of ctx.
+func (c *Jenkins) BulkCtx(ctx context.Context, sel Selector, op Operation, opts *BulkOpts) (*BulkReport, error) {
+	var o BulkOpts
+	if opts != nil {
+		o = *opts
+	}
+	if o.Workers <= 0 {
+		o.Workers = DefaultBulkWorkers
+	}
+	var (
+		mu       sync.Mutex
+		firstErr error
+		// closed at the first failure with FailFast, running operations
+		// are not interrupted
+		stop     = make(chan struct{})
+		stopOnce sync.Once
+	)
+	fail := func(err error) {
+		mu.Lock()
+		if firstErr == nil {
+			firstErr = err
+		}
+		mu.Unlock()
+		if o.FailFast {
+			stopOnce.Do(func() { close(stop) })
+		}
+	}
+	stopped := func() bool {
+		select {
+		case <-stop:
+			return true
+		default:
+			return ctx.Err() != nil
+		}
+	}
+	report := &BulkReport{}
+	work := make(chan *BulkResult)
+	var wg sync.WaitGroup
+	for range o.Workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for result := range work {
+				if stopped() {
+					result.Skipped = true
+					continue
+				}
+				start := time.Now()
+				result.Err = op(ctx, result.Job)
+				result.Duration = time.Since(start)
+				if result.Err != nil {
+					fail(result.Err)
+				}
+			}
+		}()
+	}
+	for job, err := range sel(ctx, c) {
+		result := &BulkResult{Job: job, Err: err}
+		report.Results = append(report.Results, result)
+		if err != nil {
+			fail(err)
+		} else if o.DryRun {
+			result.Skipped = true
+		} else {
+			select {
+			case work <- result:
+			case <-stop:
+				result.Skipped = true
+			case <-ctx.Done():
+				result.Skipped = true
+			}
+		}
+		if stopped() {
+			break
+		}
+	}
+	close(work)
+	wg.Wait()
+
+	for _, result := range report.Results {
+		switch {
+		case result.Err != nil:
+			report.Failed++
+		case result.Skipped:
+			report.Skipped++
+		default:
+			report.Succeeded++
+		}
+	}
+	if o.FailFast && firstErr != nil {
+		return report, firstErr
+	}
+	if err := report.Err(); err != nil {
+		return report, err
+	}
+	return report, ctx.Err()
+}
@@ -0,0 +1,143 @@
+package jenkins
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/joelee2012/go-jenkins/jenkinstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBulkJenkins(t *testing.T) (*jenkinstest.Server, *Jenkins) {
+	srv := jenkinstest.NewServer()
+	t.Cleanup(srv.Close)
+	for _, name := range []string{"team", "team/app", "team/lib", "team/lib/nested"} {
+		require.NoError(t, srv.CreateJob(name, folderConf))
+	}
+	for _, name := range []string{"team/app/build", "team/app/deploy-dev", "team/app/deploy-prod",
+		"team/lib/deploy-dev", "team/lib/nested/deploy-dev", "other"} {
+		require.NoError(t, srv.CreateJob(name, jobConf))
+	}
+	client, err := New(srv.URL, "admin", "1234")
+	require.NoError(t, err)
+	return srv, client
+}
+
+func fullNames(report *BulkReport) []string {
+	var names []string
+	for _, result := range report.Results {
+		if result.Job != nil {
+			names = append(names, result.Job.FullName)
+		}
+	}
+	return names
+}
+
+func TestBulkSelect(t *testing.T) {
+	_, client := newBulkJenkins(t)
+	dryRun := &BulkOpts{DryRun: true}
+	var tests = []struct {
+		sel    Selector
+		expect []string
+	}{
+		{SelectGlob("team/*/deploy-*"), []string{"team/app/deploy-dev", "team/app/deploy-prod", "team/lib/deploy-dev"}},
+		{SelectGlob("/team/app/deploy-?ev"), []string{"team/app/deploy-dev"}},
+		{SelectGlob("*"), []string{"team", "other"}},
+		{SelectGlob("team/app/build"), []string{"team/app/build"}},
+		{SelectFolder("team/lib", nil), []string{"team/lib/nested/deploy-dev", "team/lib/deploy-dev"}},
+		{SelectFolder("", &WalkOpts{MaxDepth: 1}), []string{"other"}},
+		{SelectItems("team/lib", nil), []string{"team/lib/nested", "team/lib/nested/deploy-dev", "team/lib/deploy-dev"}},
+		{SelectItems("", &WalkOpts{MaxDepth: 1}), []string{"team", "other"}},
+		{SelectJobs("other", "team/app"), []string{"other", "team/app"}},
+	}
+	for _, test := range tests {
+		report, err := client.Bulk(test.sel, func(ctx context.Context, job *Job) error {
+			t.Errorf("%s is operated in dry run", job)
+			return nil
+		}, dryRun)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expect, fullNames(report))
+		assert.Equal(t, len(test.expect), report.Skipped)
+	}
+
+	_, err := client.Bulk(SelectGlob("team/[a"), BulkDisable(), dryRun)
+	assert.Error(t, err)
+}
+
+func TestBulkOperations(t *testing.T) {
+	srv, client := newBulkJenkins(t)
+	report, err := client.Bulk(SelectGlob("team/*/deploy-*"), BulkDisable(), &BulkOpts{Workers: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 3, report.Succeeded)
+	for _, name := range []string{"team/app/deploy-dev", "team/lib/deploy-dev", "team/lib/nested/deploy-dev"} {
+		job, err := client.GetJob(name)
+		require.NoError(t, err)
+		buildable, err := job.IsBuildable()
+		require.NoError(t, err)
+		assert.Equal(t, name == "team/lib/nested/deploy-dev", buildable, name)
+	}
+
+	// continue on error
+	report, err = client.Bulk(SelectJobs("other", "missing", "team/app/build"), BulkSetDescription("bulk"), nil)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, 2, report.Succeeded)
+	assert.Equal(t, 1, report.Failed)
+	assert.Len(t, report.Failures(), 1)
+	description, err := report.Results[2].Job.GetDescription()
+	require.NoError(t, err)
+	assert.Equal(t, "bulk", description)
+
+	// trigger and delete failed builds
+	srv.HandleBuild(func(job string, params url.Values) jenkinstest.BuildScript {
+		return jenkinstest.BuildScript{Result: params.Get("ARG1")}
+	})
+	for _, result := range []string{"SUCCESS", "FAILURE", "FAILURE"} {
+		_, err = client.Bulk(SelectJobs("other"), BulkBuild(url.Values{"ARG1": {result}}), nil)
+		require.NoError(t, err)
+	}
+	queue, err := client.Queue().List()
+	require.NoError(t, err)
+	for _, item := range queue {
+		_, err := item.GetBuild()
+		require.NoError(t, err)
+	}
+	_, err = client.Bulk(SelectJobs("other"), BulkDeleteBuilds(&HistoryOpts{Results: []string{"FAILURE"}}), nil)
+	require.NoError(t, err)
+	other, err := client.GetJob("other")
+	require.NoError(t, err)
+	builds, err := other.ListAllBuilds(nil)
+	require.NoError(t, err)
+	require.Len(t, builds, 1)
+	assert.Equal(t, "SUCCESS", builds[0].Result)
+
+	// folders are kept
+	report, err = client.Bulk(SelectFolder("team/lib", nil), BulkDelete(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Succeeded)
+	exists, err := client.JobExists("team/lib/nested")
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestBulkFailFast(t *testing.T) {
+	_, client := newBulkJenkins(t)
+	boom := errors.New("boom")
+	var runs atomic.Int32
+	report, err := client.Bulk(SelectFolder("", nil), func(ctx context.Context, job *Job) error {
+		runs.Add(1)
+		return boom
+	}, &BulkOpts{Workers: 1, FailFast: true})
+	assert.ErrorIs(t, err, boom)
+	assert.Equal(t, int32(1), runs.Load())
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, len(report.Results)-1, report.Skipped)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.BulkCtx(ctx, SelectJobs("other"), BulkDisable(), nil)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,236 @@
+package jenkins
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheOpts configures the opt-in cache of api/json responses. Responses
+// with ETag or Last-Modified are revalidated with a conditional GET on every
+// use, others are reused until their TTL expires.
+type CacheOpts struct {
+	// TTL of responses without validators, they are not cached if 0
+	TTL time.Duration
+	// TTL by endpoint, which overrides TTL, endpoint is normalized as
+	// metrics does, e.g. "/job/{job}/api/json"
+	EndpointTTL map[string]time.Duration
+	// maximum number of cached responses, unlimited if 0
+	MaxEntries int
+}
+
+// Cache api/json responses, see CacheOpts
+func WithCache(opts CacheOpts) Option {
+	return func(o *JenkinsOpts) error {
+		o.Cache = &opts
+		return nil
+	}
+}
+
+type CacheStats struct {
+	// served from cache without contacting jenkins
+	Hits uint64
+	// confirmed by jenkins with 304 Not Modified
+	Revalidated uint64
+	Misses      uint64
+	// entries dropped by mutating requests
+	Invalidations uint64
+	Entries       int
+}
+
+type cacheEntry struct {
+	// url of item which the entry belongs to
+	item         string
+	header       http.Header
+	body         []byte
+	etag         string
+	lastModified string
+	expires      time.Time
+	stored       time.Time
+}
+
+func (e *cacheEntry) hasValidator() bool {
+	return e.etag != "" || e.lastModified != ""
+}
+
+type cache struct {
+	opts    CacheOpts
+	base    string
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+	stats   CacheStats
+}
+
+func newCache(opts CacheOpts, baseURL string) *cache {
+	base := "/"
+	if u, err := url.Parse(baseURL); err == nil {
+		base = appendSlash(u.Path)
+	}
+	return &cache{opts: opts, base: base, entries: map[string]*cacheEntry{}}
+}
+
+// Stats of api/json cache, it is zero if cache is not enabled by WithCache.
+func (c *Jenkins) CacheStats() CacheStats {
+	if c.cache == nil {
+		return CacheStats{}
+	}
+	c.cache.mu.Lock()
+	defer c.cache.mu.Unlock()
+	stats := c.cache.stats
+	stats.Entries = len(c.cache.entries)
+	return stats
+}
+
+func (c *cache) ttl(u *url.URL) time.Duration {
+	if ttl, ok := c.opts.EndpointTTL[normalizeEndpoint(c.base, u.Path)]; ok {
+		return ttl
+	}
+	return c.opts.TTL
+}
+
+func cacheable(req *http.Request) bool {
+	return req.Method == "GET" &&
+		strings.HasSuffix(req.URL.Path, "/api/json") &&
+		// crumb is re-fetched after being rejected, it must be fresh
+		!strings.HasSuffix(req.URL.Path, "/crumbIssuer/api/json")
+}
+
+func (c *cache) interceptor(next Doer) Doer {
+	if c == nil {
+		return next
+	}
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+		if !cacheable(req) {
+			resp, err := next.Do(req)
+			if req.Method != "GET" && req.Method != "HEAD" {
+				c.invalidate(req)
+			}
+			return resp, err
+		}
+		key := req.URL.String()
+		now := time.Now()
+		c.mu.Lock()
+		entry := c.entries[key]
+		if entry != nil && !entry.hasValidator() && now.Before(entry.expires) {
+			c.stats.Hits++
+			c.mu.Unlock()
+			return entry.response(req), nil
+		}
+		c.mu.Unlock()
+
+		if entry != nil && entry.hasValidator() {
+			req = req.Clone(req.Context())
+			if entry.etag != "" {
+				req.Header.Set("If-None-Match", entry.etag)
+			}
+			if entry.lastModified != "" {
+				req.Header.Set("If-Modified-Since", entry.lastModified)
+			}
+		}
+		resp, err := next.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusNotModified && entry != nil {
+			resp.Body.Close()
+			c.mu.Lock()
+			c.stats.Revalidated++
+			c.mu.Unlock()
+			return entry.response(req), nil
+		}
+		c.mu.Lock()
+		c.stats.Misses++
+		c.mu.Unlock()
+		if resp.StatusCode != http.StatusOK {
+			return resp, nil
+		}
+		return c.store(key, req, resp, now)
+	})
+}
+
+func (c *cache) store(key string, req *http.Request, resp *http.Response, now time.Time) (*http.Response, error) {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	entry := &cacheEntry{
+		item:         strings.TrimSuffix(req.URL.Path, "api/json"),
+		header:       resp.Header.Clone(),
+		body:         body,
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+		stored:       now,
+	}
+	ttl := c.ttl(req.URL)
+	if !entry.hasValidator() && ttl <= 0 {
+		return resp, nil
+	}
+	entry.expires = now.Add(ttl)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; !ok && c.opts.MaxEntries > 0 && len(c.entries) >= c.opts.MaxEntries {
+		c.evictOldest()
+	}
+	c.entries[key] = entry
+	return resp, nil
+}
+
+func (c *cache) evictOldest() {
+	var oldest string
+	for key, entry := range c.entries {
+		if oldest == "" || entry.stored.Before(c.entries[oldest].stored) {
+			oldest = key
+		}
+	}
+	delete(c.entries, oldest)
+}
+
+// drop entries of the item which sent req, its ancestors, whose listing may
+// change, and its descendants. All entries are dropped if item is unknown.
+func (c *cache) invalidate(req *http.Request) {
+	itemURL := ""
+	if item := ItemFromContext(req.Context()); item != nil {
+		itemURL = item.URL
+	}
+	c.invalidateItem(itemURL)
+}
+
+// drop entries of item at itemURL, its ancestors and descendants, it is used
+// directly when a request changes items other than the one sending it
+func (c *cache) invalidateItem(itemURL string) {
+	if c == nil {
+		return
+	}
+	prefix := ""
+	if u, err := url.Parse(itemURL); err == nil {
+		prefix = u.Path
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, entry := range c.entries {
+		if strings.HasPrefix(entry.item, prefix) || strings.HasPrefix(prefix, entry.item) {
+			delete(c.entries, key)
+			c.stats.Invalidations++
+		}
+	}
+}
+
+func (e *cacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,129 @@
+package jenkins
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newCacheJenkins(t *testing.T, opts CacheOpts) (*Jenkins, map[string]int) {
+	var mu sync.Mutex
+	hits := map[string]int{}
+	j := newFakeJenkins(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hits[r.Method+" "+r.URL.Path]++
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/job/etag/api/json":
+			w.Header().Set("ETag", `"v1"`)
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		case "/job/modified/api/json":
+			w.Header().Set("Last-Modified", "Mon, 01 Jan 2024 00:00:00 GMT")
+			if r.Header.Get("If-Modified-Since") != "" {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+		w.Write([]byte(`{"description":"` + r.URL.Path + `"}`))
+	})
+	j.cache = newCache(opts, j.URL)
+	return j, hits
+}
+
+func TestCacheTTL(t *testing.T) {
+	j, hits := newCacheJenkins(t, CacheOpts{TTL: time.Minute})
+	a := NewJob(j.Name2URL("a"), "WorkflowJob", j)
+	b := NewJob(j.Name2URL("b"), "WorkflowJob", j)
+	for i := 0; i < 3; i++ {
+		desc, err := a.GetDescription()
+		assert.Nil(t, err)
+		assert.Equal(t, "/job/a/api/json", desc)
+		_, err = b.GetDescription()
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, 1, hits["GET /job/a/api/json"])
+	assert.Equal(t, CacheStats{Hits: 4, Misses: 2, Entries: 2}, j.CacheStats())
+
+	// mutation drops entries of a only
+	_, err := a.Disable()
+	assert.Nil(t, err)
+	_, err = a.GetDescription()
+	assert.Nil(t, err)
+	_, err = b.GetDescription()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, hits["GET /job/a/api/json"])
+	assert.Equal(t, 1, hits["GET /job/b/api/json"])
+	assert.Equal(t, uint64(1), j.CacheStats().Invalidations)
+
+	// query is part of key
+	_, err = a.IsBuildable()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, hits["GET /job/a/api/json"])
+}
+
+func TestCacheInvalidateAncestor(t *testing.T) {
+	j, hits := newCacheJenkins(t, CacheOpts{TTL: time.Minute})
+	folder := NewJob(j.Name2URL("folder"), "Folder", j)
+	child := NewJob(j.Name2URL("folder/child"), "WorkflowJob", j)
+	other := NewJob(j.Name2URL("other"), "WorkflowJob", j)
+	for _, job := range []*Job{folder, child, other} {
+		_, err := job.GetDescription()
+		assert.Nil(t, err)
+	}
+	_, err := child.Delete()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), j.CacheStats().Invalidations)
+	assert.Equal(t, 1, j.CacheStats().Entries)
+
+	// descendants are dropped too
+	_, err = child.GetDescription()
+	assert.Nil(t, err)
+	_, err = folder.Disable()
+	assert.Nil(t, err)
+	_, err = child.GetDescription()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, hits["GET /job/folder/job/child/api/json"])
+}
+
+func TestCacheValidators(t *testing.T) {
+	j, hits := newCacheJenkins(t, CacheOpts{})
+	for _, name := range []string{"etag", "modified"} {
+		job := NewJob(j.Name2URL(name), "WorkflowJob", j)
+		for i := 0; i < 3; i++ {
+			desc, err := job.GetDescription()
+			assert.Nil(t, err)
+			assert.Equal(t, "/job/"+name+"/api/json", desc)
+		}
+		assert.Equal(t, 3, hits["GET /job/"+name+"/api/json"])
+	}
+	// no validator and no ttl is not cached
+	_, err := NewJob(j.Name2URL("a"), "WorkflowJob", j).GetDescription()
+	assert.Nil(t, err)
+	assert.Equal(t, CacheStats{Revalidated: 4, Misses: 3, Entries: 2}, j.CacheStats())
+}
+
+func TestCacheEndpointTTL(t *testing.T) {
+	j, hits := newCacheJenkins(t, CacheOpts{
+		TTL:         time.Minute,
+		EndpointTTL: map[string]time.Duration{"/job/{job}/api/json": 0},
+		MaxEntries:  1,
+	})
+	for i := 0; i < 2; i++ {
+		_, err := NewJob(j.Name2URL("a"), "WorkflowJob", j).GetDescription()
+		assert.Nil(t, err)
+		data := map[string]string{}
+		assert.Nil(t, j.ApiJson(&data, nil))
+	}
+	assert.Equal(t, 2, hits["GET /job/a/api/json"])
+	assert.Equal(t, 1, hits["GET /api/json"])
+	assert.Equal(t, 1, j.CacheStats().Entries)
+
+	var cold Jenkins
+	assert.Equal(t, CacheStats{}, cold.CacheStats())
+}
@@ -0,0 +1,392 @@
+package jenkins
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// Types below model config.xml of common job classes. Elements and
+// attributes which are not modeled are kept in Extra and Attrs so they
+// survive a round trip. Config decoded by DecodeConfig or GetConfig keeps
+// its source, EncodeConfig writes the source back with modified elements
+// only, so order of elements, declaration and formatting are kept.
+//
+//	config, err := jenkins.DecodeConfig[jenkins.WorkflowJobConfig](xml)
+//	if err != nil {
+//		return err
+//	}
+//	config.Description = "built by go-jenkins"
+//	job.SetConfig(config)
+
+// ConfigSource keeps config.xml which config is decoded from, it is
+// embedded in config of job.
+type ConfigSource struct {
+	source string
+}
+
+func (s ConfigSource) configSource() string {
+	return s.source
+}
+
+func (s *ConfigSource) setConfigSource(text string) {
+	s.source = text
+}
+
+// RawElement is an element kept as is, e.g. settings of unknown plugin.
+type RawElement struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Inner   string     `xml:",innerxml"`
+}
+
+// ElementList is a list of elements of different classes, e.g. builders, the
+// name of each element is XMLName of T.
+type ElementList[T any] []T
+
+func (l ElementList[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, item := range l {
+		if err := e.Encode(item); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+func (l *ElementList[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			var item T
+			if err := d.DecodeElement(&item, &t); err != nil {
+				return err
+			}
+			*l = append(*l, item)
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// WorkflowJobConfig is config of pipeline job, root element flow-definition.
+type WorkflowJobConfig struct {
+	ConfigSource
+	XMLName     xml.Name        `xml:"flow-definition"`
+	Attrs       []xml.Attr      `xml:",any,attr"`
+	Description string          `xml:"description"`
+	Properties  *JobProperties  `xml:"properties"`
+	Definition  *FlowDefinition `xml:"definition"`
+	Disabled    bool            `xml:"disabled"`
+	Extra       []RawElement    `xml:",any"`
+}
+
+// FlowDefinition is either inline script, class CpsFlowDefinition, or
+// Jenkinsfile from SCM, class CpsScmFlowDefinition.
+type FlowDefinition struct {
+	Class       string       `xml:"class,attr"`
+	Attrs       []xml.Attr   `xml:",any,attr"`
+	Script      string       `xml:"script,omitempty"`
+	Sandbox     bool         `xml:"sandbox"`
+	SCM         *SCM         `xml:"scm"`
+	ScriptPath  string       `xml:"scriptPath,omitempty"`
+	Lightweight bool         `xml:"lightweight"`
+	Extra       []RawElement `xml:",any"`
+}
+
+const (
+	CpsFlowDefinition    = "org.jenkinsci.plugins.workflow.cps.CpsFlowDefinition"
+	CpsScmFlowDefinition = "org.jenkinsci.plugins.workflow.cps.CpsScmFlowDefinition"
+	GitSCMClass          = "hudson.plugins.git.GitSCM"
+	NullSCMClass         = "hudson.scm.NullSCM"
+)
+
+// SCM models GitSCM, other classes are kept in Extra.
+type SCM struct {
+	Class             string                 `xml:"class,attr"`
+	Attrs             []xml.Attr             `xml:",any,attr"`
+	UserRemoteConfigs ElementList[GitRemote] `xml:"userRemoteConfigs,omitempty"`
+	Branches          ElementList[GitBranch] `xml:"branches,omitempty"`
+	Extra             []RawElement           `xml:",any"`
+}
+
+type GitRemote struct {
+	XMLName       xml.Name     `xml:"hudson.plugins.git.UserRemoteConfig"`
+	URL           string       `xml:"url"`
+	CredentialsID string       `xml:"credentialsId,omitempty"`
+	Extra         []RawElement `xml:",any"`
+}
+
+type GitBranch struct {
+	XMLName xml.Name `xml:"hudson.plugins.git.BranchSpec"`
+	Name    string   `xml:"name"`
+}
+
+// JobProperties models common properties, others are kept in Extra.
+type JobProperties struct {
+	Parameters              *ParametersProperty              `xml:"hudson.model.ParametersDefinitionProperty"`
+	BuildDiscarder          *BuildDiscarderProperty          `xml:"jenkins.model.BuildDiscarderProperty"`
+	DisableConcurrentBuilds *DisableConcurrentBuildsProperty `xml:"org.jenkinsci.plugins.workflow.job.properties.DisableConcurrentBuildsJobProperty"`
+	Extra                   []RawElement                     `xml:",any"`
+}
+
+type ParametersProperty struct {
+	Attrs       []xml.Attr            `xml:",any,attr"`
+	Definitions ElementList[ParamDef] `xml:"parameterDefinitions"`
+	Extra       []RawElement          `xml:",any"`
+}
+
+// ParamDef is a parameter definition, XMLName is its class, e.g.
+// hudson.model.StringParameterDefinition. Choices of choice parameter are
+// kept in Extra.
+type ParamDef struct {
+	XMLName      xml.Name
+	Attrs        []xml.Attr   `xml:",any,attr"`
+	Name         string       `xml:"name"`
+	Description  string       `xml:"description,omitempty"`
+	DefaultValue string       `xml:"defaultValue,omitempty"`
+	Extra        []RawElement `xml:",any"`
+}
+
+func StringParam(name, defaultValue, description string) ParamDef {
+	return ParamDef{
+		XMLName:      xml.Name{Local: "hudson.model.StringParameterDefinition"},
+		Name:         name,
+		DefaultValue: defaultValue,
+		Description:  description,
+	}
+}
+
+type BuildDiscarderProperty struct {
+	Attrs    []xml.Attr   `xml:",any,attr"`
+	Strategy *LogRotator  `xml:"strategy"`
+	Extra    []RawElement `xml:",any"`
+}
+
+// LogRotator keeps builds and artifacts for days or numbers, -1 for
+// unlimited.
+type LogRotator struct {
+	Class              string       `xml:"class,attr"`
+	DaysToKeep         int          `xml:"daysToKeep"`
+	NumToKeep          int          `xml:"numToKeep"`
+	ArtifactDaysToKeep int          `xml:"artifactDaysToKeep"`
+	ArtifactNumToKeep  int          `xml:"artifactNumToKeep"`
+	Extra              []RawElement `xml:",any"`
+}
+
+func NewLogRotator(daysToKeep, numToKeep int) *LogRotator {
+	return &LogRotator{
+		Class:              "hudson.tasks.LogRotator",
+		DaysToKeep:         daysToKeep,
+		NumToKeep:          numToKeep,
+		ArtifactDaysToKeep: -1,
+		ArtifactNumToKeep:  -1,
+	}
+}
+
+type DisableConcurrentBuildsProperty struct {
+	Attrs         []xml.Attr   `xml:",any,attr"`
+	AbortPrevious bool         `xml:"abortPrevious"`
+	Extra         []RawElement `xml:",any"`
+}
+
+// FreeStyleProjectConfig is config of freestyle job, root element project.
+type FreeStyleProjectConfig struct {
+	ConfigSource
+	XMLName         xml.Name               `xml:"project"`
+	Attrs           []xml.Attr             `xml:",any,attr"`
+	Description     string                 `xml:"description"`
+	Properties      *JobProperties         `xml:"properties"`
+	SCM             *SCM                   `xml:"scm"`
+	Disabled        bool                   `xml:"disabled"`
+	Triggers        ElementList[Trigger]   `xml:"triggers"`
+	ConcurrentBuild bool                   `xml:"concurrentBuild"`
+	Builders        ElementList[BuildStep] `xml:"builders"`
+	Publishers      ElementList[BuildStep] `xml:"publishers"`
+	Extra           []RawElement           `xml:",any"`
+}
+
+// Trigger, XMLName is its class, e.g. hudson.triggers.TimerTrigger.
+type Trigger struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr   `xml:",any,attr"`
+	Spec    string       `xml:"spec,omitempty"`
+	Extra   []RawElement `xml:",any"`
+}
+
+func TimerTrigger(spec string) Trigger {
+	return Trigger{XMLName: xml.Name{Local: "hudson.triggers.TimerTrigger"}, Spec: spec}
+}
+
+// BuildStep is a builder or publisher, XMLName is its class, e.g.
+// hudson.tasks.Shell.
+type BuildStep struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr   `xml:",any,attr"`
+	Command string       `xml:"command,omitempty"`
+	Extra   []RawElement `xml:",any"`
+}
+
+func ShellStep(command string) BuildStep {
+	return BuildStep{XMLName: xml.Name{Local: "hudson.tasks.Shell"}, Command: command}
+}
+
+// FolderConfig is config of folder, root element
+// com.cloudbees.hudson.plugins.folder.Folder.
+type FolderConfig struct {
+	ConfigSource
+	XMLName     xml.Name       `xml:"com.cloudbees.hudson.plugins.folder.Folder"`
+	Attrs       []xml.Attr     `xml:",any,attr"`
+	Description string         `xml:"description"`
+	DisplayName string         `xml:"displayName,omitempty"`
+	Properties  *JobProperties `xml:"properties"`
+	Extra       []RawElement   `xml:",any"`
+}
+
+// MultiBranchProjectConfig is config of multibranch pipeline, root element
+// org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject.
+type MultiBranchProjectConfig struct {
+	ConfigSource
+	XMLName              xml.Name              `xml:"org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject"`
+	Attrs                []xml.Attr            `xml:",any,attr"`
+	Description          string                `xml:"description"`
+	DisplayName          string                `xml:"displayName,omitempty"`
+	Properties           *JobProperties        `xml:"properties"`
+	OrphanedItemStrategy *OrphanedItemStrategy `xml:"orphanedItemStrategy"`
+	Triggers             ElementList[Trigger]  `xml:"triggers"`
+	Disabled             bool                  `xml:"disabled"`
+	Sources              *BranchSources        `xml:"sources"`
+	Factory              *BranchProjectFactory `xml:"factory"`
+	Extra                []RawElement          `xml:",any"`
+}
+
+type OrphanedItemStrategy struct {
+	Class             string       `xml:"class,attr"`
+	Attrs             []xml.Attr   `xml:",any,attr"`
+	PruneDeadBranches bool         `xml:"pruneDeadBranches"`
+	DaysToKeep        int          `xml:"daysToKeep"`
+	NumToKeep         int          `xml:"numToKeep"`
+	Extra             []RawElement `xml:",any"`
+}
+
+type BranchSources struct {
+	Class   string         `xml:"class,attr"`
+	Attrs   []xml.Attr     `xml:",any,attr"`
+	Sources []BranchSource `xml:"data>jenkins.branch.BranchSource"`
+	Extra   []RawElement   `xml:",any"`
+}
+
+type BranchSource struct {
+	Source *SCMSource   `xml:"source"`
+	Extra  []RawElement `xml:",any"`
+}
+
+// SCMSource, e.g. jenkins.plugins.git.GitSCMSource.
+type SCMSource struct {
+	Class         string       `xml:"class,attr"`
+	Attrs         []xml.Attr   `xml:",any,attr"`
+	ID            string       `xml:"id"`
+	Remote        string       `xml:"remote,omitempty"`
+	CredentialsID string       `xml:"credentialsId,omitempty"`
+	Extra         []RawElement `xml:",any"`
+}
+
+type BranchProjectFactory struct {
+	Class      string       `xml:"class,attr"`
+	Attrs      []xml.Attr   `xml:",any,attr"`
+	ScriptPath string       `xml:"scriptPath,omitempty"`
+	Extra      []RawElement `xml:",any"`
+}
+
+// encoding/xml supports XML 1.0 only, config of jenkins is declared as 1.1
+var xmlVersion = regexp.MustCompile(`^(\s*<\?xml[^>]*version=['"])1\.1(['"])`)
+
+// DecodeConfig decodes config.xml into T, e.g. WorkflowJobConfig.
+func DecodeConfig[T any](text string) (*T, error) {
+	v := new(T)
+	if err := decodeXML(text, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func decodeXML(text string, v any) error {
+	if err := xml.Unmarshal([]byte(xmlVersion.ReplaceAllString(text, "${1}1.0${2}")), v); err != nil {
+		return err
+	}
+	if s, ok := v.(interface{ setConfigSource(string) }); ok {
+		s.setConfigSource(text)
+	}
+	return nil
+}
+
+// EncodeConfig encodes config v as config.xml, config decoded from source
+// is written as its source with changes merged into it.
+func EncodeConfig(v any) (string, error) {
+	data, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	text := xml.Header + string(data)
+	s, ok := v.(interface{ configSource() string })
+	if !ok || s.configSource() == "" {
+		return text, nil
+	}
+	src, err := parseXMLDoc(s.configSource())
+	if err != nil {
+		return "", err
+	}
+	doc, err := parseXMLDoc(text)
+	if err != nil {
+		return "", err
+	}
+	root, other := rootElement(src), rootElement(doc)
+	if root == nil || root.name != other.name {
+		return text, nil
+	}
+	root.merge(other)
+	return src.String(), nil
+}
+
+func rootElement(doc *xmlElement) *xmlElement {
+	for _, child := range doc.children {
+		if child.elem != nil {
+			return child.elem
+		}
+	}
+	return nil
+}
+
+// Get config.xml of job and decode it into v, e.g. *WorkflowJobConfig.
+func (j *Job) GetConfig(v any) error {
+	return j.GetConfigCtx(context.Background(), v)
+}
+
+func (j *Job) GetConfigCtx(ctx context.Context, v any) error {
+	text, err := j.GetConfigureCtx(ctx)
+	if err != nil {
+		return err
+	}
+	return decodeXML(text, v)
+}
+
+// Encode v and set it as config.xml of job.
+func (j *Job) SetConfig(v any) (*http.Response, error) {
+	return j.SetConfigCtx(context.Background(), v)
+}
+
+func (j *Job) SetConfigCtx(ctx context.Context, v any) (*http.Response, error) {
+	text, err := EncodeConfig(v)
+	if err != nil {
+		return nil, err
+	}
+	return j.SetConfigureCtx(ctx, strings.NewReader(text))
+}
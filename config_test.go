@@ -0,0 +1,364 @@
+package jenkins
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/joelee2012/go-jenkins/jenkinstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	workflowJobXML = `<?xml version='1.1' encoding='UTF-8'?>
+<flow-definition plugin="workflow-job@1400.v7fd111b_ec82f">
+  <actions/>
+  <description>pipeline</description>
+  <keepDependencies>false</keepDependencies>
+  <properties>
+    <jenkins.model.BuildDiscarderProperty>
+      <strategy class="hudson.tasks.LogRotator">
+        <daysToKeep>7</daysToKeep>
+        <numToKeep>20</numToKeep>
+        <artifactDaysToKeep>-1</artifactDaysToKeep>
+        <artifactNumToKeep>-1</artifactNumToKeep>
+      </strategy>
+    </jenkins.model.BuildDiscarderProperty>
+    <org.jenkinsci.plugins.workflow.job.properties.DisableConcurrentBuildsJobProperty>
+      <abortPrevious>true</abortPrevious>
+    </org.jenkinsci.plugins.workflow.job.properties.DisableConcurrentBuildsJobProperty>
+    <com.example.UnknownProperty plugin="example@1.0"><setting a="b">kept</setting></com.example.UnknownProperty>
+    <hudson.model.ParametersDefinitionProperty>
+      <parameterDefinitions>
+        <hudson.model.StringParameterDefinition>
+          <name>ARG1</name>
+          <defaultValue>default</defaultValue>
+          <trim>true</trim>
+        </hudson.model.StringParameterDefinition>
+        <hudson.model.ChoiceParameterDefinition>
+          <name>ENV</name>
+          <choices class="java.util.Arrays$ArrayList"><a class="string-array"><string>dev</string><string>prod</string></a></choices>
+        </hudson.model.ChoiceParameterDefinition>
+      </parameterDefinitions>
+    </hudson.model.ParametersDefinitionProperty>
+  </properties>
+  <definition class="org.jenkinsci.plugins.workflow.cps.CpsScmFlowDefinition" plugin="workflow-cps@3894">
+    <scm class="hudson.plugins.git.GitSCM" plugin="git@5.2.1">
+      <configVersion>2</configVersion>
+      <userRemoteConfigs>
+        <hudson.plugins.git.UserRemoteConfig>
+          <url>https://example.com/repo.git</url>
+          <credentialsId>git</credentialsId>
+        </hudson.plugins.git.UserRemoteConfig>
+      </userRemoteConfigs>
+      <branches>
+        <hudson.plugins.git.BranchSpec>
+          <name>*/main</name>
+        </hudson.plugins.git.BranchSpec>
+      </branches>
+      <extensions/>
+    </scm>
+    <scriptPath>ci/Jenkinsfile</scriptPath>
+    <lightweight>true</lightweight>
+  </definition>
+  <triggers/>
+  <disabled>false</disabled>
+</flow-definition>`
+
+	freeStyleXML = `<?xml version='1.1' encoding='UTF-8'?>
+<project>
+  <description>freestyle &amp; more</description>
+  <keepDependencies>false</keepDependencies>
+  <properties/>
+  <scm class="hudson.scm.NullSCM"/>
+  <canRoam>true</canRoam>
+  <disabled>true</disabled>
+  <triggers>
+    <hudson.triggers.TimerTrigger>
+      <spec>H 2 * * *</spec>
+    </hudson.triggers.TimerTrigger>
+  </triggers>
+  <concurrentBuild>false</concurrentBuild>
+  <builders>
+    <hudson.tasks.Shell>
+      <command>make test</command>
+      <configuredLocalRules/>
+    </hudson.tasks.Shell>
+    <com.example.Builder plugin="example@1.0"><goal>x</goal></com.example.Builder>
+  </builders>
+  <publishers>
+    <hudson.tasks.ArtifactArchiver>
+      <artifacts>out/*</artifacts>
+    </hudson.tasks.ArtifactArchiver>
+  </publishers>
+  <buildWrappers/>
+</project>`
+
+	multiBranchXML = `<?xml version='1.1' encoding='UTF-8'?>
+<org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject plugin="workflow-multibranch@773">
+  <actions/>
+  <description>branches</description>
+  <properties/>
+  <folderViews class="jenkins.branch.MultiBranchProjectViewHolder" plugin="branch-api@2.1152">
+    <owner class="org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject" reference="../.."/>
+  </folderViews>
+  <orphanedItemStrategy class="com.cloudbees.hudson.plugins.folder.computed.DefaultOrphanedItemStrategy" plugin="cloudbees-folder@6.858">
+    <pruneDeadBranches>true</pruneDeadBranches>
+    <daysToKeep>-1</daysToKeep>
+    <numToKeep>5</numToKeep>
+    <abortBuilds>false</abortBuilds>
+  </orphanedItemStrategy>
+  <triggers/>
+  <disabled>false</disabled>
+  <sources class="jenkins.branch.MultiBranchProject$BranchSourceList" plugin="branch-api@2.1152">
+    <data>
+      <jenkins.branch.BranchSource>
+        <source class="jenkins.plugins.git.GitSCMSource" plugin="git@5.2.1">
+          <id>f5a9</id>
+          <remote>https://example.com/repo.git</remote>
+          <credentialsId>git</credentialsId>
+          <traits/>
+        </source>
+        <strategy class="jenkins.branch.DefaultBranchPropertyStrategy"><properties class="empty-list"/></strategy>
+      </jenkins.branch.BranchSource>
+    </data>
+    <owner class="org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject" reference="../.."/>
+  </sources>
+  <factory class="org.jenkinsci.plugins.workflow.multibranch.WorkflowBranchProjectFactory">
+    <owner class="org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject" reference="../.."/>
+    <scriptPath>Jenkinsfile</scriptPath>
+  </factory>
+</org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject>`
+)
+
+// decode, encode and decode again, the result must not change
+func roundTrip[T any](t *testing.T, text string) (*T, string) {
+	config, err := DecodeConfig[T](text)
+	require.NoError(t, err)
+	encoded, err := EncodeConfig(config)
+	require.NoError(t, err)
+	assert.Equal(t, text, encoded)
+	again, err := DecodeConfig[T](encoded)
+	require.NoError(t, err)
+	assert.Equal(t, config, again)
+	return config, encoded
+}
+
+func TestConfigWorkflowJob(t *testing.T) {
+	config, encoded := roundTrip[WorkflowJobConfig](t, workflowJobXML)
+	assert.Equal(t, "pipeline", config.Description)
+	assert.False(t, config.Disabled)
+	assert.Equal(t, CpsScmFlowDefinition, config.Definition.Class)
+	assert.Equal(t, "ci/Jenkinsfile", config.Definition.ScriptPath)
+	assert.True(t, config.Definition.Lightweight)
+	scm := config.Definition.SCM
+	assert.Equal(t, GitSCMClass, scm.Class)
+	require.Len(t, scm.UserRemoteConfigs, 1)
+	assert.Equal(t, "https://example.com/repo.git", scm.UserRemoteConfigs[0].URL)
+	assert.Equal(t, "git", scm.UserRemoteConfigs[0].CredentialsID)
+	require.Len(t, scm.Branches, 1)
+	assert.Equal(t, "*/main", scm.Branches[0].Name)
+
+	props := config.Properties
+	assert.Equal(t, 20, props.BuildDiscarder.Strategy.NumToKeep)
+	assert.True(t, props.DisableConcurrentBuilds.AbortPrevious)
+	params := props.Parameters.Definitions
+	require.Len(t, params, 2)
+	assert.Equal(t, "hudson.model.StringParameterDefinition", params[0].XMLName.Local)
+	assert.Equal(t, "default", params[0].DefaultValue)
+	assert.Equal(t, "ENV", params[1].Name)
+
+	for _, kept := range []string{
+		`plugin="workflow-job@1400.v7fd111b_ec82f"`,
+		`<com.example.UnknownProperty plugin="example@1.0"><setting a="b">kept</setting></com.example.UnknownProperty>`,
+		`<trim>true</trim>`,
+		`<a class="string-array"><string>dev</string><string>prod</string></a>`,
+		`<configVersion>2</configVersion>`,
+		`plugin="workflow-cps@3894"`,
+		`<keepDependencies>false</keepDependencies>`,
+	} {
+		assert.Contains(t, encoded, kept)
+	}
+
+	// build config from scratch
+	config = &WorkflowJobConfig{
+		Definition: &FlowDefinition{Class: CpsFlowDefinition, Script: "echo 'hi'", Sandbox: true},
+		Properties: &JobProperties{
+			Parameters:     &ParametersProperty{Definitions: ElementList[ParamDef]{StringParam("ARG1", "a", "")}},
+			BuildDiscarder: &BuildDiscarderProperty{Strategy: NewLogRotator(-1, 10)},
+		},
+	}
+	encoded, err := EncodeConfig(config)
+	require.NoError(t, err)
+	assert.Contains(t, encoded, `<definition class="org.jenkinsci.plugins.workflow.cps.CpsFlowDefinition">`)
+	assert.Contains(t, encoded, `<script>echo &#39;hi&#39;</script>`)
+	assert.Contains(t, encoded, `<hudson.model.StringParameterDefinition>`)
+	assert.NotContains(t, encoded, `<scm`)
+}
+
+func TestConfigFreeStyleProject(t *testing.T) {
+	config, encoded := roundTrip[FreeStyleProjectConfig](t, freeStyleXML)
+	assert.Equal(t, "freestyle & more", config.Description)
+	assert.True(t, config.Disabled)
+	assert.Equal(t, NullSCMClass, config.SCM.Class)
+	assert.Contains(t, encoded, `<scm class="hudson.scm.NullSCM"/>`)
+	assert.Equal(t, ElementList[Trigger]{TimerTrigger("H 2 * * *")}, config.Triggers)
+	require.Len(t, config.Builders, 2)
+	assert.Equal(t, "make test", config.Builders[0].Command)
+	assert.Equal(t, "com.example.Builder", config.Builders[1].XMLName.Local)
+	assert.Equal(t, "hudson.tasks.ArtifactArchiver", config.Publishers[0].XMLName.Local)
+	for _, kept := range []string{
+		`<canRoam>true</canRoam>`,
+		`<configuredLocalRules/>`,
+		`<com.example.Builder plugin="example@1.0">`,
+		`<goal>x</goal>`,
+		`<artifacts>out/*</artifacts>`,
+		`<buildWrappers/>`,
+	} {
+		assert.Contains(t, encoded, kept)
+	}
+
+	config.Builders = append(config.Builders, ShellStep("make deploy"))
+	encoded, err := EncodeConfig(config)
+	require.NoError(t, err)
+	assert.Contains(t, encoded, `<com.example.Builder plugin="example@1.0"><goal>x</goal></com.example.Builder>
+    <hudson.tasks.Shell>
+      <command>make deploy</command>
+    </hudson.tasks.Shell>
+  </builders>`)
+	config, err = DecodeConfig[FreeStyleProjectConfig](encoded)
+	require.NoError(t, err)
+	assert.Equal(t, "make deploy", config.Builders[2].Command)
+}
+
+// config as exported from jenkins, with elements which are not modeled
+// before modeled ones and false booleans
+const exportedWorkflowJobXML = `<?xml version='1.1' encoding='UTF-8'?>
+<flow-definition plugin="workflow-job@1400.v7fd111b_ec82f">
+  <actions>
+    <org.jenkinsci.plugins.pipeline.modeldefinition.actions.DeclarativeJobAction plugin="pipeline-model-definition@2.2198.v41dd8ef6dd56"/>
+  </actions>
+  <description>deploy &lt;app&gt; to &quot;dev&quot;</description>
+  <keepDependencies>false</keepDependencies>
+  <properties>
+    <org.jenkinsci.plugins.workflow.job.properties.DisableConcurrentBuildsJobProperty>
+      <abortPrevious>false</abortPrevious>
+    </org.jenkinsci.plugins.workflow.job.properties.DisableConcurrentBuildsJobProperty>
+    <jenkins.model.BuildDiscarderProperty>
+      <strategy class="hudson.tasks.LogRotator">
+        <daysToKeep>-1</daysToKeep>
+        <numToKeep>10</numToKeep>
+        <artifactDaysToKeep>-1</artifactDaysToKeep>
+        <artifactNumToKeep>-1</artifactNumToKeep>
+      </strategy>
+    </jenkins.model.BuildDiscarderProperty>
+    <hudson.model.ParametersDefinitionProperty>
+      <parameterDefinitions>
+        <hudson.model.BooleanParameterDefinition>
+          <name>DRY_RUN</name>
+          <description></description>
+          <defaultValue>false</defaultValue>
+        </hudson.model.BooleanParameterDefinition>
+        <hudson.model.StringParameterDefinition>
+          <name>VERSION</name>
+          <description>version to deploy</description>
+          <trim>false</trim>
+        </hudson.model.StringParameterDefinition>
+      </parameterDefinitions>
+    </hudson.model.ParametersDefinitionProperty>
+  </properties>
+  <definition class="org.jenkinsci.plugins.workflow.cps.CpsFlowDefinition" plugin="workflow-cps@3894.vd0f0248b_a_fc4">
+    <script>pipeline {
+  agent any
+  stages {
+    stage(&apos;deploy&apos;) {
+      steps {
+        sh &quot;./deploy.sh ${params.VERSION}&quot;
+      }
+    }
+  }
+}</script>
+    <sandbox>false</sandbox>
+  </definition>
+  <triggers/>
+  <disabled>false</disabled>
+</flow-definition>`
+
+func TestConfigExportedRoundTrip(t *testing.T) {
+	config, err := DecodeConfig[WorkflowJobConfig](exportedWorkflowJobXML)
+	require.NoError(t, err)
+	encoded, err := EncodeConfig(config)
+	require.NoError(t, err)
+	assert.Equal(t, exportedWorkflowJobXML, encoded)
+
+	config.Description = "deploy app"
+	encoded, err = EncodeConfig(config)
+	require.NoError(t, err)
+	expected := strings.Replace(exportedWorkflowJobXML, "deploy &lt;app&gt; to &quot;dev&quot;", "deploy app", 1)
+	assert.Equal(t, expected, encoded)
+	diff, err := DiffXML(exportedWorkflowJobXML, encoded)
+	require.NoError(t, err)
+	assert.Len(t, diff.Changes, 1)
+
+	// false booleans are written for config built from scratch
+	config.ConfigSource = ConfigSource{}
+	encoded, err = EncodeConfig(config)
+	require.NoError(t, err)
+	assert.Contains(t, encoded, `<sandbox>false</sandbox>`)
+	assert.Contains(t, encoded, `<abortPrevious>false</abortPrevious>`)
+}
+
+func TestConfigFolderAndMultiBranch(t *testing.T) {
+	folder, _ := roundTrip[FolderConfig](t, `<?xml version='1.1' encoding='UTF-8'?>
+<com.cloudbees.hudson.plugins.folder.Folder plugin="cloudbees-folder@6.858">
+  <description>team</description>
+  <properties><org.jenkinsci.plugins.pipeline.modeldefinition.config.FolderConfig/></properties>
+  <icon class="com.cloudbees.hudson.plugins.folder.icons.StockFolderIcon"/>
+</com.cloudbees.hudson.plugins.folder.Folder>`)
+	assert.Equal(t, "team", folder.Description)
+	assert.Len(t, folder.Properties.Extra, 1)
+	assert.Equal(t, "icon", folder.Extra[0].XMLName.Local)
+
+	mb, encoded := roundTrip[MultiBranchProjectConfig](t, multiBranchXML)
+	assert.Equal(t, "branches", mb.Description)
+	assert.True(t, mb.OrphanedItemStrategy.PruneDeadBranches)
+	assert.Equal(t, 5, mb.OrphanedItemStrategy.NumToKeep)
+	require.Len(t, mb.Sources.Sources, 1)
+	source := mb.Sources.Sources[0].Source
+	assert.Equal(t, "jenkins.plugins.git.GitSCMSource", source.Class)
+	assert.Equal(t, "https://example.com/repo.git", source.Remote)
+	assert.Equal(t, "Jenkinsfile", mb.Factory.ScriptPath)
+	for _, kept := range []string{
+		`<owner class="org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject" reference="../.."/>`,
+		`<abortBuilds>false</abortBuilds>`,
+		`<properties class="empty-list"/>`,
+		`class="jenkins.branch.MultiBranchProject$BranchSourceList" plugin="branch-api@2.1152"`,
+	} {
+		assert.Contains(t, encoded, kept)
+	}
+}
+
+func TestConfigCtx(t *testing.T) {
+	srv := jenkinstest.NewServer()
+	defer srv.Close()
+	require.NoError(t, srv.CreateJob("pipeline", workflowJobXML))
+	client, err := New(srv.URL, "admin", "1234")
+	require.NoError(t, err)
+	job, err := client.GetJob("pipeline")
+	require.NoError(t, err)
+
+	var config WorkflowJobConfig
+	require.NoError(t, job.GetConfig(&config))
+	config.Description = "changed"
+	_, err = job.SetConfig(&config)
+	require.NoError(t, err)
+
+	description, err := job.GetDescription()
+	require.NoError(t, err)
+	assert.Equal(t, "changed", description)
+	text, err := job.GetConfigure()
+	require.NoError(t, err)
+	assert.Contains(t, text, `<com.example.UnknownProperty plugin="example@1.0"><setting a="b">kept</setting></com.example.UnknownProperty>`)
+
+	assert.Error(t, job.GetConfig(&FolderConfig{}))
+}
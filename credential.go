@@ -1,7 +1,7 @@
 package jenkins
 
 import (
-	"fmt"
+	"context"
 	"io"
 	"net/http"
 )
@@ -11,8 +11,12 @@ type Credentials struct {
 }
 
 func (cs *Credentials) Get(name string) (*CredentialJson, error) {
+	return cs.GetCtx(context.Background(), name)
+}
+
+func (cs *Credentials) GetCtx(ctx context.Context, name string) (*CredentialJson, error) {
 	var credsJson CredentialsJson
-	if err := cs.ApiJson(&credsJson, &ApiJsonOpts{Depth: 1}); err != nil {
+	if err := cs.ApiJsonCtx(ctx, &credsJson, &ApiJsonOpts{Depth: 1}); err != nil {
 		return nil, err
 	}
 	if credsJson.Credentials != nil {
@@ -22,28 +26,48 @@ func (cs *Credentials) Get(name string) (*CredentialJson, error) {
 			}
 		}
 	}
-	return nil, fmt.Errorf("%s has no credential [%s]", cs, name)
+	return nil, newError(ErrNotFound, "%s has no credential [%s]", cs, name)
 }
 
 func (cs *Credentials) Create(xml io.Reader) (*http.Response, error) {
-	return cs.Request("POST", "createCredentials", xml)
+	return cs.CreateCtx(context.Background(), xml)
+}
+
+func (cs *Credentials) CreateCtx(ctx context.Context, xml io.Reader) (*http.Response, error) {
+	return cs.RequestCtx(ctx, "POST", "createCredentials", xml)
 }
 
 func (cs *Credentials) Delete(name string) (*http.Response, error) {
-	return cs.Request("POST", "credential/"+name+"/doDelete", nil)
+	return cs.DeleteCtx(context.Background(), name)
+}
+
+func (cs *Credentials) DeleteCtx(ctx context.Context, name string) (*http.Response, error) {
+	return cs.RequestCtx(ctx, "POST", "credential/"+name+"/doDelete", nil)
 }
 
 func (cs *Credentials) GetConfigure(name string) (string, error) {
-	return readResponseToString(cs, "GET", "credential/"+name+"/config.xml", nil)
+	return cs.GetConfigureCtx(context.Background(), name)
+}
+
+func (cs *Credentials) GetConfigureCtx(ctx context.Context, name string) (string, error) {
+	return readResponseToString(ctx, cs, "GET", "credential/"+name+"/config.xml", nil)
 }
 
 func (cs *Credentials) SetConfigure(name string, xml io.Reader) (*http.Response, error) {
-	return cs.Request("POST", "credential/"+name+"/config.xml", xml)
+	return cs.SetConfigureCtx(context.Background(), name, xml)
+}
+
+func (cs *Credentials) SetConfigureCtx(ctx context.Context, name string, xml io.Reader) (*http.Response, error) {
+	return cs.RequestCtx(ctx, "POST", "credential/"+name+"/config.xml", xml)
 }
 
 func (cs *Credentials) List() ([]*CredentialJson, error) {
+	return cs.ListCtx(context.Background())
+}
+
+func (cs *Credentials) ListCtx(ctx context.Context) ([]*CredentialJson, error) {
 	var credsJson CredentialsJson
-	if err := cs.ApiJson(&credsJson, &ApiJsonOpts{Depth: 1}); err != nil {
+	if err := cs.ApiJsonCtx(ctx, &credsJson, &ApiJsonOpts{Depth: 1}); err != nil {
 		return nil, err
 	}
 	return credsJson.Credentials, nil
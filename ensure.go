@@ -0,0 +1,122 @@
+package jenkins
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"path"
+	"strings"
+)
+
+// config.xml of folder created by EnsureFolder
+const folderXML = `<?xml version='1.1' encoding='UTF-8'?>
+<com.cloudbees.hudson.plugins.folder.Folder/>`
+
+// get job by reading its own api/json instead of listing its parent
+func (c *Jenkins) lookupJob(ctx context.Context, fullName string) (*Job, error) {
+	info, err := ApiJSONCtx[struct {
+		Class string `json:"_class"`
+		URL   string `json:"url"`
+	}](ctx, NewJob(c.Name2URL(fullName), "Job", c))
+	if err != nil {
+		return nil, err
+	}
+	return NewJob(info.URL, info.Class, c), nil
+}
+
+func (c *Jenkins) JobExists(fullName string) (bool, error) {
+	return c.JobExistsCtx(context.Background(), fullName)
+}
+
+// Check if job exists, it is cheaper than GetJob which lists the parent
+// folder.
+func (c *Jenkins) JobExistsCtx(ctx context.Context, fullName string) (bool, error) {
+	_, err := c.lookupJob(ctx, fullName)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (c *Jenkins) EnsureFolder(fullName string) (*Job, error) {
+	return c.EnsureFolderCtx(context.Background(), fullName)
+}
+
+// Create folder and its missing parents, existing folders are left as is,
+// e.g. EnsureFolder("a/b") creates a and a/b if they do not exist. It fails
+// with ErrNotAFolder if any of them is a job.
+func (c *Jenkins) EnsureFolderCtx(ctx context.Context, fullName string) (*Job, error) {
+	folder := NewJob(c.URL, "Folder", c)
+	fullName = strings.Trim(fullName, "/")
+	if fullName == "" {
+		return folder, nil
+	}
+	names := strings.Split(fullName, "/")
+	for i, name := range names {
+		current := strings.Join(names[:i+1], "/")
+		job, err := c.lookupJob(ctx, current)
+		if errors.Is(err, ErrNotFound) {
+			resp, cerr := folder.CreateCtx(ctx, name, strings.NewReader(folderXML))
+			// created by others meanwhile
+			if cerr != nil && !errors.Is(cerr, ErrAlreadyExists) {
+				return nil, cerr
+			}
+			if cerr == nil {
+				resp.Body.Close()
+			}
+			job, err = c.lookupJob(ctx, current)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !isFolder(job.Class) {
+			return nil, newError(ErrNotAFolder, "%s is not a folder", job)
+		}
+		folder = job
+	}
+	return folder, nil
+}
+
+func (c *Jenkins) CreateOrUpdateJob(fullName string, xml io.Reader) (*Job, error) {
+	return c.CreateOrUpdateJobCtx(context.Background(), fullName, xml)
+}
+
+// Create job with xml config or update its config.xml if it exists, missing
+// folders are created as EnsureFolder. It is safe to re-run:
+//
+//	job, err := jenkins.CreateOrUpdateJob("path/to/name", strings.NewReader(xml))
+func (c *Jenkins) CreateOrUpdateJobCtx(ctx context.Context, fullName string, xml io.Reader) (*Job, error) {
+	config, err := io.ReadAll(xml)
+	if err != nil {
+		return nil, err
+	}
+	fullName = strings.Trim(fullName, "/")
+	dir, name := path.Split(fullName)
+	folder, err := c.EnsureFolderCtx(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+	job, err := c.lookupJob(ctx, fullName)
+	if errors.Is(err, ErrNotFound) {
+		resp, cerr := folder.CreateCtx(ctx, name, bytes.NewReader(config))
+		if cerr == nil {
+			resp.Body.Close()
+			return c.lookupJob(ctx, fullName)
+		}
+		// created by others meanwhile, update it
+		if !errors.Is(cerr, ErrAlreadyExists) {
+			return nil, cerr
+		}
+		job, err = c.lookupJob(ctx, fullName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	resp, err := job.SetConfigureCtx(ctx, bytes.NewReader(config))
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return job, nil
+}
@@ -0,0 +1,93 @@
+package jenkins
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/joelee2012/go-jenkins/jenkinstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnsureFolder(t *testing.T) {
+	srv := jenkinstest.NewServer()
+	defer srv.Close()
+	require.NoError(t, srv.CreateJob("a", folderConf))
+	require.NoError(t, srv.CreateJob("job", syncJobConf))
+	client, err := New(srv.URL, "admin", "1234")
+	require.NoError(t, err)
+
+	for name, expect := range map[string]bool{"a": true, "job": true, "a/b": false, "x/y/z": false} {
+		exists, err := client.JobExists(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, expect, exists, name)
+	}
+
+	// safe to re-run
+	for range 2 {
+		folder, err := client.EnsureFolder("/a/b/c/")
+		require.NoError(t, err)
+		assert.Equal(t, "a/b/c", folder.FullName)
+		assert.Equal(t, "Folder", folder.Class)
+	}
+	exists, err := client.JobExists("a/b")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	folder, err := client.EnsureFolder("")
+	require.NoError(t, err)
+	assert.Equal(t, client.URL, folder.URL)
+	_, err = client.EnsureFolder("job/b")
+	assert.ErrorIs(t, err, ErrNotAFolder)
+}
+
+func TestCreateOrUpdateJob(t *testing.T) {
+	srv := jenkinstest.NewServer()
+	defer srv.Close()
+	client, err := New(srv.URL, "admin", "1234")
+	require.NoError(t, err)
+
+	job, err := client.CreateOrUpdateJob("x/y/app", strings.NewReader(syncJobConf))
+	require.NoError(t, err)
+	assert.Equal(t, "x/y/app", job.FullName)
+	assert.Equal(t, "FreeStyleProject", job.Class)
+
+	job, err = client.CreateOrUpdateJob("x/y/app", strings.NewReader(strings.Replace(syncJobConf, ">app<", ">new<", 1)))
+	require.NoError(t, err)
+	description, err := job.GetDescription()
+	require.NoError(t, err)
+	assert.Equal(t, "new", description)
+
+	_, err = client.CreateOrUpdateJob("x/y/app/b", strings.NewReader(syncJobConf))
+	assert.ErrorIs(t, err, ErrNotAFolder)
+}
+
+func TestCreateOrUpdateJobRace(t *testing.T) {
+	// job is created by others between lookup and create
+	var created bool
+	var config string
+	client := newFakeJenkins(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/job/app/api/json" && !created:
+			http.NotFound(w, r)
+		case r.URL.Path == "/job/app/api/json":
+			fmt.Fprintf(w, `{"_class":"hudson.model.FreeStyleProject","url":"http://%s/job/app/"}`, r.Host)
+		case r.URL.Path == "/createItem":
+			created = true
+			w.Header().Set("X-Error", `A job already exists with the name "app"`)
+			w.WriteHeader(http.StatusBadRequest)
+		case r.URL.Path == "/job/app/config.xml" && r.Method == http.MethodPost:
+			data, _ := io.ReadAll(r.Body)
+			config = string(data)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	job, err := client.CreateOrUpdateJob("app", strings.NewReader(syncJobConf))
+	require.NoError(t, err)
+	assert.Equal(t, "app", job.FullName)
+	assert.Equal(t, syncJobConf, config)
+}
@@ -0,0 +1,110 @@
+package jenkins
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	// crumb is expired or missing, it is also ErrForbidden
+	ErrCrumbInvalid = errors.New("no valid crumb")
+	ErrNotAFolder   = errors.New("not a folder")
+	ErrNoBuilds     = errors.New("no builds")
+	// item with the same name exists, eg: creating job with name in use
+	ErrAlreadyExists = errors.New("already exists")
+	// config is changed by others while it is being patched
+	ErrConflict = errors.New("conflict")
+)
+
+// max length of response body kept in APIError
+const maxErrorBody = 4096
+
+// APIError is returned for any response with status code >= 400, check the
+// kind of failure with errors.Is:
+//
+//	job, err := jenkins.GetJob("path/to/job")
+//	if errors.Is(err, jenkins.ErrNotFound) {
+//		// create job
+//	}
+type APIError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Status     string
+	Body       string
+	// value of X-Error header which is set by Jenkins for some failures
+	JenkinsError string
+}
+
+func newAPIError(req *http.Request, resp *http.Response) *APIError {
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	return &APIError{
+		Method:       req.Method,
+		URL:          req.URL.String(),
+		StatusCode:   resp.StatusCode,
+		Status:       resp.Status,
+		Body:         string(data),
+		JenkinsError: resp.Header.Get("X-Error"),
+	}
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s %s: %s", e.Method, e.URL, e.Status)
+	if e.JenkinsError != "" {
+		msg += ", " + e.JenkinsError
+	}
+	return msg
+}
+
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrCrumbInvalid:
+		return e.isCrumbInvalid()
+	case ErrAlreadyExists:
+		return e.StatusCode == http.StatusBadRequest && strings.Contains(e.JenkinsError, "already exists")
+	}
+	return false
+}
+
+func (e *APIError) isCrumbInvalid() bool {
+	if e.StatusCode != http.StatusForbidden {
+		return false
+	}
+	for _, s := range []string{e.JenkinsError, e.Status, e.Body} {
+		if strings.Contains(s, "No valid crumb") {
+			return true
+		}
+	}
+	return false
+}
+
+// error with its own message which matches sentinel by errors.Is
+type wrappedError struct {
+	msg string
+	err error
+}
+
+func (e *wrappedError) Error() string {
+	return e.msg
+}
+
+func (e *wrappedError) Unwrap() error {
+	return e.err
+}
+
+func newError(sentinel error, format string, a ...any) error {
+	return &wrappedError{msg: fmt.Sprintf(format, a...), err: sentinel}
+}
@@ -0,0 +1,69 @@
+package jenkins
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIError(t *testing.T) {
+	var tests = []struct {
+		status   int
+		xError   string
+		body     string
+		expect   []error
+		unexpect []error
+	}{
+		{http.StatusNotFound, "", "", []error{ErrNotFound}, []error{ErrForbidden, ErrUnauthorized}},
+		{http.StatusUnauthorized, "", "", []error{ErrUnauthorized}, []error{ErrNotFound}},
+		{http.StatusForbidden, "", "", []error{ErrForbidden}, []error{ErrCrumbInvalid}},
+		{http.StatusForbidden, "", "No valid crumb was included in the request", []error{ErrForbidden, ErrCrumbInvalid}, []error{ErrNotFound}},
+		{http.StatusBadRequest, "A job already exists with the name 'pipeline'", "", []error{ErrAlreadyExists}, []error{ErrNotFound, ErrForbidden}},
+		{http.StatusBadRequest, "", "", nil, []error{ErrAlreadyExists}},
+	}
+	for _, test := range tests {
+		j := newFakeJenkins(t, func(w http.ResponseWriter, r *http.Request) {
+			if test.xError != "" {
+				w.Header().Set("X-Error", test.xError)
+			}
+			w.WriteHeader(test.status)
+			w.Write([]byte(test.body))
+		})
+		_, err := j.Request("POST", "job/pipeline/doDelete", nil)
+		var apiErr *APIError
+		assert.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, "POST", apiErr.Method)
+		assert.Equal(t, j.URL+"job/pipeline/doDelete", apiErr.URL)
+		assert.Equal(t, test.status, apiErr.StatusCode)
+		assert.Equal(t, test.body, apiErr.Body)
+		assert.Equal(t, test.xError, apiErr.JenkinsError)
+		for _, target := range test.expect {
+			assert.ErrorIs(t, err, target)
+		}
+		for _, target := range test.unexpect {
+			assert.False(t, errors.Is(err, target))
+		}
+	}
+}
+
+func TestSentinelErrors(t *testing.T) {
+	j := newFakeJenkins(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jobs":[{"name":"pipeline","url":"http://jenkins/job/pipeline/"}],"views":[]}`))
+	})
+	folder := NewJob(j.URL+"job/folder/", "Folder", j)
+	_, err := folder.Get("notexist")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Contains(t, err.Error(), "no such job")
+	_, err = folder.GetBuild(1)
+	assert.ErrorIs(t, err, ErrNoBuilds)
+	_, err = folder.Views().Get("notexist")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	pipeline := NewJob(j.URL+"job/pipeline/", "WorkflowJob", j)
+	_, err = pipeline.Get("notexist")
+	assert.ErrorIs(t, err, ErrNotAFolder)
+	_, err = pipeline.List(0)
+	assert.ErrorIs(t, err, ErrNotAFolder)
+}
@@ -1,6 +1,6 @@
 module github.com/joelee2012/go-jenkins
 
-go 1.22
+go 1.23
 
 require github.com/stretchr/testify v1.7.0
 
@@ -0,0 +1,159 @@
+package jenkins
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// default number of builds fetched per request by BuildHistory
+const DefaultHistoryPageSize = 100
+
+// summary of build fetched by GetBuild and History
+type buildSummary struct {
+	Class     string `json:"_class"`
+	Number    int    `json:"number"`
+	URL       string `json:"url"`
+	Result    string `json:"result"`
+	Building  bool   `json:"building"`
+	Timestamp int64  `json:"timestamp"`
+	Duration  int    `json:"duration"`
+}
+
+type historyPage struct {
+	AllBuilds []*buildSummary `json:"allBuilds"`
+}
+
+// HistoryOpts filters builds returned by BuildHistory, zero value matches
+// all builds.
+type HistoryOpts struct {
+	// builds fetched per request, DefaultHistoryPageSize if 0
+	PageSize int
+	// results to match, e.g. "SUCCESS" or "FAILURE", all if empty
+	Results []string
+	// match builds started in [Since, Until), unbounded if zero
+	Since time.Time
+	Until time.Time
+	// stop after Limit builds, unlimited if 0
+	Limit int
+}
+
+func (o *HistoryOpts) match(b *buildSummary) bool {
+	if len(o.Results) > 0 && !slices.Contains(o.Results, b.Result) {
+		return false
+	}
+	started := time.UnixMilli(b.Timestamp)
+	if !o.Until.IsZero() && !started.Before(o.Until) {
+		return false
+	}
+	return o.Since.IsZero() || !started.Before(o.Since)
+}
+
+// BuildHistory pages through allBuilds of job from newest to oldest, which
+// unlike builds is not capped at 100 builds:
+//
+//	history := job.History(&jenkins.HistoryOpts{Results: []string{"FAILURE"}})
+//	for history.HasNext() {
+//		builds, err := history.Next()
+//		if err != nil {
+//			return err
+//		}
+//		for _, b := range builds {
+//			fmt.Println(b.Number, b.Result)
+//		}
+//	}
+type BuildHistory struct {
+	job   *Job
+	opts  HistoryOpts
+	from  int
+	count int
+	done  bool
+}
+
+func (j *Job) History(opts *HistoryOpts) *BuildHistory {
+	h := &BuildHistory{job: j}
+	if opts != nil {
+		h.opts = *opts
+	}
+	if h.opts.PageSize <= 0 {
+		h.opts.PageSize = DefaultHistoryPageSize
+	}
+	return h
+}
+
+// HasNext reports whether there may be more builds to fetch.
+func (h *BuildHistory) HasNext() bool {
+	return !h.done
+}
+
+func (h *BuildHistory) Next() ([]*BuildJson, error) {
+	return h.NextCtx(context.Background())
+}
+
+// NextCtx fetches pages until at least one build matches or history is
+// exhausted, it returns nil once HasNext is false.
+func (h *BuildHistory) NextCtx(ctx context.Context) ([]*BuildJson, error) {
+	if isFolder(h.job.Class) {
+		h.done = true
+		return nil, newError(ErrNoBuilds, "%s have no builds", h.job)
+	}
+	var builds []*BuildJson
+	for !h.done && len(builds) == 0 {
+		page, err := ApiJSONCtx[historyPage](ctx, h.job,
+			WithTreeRange("allBuilds", h.from, h.from+h.opts.PageSize))
+		if err != nil {
+			return nil, err
+		}
+		h.from += h.opts.PageSize
+		if len(page.AllBuilds) < h.opts.PageSize {
+			h.done = true
+		}
+		for _, b := range page.AllBuilds {
+			// builds are ordered from newest to oldest
+			if !h.opts.Since.IsZero() && time.UnixMilli(b.Timestamp).Before(h.opts.Since) {
+				h.done = true
+				break
+			}
+			if !h.opts.match(b) {
+				continue
+			}
+			builds = append(builds, b.toBuildJson())
+			h.count++
+			if h.opts.Limit > 0 && h.count >= h.opts.Limit {
+				h.done = true
+				break
+			}
+		}
+	}
+	return builds, nil
+}
+
+func (b *buildSummary) toBuildJson() *BuildJson {
+	return &BuildJson{
+		Class:     b.Class,
+		Number:    b.Number,
+		URL:       b.URL,
+		Result:    b.Result,
+		Building:  b.Building,
+		Timestamp: b.Timestamp,
+		Duration:  b.Duration,
+	}
+}
+
+func (j *Job) ListAllBuilds(opts *HistoryOpts) ([]*BuildJson, error) {
+	return j.ListAllBuildsCtx(context.Background(), opts)
+}
+
+// List all builds of job matching opts by walking History.
+func (j *Job) ListAllBuildsCtx(ctx context.Context, opts *HistoryOpts) ([]*BuildJson, error) {
+	var builds []*BuildJson
+	h := j.History(opts)
+	for h.HasNext() {
+		page, err := h.NextCtx(ctx)
+		if err != nil {
+			return nil, err
+		}
+		builds = append(builds, page...)
+	}
+	return builds, nil
+}
@@ -0,0 +1,131 @@
+package jenkins
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var allBuildsRange = regexp.MustCompile(`allBuilds\[.*\]\{(\d+),(\d+)\}`)
+
+// serve 250 builds, newest first, started one hour apart from base
+func newHistoryJenkins(t *testing.T, base time.Time, requests *[]string) *Jenkins {
+	var j *Jenkins
+	j = newFakeJenkins(t, func(w http.ResponseWriter, r *http.Request) {
+		if m := regexp.MustCompile(`^/job/pipeline/(\d+)/api/json$`).FindStringSubmatch(r.URL.Path); m != nil {
+			n, _ := strconv.Atoi(m[1])
+			if n < 1 || n > 250 {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			fmt.Fprintf(w, `{"_class":"WorkflowRun","number":%d,"url":"%sjob/pipeline/%d/"}`, n, j.URL, n)
+			return
+		}
+		tree := r.URL.Query().Get("tree")
+		*requests = append(*requests, tree)
+		m := allBuildsRange.FindStringSubmatch(tree)
+		if !assert.NotNil(t, m, tree) {
+			return
+		}
+		from, _ := strconv.Atoi(m[1])
+		to, _ := strconv.Atoi(m[2])
+		var builds []map[string]any
+		for i := from; i < to && i < 250; i++ {
+			n := 250 - i
+			result := "SUCCESS"
+			if n%10 == 0 {
+				result = "FAILURE"
+			}
+			builds = append(builds, map[string]any{
+				"_class":    "WorkflowRun",
+				"number":    n,
+				"url":       fmt.Sprintf("%sjob/pipeline/%d/", j.URL, n),
+				"result":    result,
+				"timestamp": base.Add(time.Duration(n) * time.Hour).UnixMilli(),
+				"duration":  1000,
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]any{"allBuilds": builds})
+	})
+	return j
+}
+
+func TestHistory(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var requests []string
+	j := newHistoryJenkins(t, base, &requests)
+	job := NewJob(j.Name2URL("pipeline"), "WorkflowJob", j)
+
+	builds, err := job.ListAllBuilds(nil)
+	assert.Nil(t, err)
+	assert.Len(t, builds, 250)
+	assert.Equal(t, 250, builds[0].Number)
+	assert.Equal(t, 1, builds[249].Number)
+	assert.Equal(t, 1000, builds[0].Duration)
+	assert.Equal(t, []string{
+		"allBuilds[number,url,result,building,timestamp,duration]{0,100}",
+		"allBuilds[number,url,result,building,timestamp,duration]{100,200}",
+		"allBuilds[number,url,result,building,timestamp,duration]{200,300}",
+	}, requests)
+
+	// filter by result
+	builds, err = job.ListAllBuilds(&HistoryOpts{PageSize: 50, Results: []string{"FAILURE"}})
+	assert.Nil(t, err)
+	assert.Len(t, builds, 25)
+	for _, b := range builds {
+		assert.Equal(t, "FAILURE", b.Result)
+	}
+
+	// time window stops paging once builds are older than Since
+	requests = nil
+	builds, err = job.ListAllBuilds(&HistoryOpts{
+		PageSize: 20,
+		Since:    base.Add(200 * time.Hour),
+		Until:    base.Add(230 * time.Hour),
+	})
+	assert.Nil(t, err)
+	assert.Len(t, builds, 30)
+	assert.Equal(t, 229, builds[0].Number)
+	assert.Equal(t, 200, builds[29].Number)
+	assert.Len(t, requests, 3)
+
+	// limit
+	requests = nil
+	h := job.History(&HistoryOpts{PageSize: 10, Limit: 15})
+	var numbers []int
+	for h.HasNext() {
+		page, err := h.Next()
+		assert.Nil(t, err)
+		for _, b := range page {
+			numbers = append(numbers, b.Number)
+		}
+	}
+	assert.Len(t, numbers, 15)
+	assert.Len(t, requests, 2)
+	assert.True(t, strings.HasSuffix(requests[1], "{10,20}"))
+
+	// folder
+	_, err = NewJob(j.Name2URL("folder"), "Folder", j).ListAllBuilds(nil)
+	assert.ErrorIs(t, err, ErrNoBuilds)
+}
+
+func TestHistoryGetBuild(t *testing.T) {
+	var requests []string
+	j := newHistoryJenkins(t, time.Now(), &requests)
+	job := NewJob(j.Name2URL("pipeline"), "WorkflowJob", j)
+	build, err := job.GetBuild(3)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, build.Number)
+	assert.Equal(t, j.URL+"job/pipeline/3/", build.URL)
+
+	_, err = job.GetBuild(251)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Empty(t, requests)
+}
@@ -0,0 +1,98 @@
+package jenkins
+
+import (
+	"context"
+	"net/http"
+)
+
+// Doer sends one http request, *http.Client is a Doer
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Interceptor wraps next to add behaviour around every request, eg:
+//
+//	timing := func(next jenkins.Doer) jenkins.Doer {
+//		return jenkins.DoerFunc(func(req *http.Request) (*http.Response, error) {
+//			start := time.Now()
+//			resp, err := next.Do(req)
+//			item := jenkins.ItemFromContext(req.Context())
+//			log.Printf("%s %s of %s took %s", req.Method, req.URL, item, time.Since(start))
+//			return resp, err
+//		})
+//	}
+//	client.Use(timing)
+//
+// It may also return a response without calling next. Response with status
+// code >= 400 is converted to *APIError after the whole chain returns.
+type Interceptor func(next Doer) Doer
+
+// Install interceptors, see Jenkins.Use
+func WithInterceptors(interceptors ...Interceptor) Option {
+	return func(o *JenkinsOpts) error {
+		o.Interceptors = append(o.Interceptors, interceptors...)
+		return nil
+	}
+}
+
+// Append interceptors to the chain, the first one is the outermost. Chain is
+// called for every attempt of a request, it is wrapped by the built-in cache,
+// retry and limiter and wraps the built-in logging and metrics:
+//
+//	cache -> retry -> limiter -> interceptors... -> logging -> metrics -> http client
+func (c *Jenkins) Use(interceptors ...Interceptor) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// copy on write, chain being built by other goroutine is not affected
+	c.interceptors = append(c.interceptors[:len(c.interceptors):len(c.interceptors)], interceptors...)
+}
+
+func (c *Jenkins) doer() Doer {
+	d := c.metrics.interceptor(c.Client())
+	c.mu.Lock()
+	interceptors := c.interceptors
+	c.mu.Unlock()
+	if log := c.requestLogger(); log != nil {
+		d = log.interceptor(d)
+	}
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		d = interceptors[i](d)
+	}
+	return c.cache.interceptor(c.retryInterceptor(c.limiter.interceptor(d)))
+}
+
+type itemKey struct{}
+
+// Item which sends the request, it is nil if ctx is not from a request
+// created by this package.
+func ItemFromContext(ctx context.Context) *Item {
+	item, _ := ctx.Value(itemKey{}).(*Item)
+	return item
+}
+
+func contextWithItem(ctx context.Context, item *Item) context.Context {
+	return context.WithValue(ctx, itemKey{}, item)
+}
+
+type attemptKey struct{}
+
+// number of attempt of request, starts from 1
+func attemptFromContext(ctx context.Context) int {
+	if attempt, ok := ctx.Value(attemptKey{}).(int); ok {
+		return attempt
+	}
+	return 1
+}
+
+type crumbKey struct{}
+
+func crumbFromContext(ctx context.Context) *Crumb {
+	crumb, _ := ctx.Value(crumbKey{}).(*Crumb)
+	return crumb
+}
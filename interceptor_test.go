@@ -0,0 +1,101 @@
+package jenkins
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterceptors(t *testing.T) {
+	var headers []string
+	j := newFakeJenkins(t, func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Get("X-Trace"))
+		w.Write([]byte(`{"description":"from server"}`))
+	})
+	var trace []string
+	record := func(name string) Interceptor {
+		return func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				item := ItemFromContext(req.Context())
+				trace = append(trace, name+" "+req.Method+" "+item.Class)
+				req.Header.Set("X-Trace", req.Header.Get("X-Trace")+name)
+				resp, err := next.Do(req)
+				trace = append(trace, name+" done")
+				return resp, err
+			})
+		}
+	}
+	j.Use(record("a"), record("b"))
+	job := NewJob(j.URL+"job/pipeline/", "WorkflowJob", j)
+	description, err := job.GetDescription()
+	assert.Nil(t, err)
+	assert.Equal(t, "from server", description)
+	// crumb is requested by Jenkins itself
+	assert.Equal(t, []string{
+		"a GET Jenkins", "b GET Jenkins", "b done", "a done",
+		"a GET WorkflowJob", "b GET WorkflowJob", "b done", "a done",
+	}, trace)
+	assert.Equal(t, []string{"ab"}, headers)
+}
+
+func TestInterceptorFaultInjection(t *testing.T) {
+	var calls int
+	j := newFakeJenkins(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	})
+	_, err := j.GetCrumb()
+	assert.Nil(t, err)
+	failures := 2
+	j.Use(func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			if failures > 0 {
+				failures--
+				return &http.Response{
+					StatusCode: http.StatusBadGateway,
+					Status:     "502 Bad Gateway",
+					Header:     http.Header{},
+					Body:       io.NopCloser(strings.NewReader("injected")),
+				}, nil
+			}
+			return next.Do(req)
+		})
+	})
+	// injected failure is returned as *APIError
+	_, err = j.Request("POST", "job/pipeline/build", nil)
+	var apiErr *APIError
+	assert.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "injected", apiErr.Body)
+	assert.Equal(t, 0, calls)
+
+	// and retried by built-in retry
+	j.retry = &testRetryPolicy
+	_, err = j.Request("GET", "api/json", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestInterceptorRewrite(t *testing.T) {
+	var paths []string
+	var bodies []string
+	j := newFakeJenkins(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		data, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(data))
+	})
+	// rewrite request for reverse proxy which serves jenkins under /jenkins
+	j.Use(func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			req.URL.Path = strings.TrimPrefix(req.URL.Path, "/jenkins")
+			return next.Do(req)
+		})
+	})
+	j.URL += "jenkins/"
+	_, err := j.Request("POST", "job/pipeline/config.xml", bytes.NewReader([]byte("<project/>")))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"/job/pipeline/config.xml"}, paths)
+	assert.Equal(t, []string{"<project/>"}, bodies)
+}
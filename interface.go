@@ -1,10 +1,695 @@
 package jenkins
 
 import (
+	"context"
 	"io"
+	"iter"
 	"net/http"
+	"net/url"
 )
 
+//go:generate go run ./internal/mockgen -src interface.go -out jenkinsmock/mock_gen.go
+
 type Requester interface {
 	Request(method, entry string, body io.Reader) (*http.Response, error)
+	RequestCtx(ctx context.Context, method, entry string, body io.Reader) (*http.Response, error)
+}
+
+// Interfaces below describe the remote API of each resource so that code
+// using them can be tested with jenkinsmock or other fakes. Jobs, builds and
+// queue items are returned as interfaces, so are their methods, get them
+// from client by API(), eg: client.API().GetJob("folder/job"). Accessors of
+// services, eg: Jenkins.Nodes(), are left out, depend on the service
+// interface instead, eg: client.Nodes().API().
+
+type ItemAPI interface {
+	Requester
+	ApiJson(v any, opts *ApiJsonOpts) error
+	ApiJsonCtx(ctx context.Context, v any, opts *ApiJsonOpts) error
+	String() string
+}
+
+type JenkinsAPI interface {
+	ItemAPI
+	GetCrumb() (*Crumb, error)
+	GetCrumbCtx(ctx context.Context) (*Crumb, error)
+	GetJob(fullName string) (JobAPI, error)
+	GetJobCtx(ctx context.Context, fullName string) (JobAPI, error)
+	CreateJob(fullName string, xml io.Reader) (*http.Response, error)
+	CreateJobCtx(ctx context.Context, fullName string, xml io.Reader) (*http.Response, error)
+	DeleteJob(fullName string) (*http.Response, error)
+	DeleteJobCtx(ctx context.Context, fullName string) (*http.Response, error)
+	JobExists(fullName string) (bool, error)
+	JobExistsCtx(ctx context.Context, fullName string) (bool, error)
+	EnsureFolder(fullName string) (JobAPI, error)
+	EnsureFolderCtx(ctx context.Context, fullName string) (JobAPI, error)
+	CreateOrUpdateJob(fullName string, xml io.Reader) (JobAPI, error)
+	CreateOrUpdateJobCtx(ctx context.Context, fullName string, xml io.Reader) (JobAPI, error)
+	Name2URL(fullName string) string
+	URL2Name(url string) (string, error)
+	GetVersion() (string, error)
+	GetVersionCtx(ctx context.Context) (string, error)
+	BuildJob(fullName string, params url.Values) (QueueItemAPI, error)
+	BuildJobCtx(ctx context.Context, fullName string, params url.Values) (QueueItemAPI, error)
+	ListJobs(depth int) ([]JobAPI, error)
+	ListJobsCtx(ctx context.Context, depth int) ([]JobAPI, error)
+	AllJobs(opts *WalkOpts) iter.Seq2[JobAPI, error]
+	AllJobsCtx(ctx context.Context, opts *WalkOpts) iter.Seq2[JobAPI, error]
+	Restart() (*http.Response, error)
+	RestartCtx(ctx context.Context) (*http.Response, error)
+	SafeRestart() (*http.Response, error)
+	SafeRestartCtx(ctx context.Context) (*http.Response, error)
+	Exit() (*http.Response, error)
+	ExitCtx(ctx context.Context) (*http.Response, error)
+	SafeExit() (*http.Response, error)
+	SafeExitCtx(ctx context.Context) (*http.Response, error)
+	QuiteDown() (*http.Response, error)
+	QuiteDownCtx(ctx context.Context) (*http.Response, error)
+	CancelQuiteDown() (*http.Response, error)
+	CancelQuiteDownCtx(ctx context.Context) (*http.Response, error)
+	ReloadJCasC() (*http.Response, error)
+	ReloadJCasCCtx(ctx context.Context) (*http.Response, error)
+	ValidateJenkinsfile(content string) (string, error)
+	ValidateJenkinsfileCtx(ctx context.Context, content string) (string, error)
+	RunScript(script string) (string, error)
+	RunScriptCtx(ctx context.Context, script string) (string, error)
+}
+
+type JobAPI interface {
+	ItemAPI
+	Rename(name string) (*url.URL, error)
+	RenameCtx(ctx context.Context, name string) (*url.URL, error)
+	Move(path string) (*url.URL, error)
+	MoveCtx(ctx context.Context, path string) (*url.URL, error)
+	RenameTo(name string) (JobAPI, error)
+	RenameToCtx(ctx context.Context, name string) (JobAPI, error)
+	MoveTo(folder string) (JobAPI, error)
+	MoveToCtx(ctx context.Context, folder string) (JobAPI, error)
+	Copy(src, dest string) (*http.Response, error)
+	CopyCtx(ctx context.Context, src, dest string) (*http.Response, error)
+	GetParent() (JobAPI, error)
+	GetParentCtx(ctx context.Context) (JobAPI, error)
+	GetConfigure() (string, error)
+	GetConfigureCtx(ctx context.Context) (string, error)
+	SetConfigure(xml io.Reader) (*http.Response, error)
+	SetConfigureCtx(ctx context.Context, xml io.Reader) (*http.Response, error)
+	GetConfig(v any) error
+	GetConfigCtx(ctx context.Context, v any) error
+	SetConfig(v any) (*http.Response, error)
+	SetConfigCtx(ctx context.Context, v any) (*http.Response, error)
+	PatchConfigure(edits ...ConfigEdit) (*PatchResult, error)
+	PatchConfigureCtx(ctx context.Context, edits ...ConfigEdit) (*PatchResult, error)
+	DiffConfigure(other JobAPI) (*XMLDiff, error)
+	DiffConfigureCtx(ctx context.Context, other JobAPI) (*XMLDiff, error)
+	Disable() (*http.Response, error)
+	DisableCtx(ctx context.Context) (*http.Response, error)
+	Enable() (*http.Response, error)
+	EnableCtx(ctx context.Context) (*http.Response, error)
+	IsBuildable() (bool, error)
+	IsBuildableCtx(ctx context.Context) (bool, error)
+	GetDescription() (string, error)
+	GetDescriptionCtx(ctx context.Context) (string, error)
+	SetDescription(description string) (*http.Response, error)
+	SetDescriptionCtx(ctx context.Context, description string) (*http.Response, error)
+	Build(param url.Values) (QueueItemAPI, error)
+	BuildCtx(ctx context.Context, param url.Values) (QueueItemAPI, error)
+	GetBuild(number int) (BuildAPI, error)
+	GetBuildCtx(ctx context.Context, number int) (BuildAPI, error)
+	Get(name string) (JobAPI, error)
+	GetCtx(ctx context.Context, name string) (JobAPI, error)
+	Create(name string, xml io.Reader) (*http.Response, error)
+	CreateCtx(ctx context.Context, name string, xml io.Reader) (*http.Response, error)
+	List(depth int) ([]JobAPI, error)
+	ListCtx(ctx context.Context, depth int) ([]JobAPI, error)
+	Walk(opts *WalkOpts) iter.Seq2[JobAPI, error]
+	WalkCtx(ctx context.Context, opts *WalkOpts) iter.Seq2[JobAPI, error]
+	GetFirstBuild() (BuildAPI, error)
+	GetFirstBuildCtx(ctx context.Context) (BuildAPI, error)
+	GetLastBuild() (BuildAPI, error)
+	GetLastBuildCtx(ctx context.Context) (BuildAPI, error)
+	GetLastCompleteBuild() (BuildAPI, error)
+	GetLastCompleteBuildCtx(ctx context.Context) (BuildAPI, error)
+	GetLastFailedBuild() (BuildAPI, error)
+	GetLastFailedBuildCtx(ctx context.Context) (BuildAPI, error)
+	GetLastStableBuild() (BuildAPI, error)
+	GetLastStableBuildCtx(ctx context.Context) (BuildAPI, error)
+	GetLastUnstableBuild() (BuildAPI, error)
+	GetLastUnstableBuildCtx(ctx context.Context) (BuildAPI, error)
+	GetLastSuccessfulBuild() (BuildAPI, error)
+	GetLastSuccessfulBuildCtx(ctx context.Context) (BuildAPI, error)
+	GetLastUnsucessfulBuild() (BuildAPI, error)
+	GetLastUnsucessfulBuildCtx(ctx context.Context) (BuildAPI, error)
+	GetBuildByName(name string) (BuildAPI, error)
+	GetBuildByNameCtx(ctx context.Context, name string) (BuildAPI, error)
+	Delete() (*http.Response, error)
+	DeleteCtx(ctx context.Context) (*http.Response, error)
+	ListBuilds() ([]BuildAPI, error)
+	ListBuildsCtx(ctx context.Context) ([]BuildAPI, error)
+	ListAllBuilds(opts *HistoryOpts) ([]*BuildJson, error)
+	ListAllBuildsCtx(ctx context.Context, opts *HistoryOpts) ([]*BuildJson, error)
+	SetNextBuildNumber(number int) (*http.Response, error)
+	SetNextBuildNumberCtx(ctx context.Context, number int) (*http.Response, error)
+	GetParameters() ([]*ParameterDefinition, error)
+	GetParametersCtx(ctx context.Context) ([]*ParameterDefinition, error)
+	SCMPolling() (*http.Response, error)
+	SCMPollingCtx(ctx context.Context) (*http.Response, error)
+	GetMultibranchPipelineScanLog() (string, error)
+	GetMultibranchPipelineScanLogCtx(ctx context.Context) (string, error)
+}
+
+type BuildAPI interface {
+	ItemAPI
+	IsBuilding() (bool, error)
+	IsBuildingCtx(ctx context.Context) (bool, error)
+	GetResult() (string, error)
+	GetResultCtx(ctx context.Context) (string, error)
+	Delete() (*http.Response, error)
+	DeleteCtx(ctx context.Context) (*http.Response, error)
+	Stop() (*http.Response, error)
+	StopCtx(ctx context.Context) (*http.Response, error)
+	Kill() (*http.Response, error)
+	KillCtx(ctx context.Context) (*http.Response, error)
+	Term() (*http.Response, error)
+	TermCtx(ctx context.Context) (*http.Response, error)
+	GetJob() (JobAPI, error)
+	GetJobCtx(ctx context.Context) (JobAPI, error)
+	LoopLog(f func(line string) error) error
+	LoopLogCtx(ctx context.Context, f func(line string) error) error
+	LoopProgressiveLog(kind string, f func(line string) error) error
+	StreamLog(ctx context.Context, kind string, f func(line string) error) error
+	GetDescription() (string, error)
+	GetDescriptionCtx(ctx context.Context) (string, error)
+	SetDescription(description string) (*http.Response, error)
+	SetDescriptionCtx(ctx context.Context, description string) (*http.Response, error)
+}
+
+type QueueItemAPI interface {
+	ItemAPI
+	GetJob() (JobAPI, error)
+	GetJobCtx(ctx context.Context) (JobAPI, error)
+	GetBuild() (BuildAPI, error)
+	GetBuildCtx(ctx context.Context) (BuildAPI, error)
+}
+
+type QueueAPI interface {
+	ItemAPI
+	List() ([]QueueItemAPI, error)
+	ListCtx(ctx context.Context) ([]QueueItemAPI, error)
+	Get(id int) (QueueItemAPI, error)
+	GetCtx(ctx context.Context, id int) (QueueItemAPI, error)
+	Cancel(id int) (*http.Response, error)
+	CancelCtx(ctx context.Context, id int) (*http.Response, error)
+}
+
+type NodesAPI interface {
+	ItemAPI
+	GetBuilds() ([]BuildAPI, error)
+	GetBuildsCtx(ctx context.Context) ([]BuildAPI, error)
+	Get(name string) (*Computer, error)
+	GetCtx(ctx context.Context, name string) (*Computer, error)
+	List() ([]*Computer, error)
+	ListCtx(ctx context.Context) ([]*Computer, error)
+	Enable(name string) (*http.Response, error)
+	EnableCtx(ctx context.Context, name string) (*http.Response, error)
+	Disable(name, msg string) (*http.Response, error)
+	DisableCtx(ctx context.Context, name, msg string) (*http.Response, error)
+	Delete(name string) (*http.Response, error)
+	DeleteCtx(ctx context.Context, name string) (*http.Response, error)
+	GetConfigure(name string) (string, error)
+	GetConfigureCtx(ctx context.Context, name string) (string, error)
+	SetConfigure(name string, xml io.Reader) (*http.Response, error)
+	SetConfigureCtx(ctx context.Context, name string, xml io.Reader) (*http.Response, error)
+	Create(name string, xml io.Reader) (*http.Response, error)
+	CreateCtx(ctx context.Context, name string, xml io.Reader) (*http.Response, error)
+}
+
+type PluginsAPI interface {
+	ItemAPI
+	Get(shortName string) (*PluginJson, error)
+	GetCtx(ctx context.Context, shortName string) (*PluginJson, error)
+	List() ([]*PluginJson, error)
+	ListCtx(ctx context.Context) ([]*PluginJson, error)
+}
+
+type CredentialsAPI interface {
+	ItemAPI
+	Get(name string) (*CredentialJson, error)
+	GetCtx(ctx context.Context, name string) (*CredentialJson, error)
+	Create(xml io.Reader) (*http.Response, error)
+	CreateCtx(ctx context.Context, xml io.Reader) (*http.Response, error)
+	Delete(name string) (*http.Response, error)
+	DeleteCtx(ctx context.Context, name string) (*http.Response, error)
+	GetConfigure(name string) (string, error)
+	GetConfigureCtx(ctx context.Context, name string) (string, error)
+	SetConfigure(name string, xml io.Reader) (*http.Response, error)
+	SetConfigureCtx(ctx context.Context, name string, xml io.Reader) (*http.Response, error)
+	List() ([]*CredentialJson, error)
+	ListCtx(ctx context.Context) ([]*CredentialJson, error)
+}
+
+type ViewsAPI interface {
+	ItemAPI
+	Get(name string) (*ViewJson, error)
+	GetCtx(ctx context.Context, name string) (*ViewJson, error)
+	Create(name string, xml io.Reader) (*http.Response, error)
+	CreateCtx(ctx context.Context, name string, xml io.Reader) (*http.Response, error)
+	Delete(name string) (*http.Response, error)
+	DeleteCtx(ctx context.Context, name string) (*http.Response, error)
+	AddJobToView(name, jobName string) (*http.Response, error)
+	AddJobToViewCtx(ctx context.Context, name, jobName string) (*http.Response, error)
+	RemoveJobFromView(name, jobName string) (*http.Response, error)
+	RemoveJobFromViewCtx(ctx context.Context, name, jobName string) (*http.Response, error)
+	GetConfigure(name string) (string, error)
+	GetConfigureCtx(ctx context.Context, name string) (string, error)
+	SetConfigure(name string, xml io.Reader) (*http.Response, error)
+	SetConfigureCtx(ctx context.Context, name string, xml io.Reader) (*http.Response, error)
+	SetDescription(name, description string) (*http.Response, error)
+	SetDescriptionCtx(ctx context.Context, name, description string) (*http.Response, error)
+	List() ([]*ViewJson, error)
+	ListCtx(ctx context.Context) ([]*ViewJson, error)
+}
+
+var (
+	_ JenkinsAPI     = jenkinsAPI{}
+	_ JobAPI         = jobAPI{}
+	_ BuildAPI       = buildAPI{}
+	_ QueueItemAPI   = queueItemAPI{}
+	_ QueueAPI       = queueAPI{}
+	_ NodesAPI       = nodesAPI{}
+	_ PluginsAPI     = (*Plugins)(nil)
+	_ CredentialsAPI = (*Credentials)(nil)
+	_ ViewsAPI       = (*Views)(nil)
+)
+
+// API returns c as JenkinsAPI, whose methods return jobs, builds and queue
+// items as interfaces too, so code using it can be tested with jenkinsmock.
+func (c *Jenkins) API() JenkinsAPI {
+	return jenkinsAPI{c}
+}
+
+// API returns j as JobAPI, see Jenkins.API.
+func (j *Job) API() JobAPI {
+	return jobAPI{j}
+}
+
+// API returns b as BuildAPI, see Jenkins.API.
+func (b *Build) API() BuildAPI {
+	return buildAPI{b}
+}
+
+// API returns q as QueueItemAPI, see Jenkins.API.
+func (q *OneQueueItem) API() QueueItemAPI {
+	return queueItemAPI{q}
+}
+
+// API returns q as QueueAPI, see Jenkins.API.
+func (q *Queue) API() QueueAPI {
+	return queueAPI{q}
+}
+
+// API returns n as NodesAPI, see Jenkins.API.
+func (n *Nodes) API() NodesAPI {
+	return nodesAPI{n}
+}
+
+// nil is kept, so callers can compare result with nil
+func asJobAPI(j *Job) JobAPI {
+	if j == nil {
+		return nil
+	}
+	return jobAPI{j}
+}
+
+func asBuildAPI(b *Build) BuildAPI {
+	if b == nil {
+		return nil
+	}
+	return buildAPI{b}
+}
+
+func asQueueItemAPI(q *OneQueueItem) QueueItemAPI {
+	if q == nil {
+		return nil
+	}
+	return queueItemAPI{q}
+}
+
+func asAPIs[T, A any](items []T, as func(T) A) []A {
+	if items == nil {
+		return nil
+	}
+	apis := make([]A, len(items))
+	for i, item := range items {
+		apis[i] = as(item)
+	}
+	return apis
+}
+
+func asJobAPISeq(seq iter.Seq2[*Job, error]) iter.Seq2[JobAPI, error] {
+	return func(yield func(JobAPI, error) bool) {
+		for job, err := range seq {
+			if !yield(asJobAPI(job), err) {
+				return
+			}
+		}
+	}
+}
+
+// adapters below embed the concrete type and convert results of methods
+// which return concrete types
+
+type jenkinsAPI struct {
+	*Jenkins
+}
+
+func (a jenkinsAPI) GetJob(fullName string) (JobAPI, error) {
+	v, err := a.Jenkins.GetJob(fullName)
+	return asJobAPI(v), err
+}
+
+func (a jenkinsAPI) GetJobCtx(ctx context.Context, fullName string) (JobAPI, error) {
+	v, err := a.Jenkins.GetJobCtx(ctx, fullName)
+	return asJobAPI(v), err
+}
+
+func (a jenkinsAPI) EnsureFolder(fullName string) (JobAPI, error) {
+	v, err := a.Jenkins.EnsureFolder(fullName)
+	return asJobAPI(v), err
+}
+
+func (a jenkinsAPI) EnsureFolderCtx(ctx context.Context, fullName string) (JobAPI, error) {
+	v, err := a.Jenkins.EnsureFolderCtx(ctx, fullName)
+	return asJobAPI(v), err
+}
+
+func (a jenkinsAPI) CreateOrUpdateJob(fullName string, xml io.Reader) (JobAPI, error) {
+	v, err := a.Jenkins.CreateOrUpdateJob(fullName, xml)
+	return asJobAPI(v), err
+}
+
+func (a jenkinsAPI) CreateOrUpdateJobCtx(ctx context.Context, fullName string, xml io.Reader) (JobAPI, error) {
+	v, err := a.Jenkins.CreateOrUpdateJobCtx(ctx, fullName, xml)
+	return asJobAPI(v), err
+}
+
+func (a jenkinsAPI) BuildJob(fullName string, params url.Values) (QueueItemAPI, error) {
+	v, err := a.Jenkins.BuildJob(fullName, params)
+	return asQueueItemAPI(v), err
+}
+
+func (a jenkinsAPI) BuildJobCtx(ctx context.Context, fullName string, params url.Values) (QueueItemAPI, error) {
+	v, err := a.Jenkins.BuildJobCtx(ctx, fullName, params)
+	return asQueueItemAPI(v), err
+}
+
+func (a jenkinsAPI) ListJobs(depth int) ([]JobAPI, error) {
+	v, err := a.Jenkins.ListJobs(depth)
+	return asAPIs(v, asJobAPI), err
+}
+
+func (a jenkinsAPI) ListJobsCtx(ctx context.Context, depth int) ([]JobAPI, error) {
+	v, err := a.Jenkins.ListJobsCtx(ctx, depth)
+	return asAPIs(v, asJobAPI), err
+}
+
+func (a jenkinsAPI) AllJobs(opts *WalkOpts) iter.Seq2[JobAPI, error] {
+	return asJobAPISeq(a.Jenkins.AllJobs(opts))
+}
+
+func (a jenkinsAPI) AllJobsCtx(ctx context.Context, opts *WalkOpts) iter.Seq2[JobAPI, error] {
+	return asJobAPISeq(a.Jenkins.AllJobsCtx(ctx, opts))
+}
+
+type jobAPI struct {
+	*Job
+}
+
+func (a jobAPI) RenameTo(name string) (JobAPI, error) {
+	v, err := a.Job.RenameTo(name)
+	return asJobAPI(v), err
+}
+
+func (a jobAPI) RenameToCtx(ctx context.Context, name string) (JobAPI, error) {
+	v, err := a.Job.RenameToCtx(ctx, name)
+	return asJobAPI(v), err
+}
+
+func (a jobAPI) MoveTo(folder string) (JobAPI, error) {
+	v, err := a.Job.MoveTo(folder)
+	return asJobAPI(v), err
+}
+
+func (a jobAPI) MoveToCtx(ctx context.Context, folder string) (JobAPI, error) {
+	v, err := a.Job.MoveToCtx(ctx, folder)
+	return asJobAPI(v), err
+}
+
+func (a jobAPI) GetParent() (JobAPI, error) {
+	v, err := a.Job.GetParent()
+	return asJobAPI(v), err
+}
+
+func (a jobAPI) GetParentCtx(ctx context.Context) (JobAPI, error) {
+	v, err := a.Job.GetParentCtx(ctx)
+	return asJobAPI(v), err
+}
+
+func (a jobAPI) DiffConfigure(other JobAPI) (*XMLDiff, error) {
+	return a.DiffConfigureCtx(context.Background(), other)
+}
+
+func (a jobAPI) DiffConfigureCtx(ctx context.Context, other JobAPI) (*XMLDiff, error) {
+	old, err := a.GetConfigureCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	new, err := other.GetConfigureCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return DiffXML(old, new)
+}
+
+func (a jobAPI) Build(param url.Values) (QueueItemAPI, error) {
+	v, err := a.Job.Build(param)
+	return asQueueItemAPI(v), err
+}
+
+func (a jobAPI) BuildCtx(ctx context.Context, param url.Values) (QueueItemAPI, error) {
+	v, err := a.Job.BuildCtx(ctx, param)
+	return asQueueItemAPI(v), err
+}
+
+func (a jobAPI) GetBuild(number int) (BuildAPI, error) {
+	v, err := a.Job.GetBuild(number)
+	return asBuildAPI(v), err
+}
+
+func (a jobAPI) GetBuildCtx(ctx context.Context, number int) (BuildAPI, error) {
+	v, err := a.Job.GetBuildCtx(ctx, number)
+	return asBuildAPI(v), err
+}
+
+func (a jobAPI) Get(name string) (JobAPI, error) {
+	v, err := a.Job.Get(name)
+	return asJobAPI(v), err
+}
+
+func (a jobAPI) GetCtx(ctx context.Context, name string) (JobAPI, error) {
+	v, err := a.Job.GetCtx(ctx, name)
+	return asJobAPI(v), err
+}
+
+func (a jobAPI) List(depth int) ([]JobAPI, error) {
+	v, err := a.Job.List(depth)
+	return asAPIs(v, asJobAPI), err
+}
+
+func (a jobAPI) ListCtx(ctx context.Context, depth int) ([]JobAPI, error) {
+	v, err := a.Job.ListCtx(ctx, depth)
+	return asAPIs(v, asJobAPI), err
+}
+
+func (a jobAPI) Walk(opts *WalkOpts) iter.Seq2[JobAPI, error] {
+	return asJobAPISeq(a.Job.Walk(opts))
+}
+
+func (a jobAPI) WalkCtx(ctx context.Context, opts *WalkOpts) iter.Seq2[JobAPI, error] {
+	return asJobAPISeq(a.Job.WalkCtx(ctx, opts))
+}
+
+func (a jobAPI) GetFirstBuild() (BuildAPI, error) {
+	v, err := a.Job.GetFirstBuild()
+	return asBuildAPI(v), err
+}
+
+func (a jobAPI) GetFirstBuildCtx(ctx context.Context) (BuildAPI, error) {
+	v, err := a.Job.GetFirstBuildCtx(ctx)
+	return asBuildAPI(v), err
+}
+
+func (a jobAPI) GetLastBuild() (BuildAPI, error) {
+	v, err := a.Job.GetLastBuild()
+	return asBuildAPI(v), err
+}
+
+func (a jobAPI) GetLastBuildCtx(ctx context.Context) (BuildAPI, error) {
+	v, err := a.Job.GetLastBuildCtx(ctx)
+	return asBuildAPI(v), err
+}
+
+func (a jobAPI) GetLastCompleteBuild() (BuildAPI, error) {
+	v, err := a.Job.GetLastCompleteBuild()
+	return asBuildAPI(v), err
+}
+
+func (a jobAPI) GetLastCompleteBuildCtx(ctx context.Context) (BuildAPI, error) {
+	v, err := a.Job.GetLastCompleteBuildCtx(ctx)
+	return asBuildAPI(v), err
+}
+
+func (a jobAPI) GetLastFailedBuild() (BuildAPI, error) {
+	v, err := a.Job.GetLastFailedBuild()
+	return asBuildAPI(v), err
+}
+
+func (a jobAPI) GetLastFailedBuildCtx(ctx context.Context) (BuildAPI, error) {
+	v, err := a.Job.GetLastFailedBuildCtx(ctx)
+	return asBuildAPI(v), err
+}
+
+func (a jobAPI) GetLastStableBuild() (BuildAPI, error) {
+	v, err := a.Job.GetLastStableBuild()
+	return asBuildAPI(v), err
+}
+
+func (a jobAPI) GetLastStableBuildCtx(ctx context.Context) (BuildAPI, error) {
+	v, err := a.Job.GetLastStableBuildCtx(ctx)
+	return asBuildAPI(v), err
+}
+
+func (a jobAPI) GetLastUnstableBuild() (BuildAPI, error) {
+	v, err := a.Job.GetLastUnstableBuild()
+	return asBuildAPI(v), err
+}
+
+func (a jobAPI) GetLastUnstableBuildCtx(ctx context.Context) (BuildAPI, error) {
+	v, err := a.Job.GetLastUnstableBuildCtx(ctx)
+	return asBuildAPI(v), err
+}
+
+func (a jobAPI) GetLastSuccessfulBuild() (BuildAPI, error) {
+	v, err := a.Job.GetLastSuccessfulBuild()
+	return asBuildAPI(v), err
+}
+
+func (a jobAPI) GetLastSuccessfulBuildCtx(ctx context.Context) (BuildAPI, error) {
+	v, err := a.Job.GetLastSuccessfulBuildCtx(ctx)
+	return asBuildAPI(v), err
+}
+
+func (a jobAPI) GetLastUnsucessfulBuild() (BuildAPI, error) {
+	v, err := a.Job.GetLastUnsucessfulBuild()
+	return asBuildAPI(v), err
+}
+
+func (a jobAPI) GetLastUnsucessfulBuildCtx(ctx context.Context) (BuildAPI, error) {
+	v, err := a.Job.GetLastUnsucessfulBuildCtx(ctx)
+	return asBuildAPI(v), err
+}
+
+func (a jobAPI) GetBuildByName(name string) (BuildAPI, error) {
+	v, err := a.Job.GetBuildByName(name)
+	return asBuildAPI(v), err
+}
+
+func (a jobAPI) GetBuildByNameCtx(ctx context.Context, name string) (BuildAPI, error) {
+	v, err := a.Job.GetBuildByNameCtx(ctx, name)
+	return asBuildAPI(v), err
+}
+
+func (a jobAPI) ListBuilds() ([]BuildAPI, error) {
+	v, err := a.Job.ListBuilds()
+	return asAPIs(v, asBuildAPI), err
+}
+
+func (a jobAPI) ListBuildsCtx(ctx context.Context) ([]BuildAPI, error) {
+	v, err := a.Job.ListBuildsCtx(ctx)
+	return asAPIs(v, asBuildAPI), err
+}
+
+type buildAPI struct {
+	*Build
+}
+
+func (a buildAPI) GetJob() (JobAPI, error) {
+	v, err := a.Build.GetJob()
+	return asJobAPI(v), err
+}
+
+func (a buildAPI) GetJobCtx(ctx context.Context) (JobAPI, error) {
+	v, err := a.Build.GetJobCtx(ctx)
+	return asJobAPI(v), err
+}
+
+type queueItemAPI struct {
+	*OneQueueItem
+}
+
+func (a queueItemAPI) GetJob() (JobAPI, error) {
+	v, err := a.OneQueueItem.GetJob()
+	return asJobAPI(v), err
+}
+
+func (a queueItemAPI) GetJobCtx(ctx context.Context) (JobAPI, error) {
+	v, err := a.OneQueueItem.GetJobCtx(ctx)
+	return asJobAPI(v), err
+}
+
+func (a queueItemAPI) GetBuild() (BuildAPI, error) {
+	v, err := a.OneQueueItem.GetBuild()
+	return asBuildAPI(v), err
+}
+
+func (a queueItemAPI) GetBuildCtx(ctx context.Context) (BuildAPI, error) {
+	v, err := a.OneQueueItem.GetBuildCtx(ctx)
+	return asBuildAPI(v), err
+}
+
+type queueAPI struct {
+	*Queue
+}
+
+func (a queueAPI) List() ([]QueueItemAPI, error) {
+	v, err := a.Queue.List()
+	return asAPIs(v, asQueueItemAPI), err
+}
+
+func (a queueAPI) ListCtx(ctx context.Context) ([]QueueItemAPI, error) {
+	v, err := a.Queue.ListCtx(ctx)
+	return asAPIs(v, asQueueItemAPI), err
+}
+
+func (a queueAPI) Get(id int) (QueueItemAPI, error) {
+	v, err := a.Queue.Get(id)
+	return asQueueItemAPI(v), err
+}
+
+func (a queueAPI) GetCtx(ctx context.Context, id int) (QueueItemAPI, error) {
+	v, err := a.Queue.GetCtx(ctx, id)
+	return asQueueItemAPI(v), err
+}
+
+type nodesAPI struct {
+	*Nodes
+}
+
+func (a nodesAPI) GetBuilds() ([]BuildAPI, error) {
+	v, err := a.Nodes.GetBuilds()
+	return asAPIs(v, asBuildAPI), err
+}
+
+func (a nodesAPI) GetBuildsCtx(ctx context.Context) ([]BuildAPI, error) {
+	v, err := a.Nodes.GetBuildsCtx(ctx)
+	return asAPIs(v, asBuildAPI), err
 }
@@ -0,0 +1,58 @@
+package jenkins
+
+import (
+	"testing"
+
+	"github.com/joelee2012/go-jenkins/jenkinstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI(t *testing.T) {
+	srv := jenkinstest.NewServer()
+	defer srv.Close()
+	require.NoError(t, srv.CreateJob("folder", folderConf))
+	require.NoError(t, srv.CreateJob("folder/pipeline", jobConf))
+	client, err := New(srv.URL, "admin", "1234")
+	require.NoError(t, err)
+	api := client.API()
+
+	job, err := api.GetJob("folder/pipeline")
+	require.NoError(t, err)
+	assert.Equal(t, "<WorkflowJob: "+srv.URL+"/job/folder/job/pipeline/>", job.String())
+	parent, err := job.GetParent()
+	require.NoError(t, err)
+	jobs, err := parent.List(0)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	diff, err := jobs[0].DiffConfigure(job)
+	require.NoError(t, err)
+	assert.True(t, diff.Equal())
+	var walked []string
+	for job, err := range api.AllJobs(nil) {
+		require.NoError(t, err)
+		walked = append(walked, job.String())
+	}
+	assert.Len(t, walked, 2)
+
+	// nil is not wrapped
+	job, err = api.GetJob("missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Nil(t, job)
+
+	item, err := api.BuildJob("folder/pipeline", nil)
+	require.NoError(t, err)
+	build, err := item.GetBuild()
+	require.NoError(t, err)
+	result, err := build.GetResult()
+	require.NoError(t, err)
+	assert.Equal(t, "SUCCESS", result)
+	job, err = build.GetJob()
+	require.NoError(t, err)
+	builds, err := job.ListBuilds()
+	require.NoError(t, err)
+	assert.Len(t, builds, 1)
+	builds, err = client.Nodes().API().GetBuilds()
+	require.NoError(t, err)
+	assert.Empty(t, builds)
+}
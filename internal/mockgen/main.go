@@ -0,0 +1,305 @@
+// Command mockgen generates jenkinsmock from interfaces declared in
+// interface.go, run it with go generate in root of module.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"slices"
+	"strings"
+)
+
+const (
+	pkgName  = "jenkins"
+	pkgPath  = "github.com/joelee2012/go-jenkins"
+	mockName = "jenkinsmock"
+)
+
+func main() {
+	src := flag.String("src", "interface.go", "file declaring interfaces")
+	out := flag.String("out", "jenkinsmock/mock_gen.go", "generated file")
+	flag.Parse()
+	data, err := os.ReadFile(*src)
+	if err != nil {
+		log.Fatal(err)
+	}
+	code, err := generate(data)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, code, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+type method struct {
+	name    string
+	params  []param
+	results []string
+}
+
+type param struct {
+	name, typ string
+	variadic  bool
+}
+
+type generator struct {
+	// import path of package names used in signatures
+	imports    map[string]string
+	used       map[string]bool
+	interfaces map[string]*ast.InterfaceType
+}
+
+func generate(src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, 0)
+	if err != nil {
+		return nil, err
+	}
+	g := &generator{
+		imports:    map[string]string{},
+		used:       map[string]bool{pkgName: true},
+		interfaces: map[string]*ast.InterfaceType{},
+	}
+	for _, spec := range f.Imports {
+		path := strings.Trim(spec.Path.Value, `"`)
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		g.imports[name] = path
+	}
+	g.imports[pkgName] = pkgPath
+
+	var names []string
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if it, ok := ts.Type.(*ast.InterfaceType); ok && ts.Name.IsExported() {
+				g.interfaces[ts.Name.Name] = it
+				names = append(names, ts.Name.Name)
+			}
+		}
+	}
+
+	var body bytes.Buffer
+	for _, name := range names {
+		methods, err := g.methods(name)
+		if err != nil {
+			return nil, err
+		}
+		g.writeMock(&body, name, methods)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by mockgen. DO NOT EDIT.\n\npackage %s\n\nimport (\n", mockName)
+	var paths []string
+	for name := range g.used {
+		paths = append(paths, g.imports[name])
+	}
+	slices.Sort(paths)
+	// standard library first
+	std := func(path string) bool {
+		first, _, _ := strings.Cut(path, "/")
+		return !strings.Contains(first, ".")
+	}
+	slices.SortStableFunc(paths, func(a, b string) int {
+		if std(a) == std(b) {
+			return 0
+		}
+		if std(a) {
+			return -1
+		}
+		return 1
+	})
+	for i, path := range paths {
+		if i > 0 && std(paths[i-1]) != std(path) {
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(&buf, "\t%q\n", path)
+	}
+	buf.WriteString(")\n")
+	buf.Write(body.Bytes())
+	return format.Source(buf.Bytes())
+}
+
+// methods of interface name including those of embedded interfaces, in order
+// of declaration
+func (g *generator) methods(name string) ([]*method, error) {
+	it, ok := g.interfaces[name]
+	if !ok {
+		return nil, fmt.Errorf("mockgen: interface %s is not declared", name)
+	}
+	var methods []*method
+	for _, field := range it.Methods.List {
+		switch t := field.Type.(type) {
+		case *ast.Ident:
+			embedded, err := g.methods(t.Name)
+			if err != nil {
+				return nil, err
+			}
+			methods = append(methods, embedded...)
+		case *ast.FuncType:
+			m := &method{name: field.Names[0].Name}
+			if t.Params != nil {
+				for _, p := range t.Params.List {
+					typ, variadic := p.Type, false
+					if e, ok := typ.(*ast.Ellipsis); ok {
+						typ, variadic = e.Elt, true
+					}
+					names := p.Names
+					if len(names) == 0 {
+						names = []*ast.Ident{{Name: fmt.Sprintf("p%d", len(m.params))}}
+					}
+					for _, n := range names {
+						m.params = append(m.params, param{name: n.Name, typ: g.typ(typ), variadic: variadic})
+					}
+				}
+			}
+			if t.Results != nil {
+				for _, r := range t.Results.List {
+					for range max(len(r.Names), 1) {
+						m.results = append(m.results, g.typ(r.Type))
+					}
+				}
+			}
+			methods = append(methods, m)
+		default:
+			return nil, fmt.Errorf("mockgen: unsupported element of interface %s", name)
+		}
+	}
+	return methods, nil
+}
+
+// typ returns type expression as written in package jenkinsmock
+func (g *generator) typ(e ast.Expr) string {
+	switch t := e.(type) {
+	case *ast.Ident:
+		if t.IsExported() {
+			return pkgName + "." + t.Name
+		}
+		return t.Name
+	case *ast.SelectorExpr:
+		pkg := t.X.(*ast.Ident).Name
+		g.used[pkg] = true
+		return pkg + "." + t.Sel.Name
+	case *ast.StarExpr:
+		return "*" + g.typ(t.X)
+	case *ast.ArrayType:
+		return "[]" + g.typ(t.Elt)
+	case *ast.MapType:
+		return "map[" + g.typ(t.Key) + "]" + g.typ(t.Value)
+	case *ast.Ellipsis:
+		return "..." + g.typ(t.Elt)
+	case *ast.IndexExpr:
+		return g.typ(t.X) + "[" + g.typ(t.Index) + "]"
+	case *ast.IndexListExpr:
+		args := make([]string, len(t.Indices))
+		for i, index := range t.Indices {
+			args[i] = g.typ(index)
+		}
+		return g.typ(t.X) + "[" + strings.Join(args, ", ") + "]"
+	case *ast.FuncType:
+		var params, results []string
+		if t.Params != nil {
+			for _, p := range t.Params.List {
+				for range max(len(p.Names), 1) {
+					params = append(params, g.typ(p.Type))
+				}
+			}
+		}
+		if t.Results != nil {
+			for _, r := range t.Results.List {
+				for range max(len(r.Names), 1) {
+					results = append(results, g.typ(r.Type))
+				}
+			}
+		}
+		s := "func(" + strings.Join(params, ", ") + ")"
+		switch len(results) {
+		case 0:
+		case 1:
+			s += " " + results[0]
+		default:
+			s += " (" + strings.Join(results, ", ") + ")"
+		}
+		return s
+	case *ast.InterfaceType:
+		return "interface{}"
+	}
+	panic(fmt.Sprintf("mockgen: unsupported type %T", e))
+}
+
+func (g *generator) writeMock(w *bytes.Buffer, iface string, methods []*method) {
+	name := strings.TrimSuffix(iface, "API")
+	fmt.Fprintf(w, "\n// %s is a mock of jenkins.%s, methods return result of\n", name, iface)
+	fmt.Fprintf(w, "// XxxFunc if set, zero values otherwise.\n")
+	fmt.Fprintf(w, "type %s struct {\n\tRecorder\n", name)
+	for _, m := range methods {
+		fmt.Fprintf(w, "\t%sFunc func(%s) %s\n", m.name, m.signatureParams(), m.signatureResults())
+	}
+	w.WriteString("}\n")
+	fmt.Fprintf(w, "\nvar _ jenkins.%s = (*%s)(nil)\n", iface, name)
+	for _, m := range methods {
+		fmt.Fprintf(w, "\nfunc (m *%s) %s(%s) %s {\n", name, m.name, m.signatureParams(), m.signatureResults())
+		args := make([]string, len(m.params))
+		for i, p := range m.params {
+			args[i] = p.name
+		}
+		recorded := append([]string{fmt.Sprintf("%q", m.name)}, args...)
+		fmt.Fprintf(w, "\tm.record(%s)\n", strings.Join(recorded, ", "))
+		if n := len(args); n > 0 && m.params[n-1].variadic {
+			args[n-1] += "..."
+		}
+		call := fmt.Sprintf("m.%sFunc(%s)", m.name, strings.Join(args, ", "))
+		fmt.Fprintf(w, "\tif m.%sFunc != nil {\n", m.name)
+		if len(m.results) > 0 {
+			fmt.Fprintf(w, "\t\treturn %s\n", call)
+		} else {
+			fmt.Fprintf(w, "\t\t%s\n\t\treturn\n", call)
+		}
+		w.WriteString("\t}\n")
+		if len(m.results) > 0 {
+			zeros := make([]string, len(m.results))
+			for i, r := range m.results {
+				zeros[i] = fmt.Sprintf("r%d", i)
+				fmt.Fprintf(w, "\tvar r%d %s\n", i, r)
+			}
+			fmt.Fprintf(w, "\treturn %s\n", strings.Join(zeros, ", "))
+		}
+		w.WriteString("}\n")
+	}
+}
+
+func (m *method) signatureParams() string {
+	params := make([]string, len(m.params))
+	for i, p := range m.params {
+		typ := p.typ
+		if p.variadic {
+			typ = "..." + typ
+		}
+		params[i] = p.name + " " + typ
+	}
+	return strings.Join(params, ", ")
+}
+
+func (m *method) signatureResults() string {
+	switch len(m.results) {
+	case 0:
+		return ""
+	case 1:
+		return m.results[0]
+	}
+	return "(" + strings.Join(m.results, ", ") + ")"
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateIsUpToDate(t *testing.T) {
+	src, err := os.ReadFile("../../interface.go")
+	require.NoError(t, err)
+	code, err := generate(src)
+	require.NoError(t, err)
+	generated, err := os.ReadFile("../../jenkinsmock/mock_gen.go")
+	require.NoError(t, err)
+	assert.Equal(t, string(generated), string(code), "run go generate to update jenkinsmock")
+}
@@ -1,6 +1,7 @@
 package jenkins
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -11,6 +12,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type Item struct {
@@ -45,15 +47,19 @@ func (o *ApiJsonOpts) Encode() string {
 //	jenkins.ApiJson(&data, &ApiJsonOpts{"tree":"description"})
 //	fmt.Println(data["description"])
 func (i *Item) ApiJson(v any, opts *ApiJsonOpts) error {
-	return unmarshalApiJson(i, v, opts)
+	return i.ApiJsonCtx(context.Background(), v, opts)
 }
 
-func unmarshalApiJson(r Requester, v any, opts *ApiJsonOpts) error {
+func (i *Item) ApiJsonCtx(ctx context.Context, v any, opts *ApiJsonOpts) error {
+	return unmarshalApiJson(ctx, i, v, opts)
+}
+
+func unmarshalApiJson(ctx context.Context, r Requester, v any, opts *ApiJsonOpts) error {
 	entry := "api/json"
 	if opts != nil {
 		entry = "api/json?" + opts.Encode()
 	}
-	resp, err := r.Request("GET", entry, nil)
+	resp, err := r.RequestCtx(ctx, "GET", entry, nil)
 	if err != nil {
 		return err
 	}
@@ -69,7 +75,13 @@ func unmarshalApiJson(r Requester, v any, opts *ApiJsonOpts) error {
 }
 
 func (i *Item) Request(method, entry string, body io.Reader) (*http.Response, error) {
-	return i.jenkins.doRequest(method, i.URL+entry, body)
+	return i.RequestCtx(context.Background(), method, entry, body)
+}
+
+// Send request to entry relative to item url, the request is aborted once ctx
+// is done.
+func (i *Item) RequestCtx(ctx context.Context, method, entry string, body io.Reader) (*http.Response, error) {
+	return i.jenkins.doRequest(contextWithItem(ctx, i), method, i.URL+entry, body)
 }
 
 func (i *Item) String() string {
@@ -95,6 +107,18 @@ func parseId(url string) int {
 	return id
 }
 
+// sleep for d or until ctx is done, whichever comes first
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
 func prettyPrintJson(v any) {
 	json, err := json.MarshalIndent(v, "", "  ")
 	if err != nil {
@@ -1,14 +1,20 @@
 package jenkins
 
 import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"path"
 	"strings"
+	"sync"
 )
 
 type JenkinsOpts struct {
@@ -16,18 +22,54 @@ type JenkinsOpts struct {
 	User     string
 	Password string
 	http.Client
-}
-
+	// options of transport, which is cloned from BaseTransport or
+	// http.DefaultTransport if any of them is set
+	BaseTransport   *http.Transport
+	RootCAs         *x509.CertPool
+	Certificates    []tls.Certificate
+	Proxy           func(*http.Request) (*url.URL, error)
+	UserAgent       string
+	VerifyOnConnect bool
+	RetryPolicy     *RetryPolicy
+	Interceptors    []Interceptor
+	Logger          *slog.Logger
+	LogLevel        slog.Level
+	LogErrorLevel   slog.Level
+	LogBodyPreview  int
+	Cache           *CacheOpts
+	Limit           *LimitOpts
+}
+
+// Jenkins is safe for concurrent use by multiple goroutines, so are the
+// services returned by its accessors and Job except the deprecated Job.Rename
+// and Job.Move.
+// Header is copied to every request, it must not be modified once the client
+// is in use.
 type Jenkins struct {
 	*Item
+	// guards client and lazily created services
+	mu          sync.Mutex
 	client      *http.Client
-	crumb       *Crumb
 	credentials *Credentials
 	nodes       *Nodes
+	plugins     *Plugins
 	queue       *Queue
 	views       *Views
-	Header      http.Header
-	Debug       bool
+	// guards crumb and cookie, it is held while crumb is being fetched so
+	// that concurrent requests share one fetch
+	crumbMu sync.Mutex
+	crumb   *Crumb
+	cookie  string
+	retry   *RetryPolicy
+	log     *requestLogger
+	metrics *metrics
+	cache   *cache
+	limiter *limiter
+	// guarded by mu
+	interceptors []Interceptor
+	Header       http.Header
+	// log requests to stdout if no logger is configured by WithLogger
+	Debug bool
 }
 
 type Crumb struct {
@@ -96,39 +138,81 @@ type Crumb struct {
 //		})
 //	}
 func New(url, user, password string) (*Jenkins, error) {
-	url = appendSlash(url)
+	return NewWithOptions(url, WithBasicAuth(user, password))
+}
+
+func newJenkins(o *JenkinsOpts) *Jenkins {
 	c := &Jenkins{Header: make(http.Header)}
-	c.Item = NewItem(url, "Jenkins", c)
-	c.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", user, password))))
+	c.Item = NewItem(appendSlash(o.URL), "Jenkins", c)
+	c.metrics = newMetrics(c.URL)
+	c.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", o.User, o.Password))))
 	c.Header.Set("Accept", "application/json")
 	c.Header.Set("Content-Type", "application/xml; charset=UTF-8")
-	return c, nil
+	if o.UserAgent != "" {
+		c.Header.Set("User-Agent", o.UserAgent)
+	}
+	c.retry = o.RetryPolicy
+	if o.Cache != nil {
+		c.cache = newCache(*o.Cache, c.URL)
+	}
+	if o.Limit != nil {
+		c.limiter = newLimiter(*o.Limit)
+	}
+	c.interceptors = o.Interceptors
+	if o.Logger != nil {
+		c.log = &requestLogger{
+			logger:      o.Logger,
+			level:       o.LogLevel,
+			errorLevel:  o.LogErrorLevel,
+			bodyPreview: o.LogBodyPreview,
+		}
+	}
+	return c
 }
 
+// Replace http client, note that Job.Rename() and Move() require redirect is
+// not followed, see Client().
 func (j *Jenkins) SetClient(c *http.Client) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
 	j.client = c
 }
 
 func (j *Jenkins) Client() *http.Client {
+	j.mu.Lock()
+	defer j.mu.Unlock()
 	if j.client == nil {
-		// disable redirect for Job.Rename() and Move()
-		j.client = &http.Client{
-			CheckRedirect: func(req *http.Request, via []*http.Request) error {
-				return http.ErrUseLastResponse
-			},
-		}
+		j.client = &http.Client{CheckRedirect: noRedirect}
 	}
 	return j.client
 }
 
+// disable redirect for Job.Rename() and Move()
+func noRedirect(req *http.Request, via []*http.Request) error {
+	return http.ErrUseLastResponse
+}
+
 func (j *Jenkins) Nodes() *Nodes {
+	j.mu.Lock()
+	defer j.mu.Unlock()
 	if j.nodes == nil {
 		j.nodes = &Nodes{Item: NewItem(j.URL+"computer/", "Nodes", j)}
 	}
 	return j.nodes
 }
 
+func (j *Jenkins) Plugins() *Plugins {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.plugins == nil {
+		j.plugins = &Plugins{Item: NewItem(j.URL+"pluginManager/", "Plugins", j)}
+	}
+	return j.plugins
+}
+
 func (j *Jenkins) Views() *Views {
+	j.mu.Lock()
+	defer j.mu.Unlock()
 	if j.views == nil {
 		j.views = &Views{Item: NewItem(j.URL, "Views", j)}
 	}
@@ -136,6 +220,8 @@ func (j *Jenkins) Views() *Views {
 }
 
 func (j *Jenkins) Credentials() *Credentials {
+	j.mu.Lock()
+	defer j.mu.Unlock()
 	if j.credentials == nil {
 		j.credentials = &Credentials{Item: NewItem(j.URL+"credentials/store/system/domain/_/", "Credentials", j)}
 	}
@@ -143,6 +229,8 @@ func (j *Jenkins) Credentials() *Credentials {
 }
 
 func (j *Jenkins) Queue() *Queue {
+	j.mu.Lock()
+	defer j.mu.Unlock()
 	if j.queue == nil {
 		j.queue = &Queue{Item: NewItem(j.URL+"queue/", "Queue", j)}
 	}
@@ -150,76 +238,113 @@ func (j *Jenkins) Queue() *Queue {
 }
 
 func (c *Jenkins) GetCrumb() (*Crumb, error) {
+	return c.GetCrumbCtx(context.Background())
+}
+
+func (c *Jenkins) GetCrumbCtx(ctx context.Context) (*Crumb, error) {
+	c.crumbMu.Lock()
+	defer c.crumbMu.Unlock()
 	if c.crumb != nil {
 		return c.crumb, nil
 	}
-	req, err := http.NewRequest("GET", c.URL+"crumbIssuer/api/json", nil)
+	resp, err := c.send(contextWithItem(ctx, c.Item), "GET", c.URL+"crumbIssuer/api/json", nil, false)
 	if err != nil {
 		return nil, err
 	}
-	req.Header = c.Header
-	resp, err := c.Client().Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("%s: %s", resp.Status, c.URL+"crumbIssuer/api/json")
+		return nil, newAPIError(resp.Request, resp)
 	}
+	defer resp.Body.Close()
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, err
 	}
-	if err := json.Unmarshal(body, &c.crumb); err != nil {
+	crumb := &Crumb{}
+	if err := json.Unmarshal(body, crumb); err != nil {
 		return nil, err
 	}
-	c.Header.Set(c.crumb.RequestFields, c.crumb.Value)
-	c.Header.Set("Cookie", resp.Header.Get("set-cookie"))
+	// crumb is bound to session
+	c.crumb, c.cookie = crumb, resp.Header.Get("set-cookie")
 	return c.crumb, nil
 }
 
-func (c *Jenkins) doRequest(method, url string, body io.Reader) (*http.Response, error) {
-	if _, err := c.GetCrumb(); err != nil {
-		return nil, err
+// drop crumb which is rejected by Jenkins, eg: after restart. It is a no-op
+// if crumb has been re-issued by another request already.
+func (c *Jenkins) resetCrumb(stale *Crumb) {
+	c.crumbMu.Lock()
+	defer c.crumbMu.Unlock()
+	if c.crumb == stale {
+		c.crumb, c.cookie = nil, ""
 	}
-	req, err := http.NewRequest(method, url, body)
-	if err != nil {
+}
+
+// copy of Header with crumb and session cookie if they are available
+func (c *Jenkins) requestHeader(withCrumb bool) (http.Header, *Crumb) {
+	header := c.Header.Clone()
+	if !withCrumb {
+		return header, nil
+	}
+	c.crumbMu.Lock()
+	defer c.crumbMu.Unlock()
+	if c.crumb != nil {
+		header.Set(c.crumb.RequestFields, c.crumb.Value)
+		if c.cookie != "" {
+			header.Set("Cookie", c.cookie)
+		}
+	}
+	return header, c.crumb
+}
+
+func (c *Jenkins) doRequest(ctx context.Context, method, url string, body io.Reader) (*http.Response, error) {
+	if _, err := c.GetCrumbCtx(ctx); err != nil {
 		return nil, err
 	}
-	req.Header = c.Header
-	if c.Debug {
-		printRequest(req)
+	var payload []byte
+	if body != nil {
+		// keep body to send it again on retry
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+		payload = data
+	}
+	return c.send(ctx, method, url, payload, true)
+}
+
+// send request through interceptors, response with status code >= 400 is
+// returned as *APIError
+func (c *Jenkins) send(ctx context.Context, method, url string, payload []byte, withCrumb bool) (*http.Response, error) {
+	ctx = ContextWithRequestID(ctx, requestID(ctx))
+	if ItemFromContext(ctx) == nil {
+		ctx = contextWithItem(ctx, c.Item)
+	}
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
 	}
-	resp, err := c.Client().Do(req)
-	if c.Debug {
-		defer printResponse(resp)
+	header, crumb := c.requestHeader(withCrumb)
+	if crumb != nil {
+		ctx = context.WithValue(ctx, crumbKey{}, crumb)
 	}
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, err
 	}
+	req.Header = header
+	resp, err := c.doer().Do(req)
+	if err != nil {
+		// report cancellation as is rather than wrapped in *url.Error
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
 	if resp.StatusCode >= 400 {
-		data, err := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("%s: %s, %s, %s", resp.Status, url, data, err)
+		return nil, newAPIError(req, resp)
 	}
 	return resp, nil
 }
 
-func printRequest(req *http.Request) {
-	fmt.Printf("> %s %s %s\n", req.Method, req.URL.RequestURI(), req.Proto)
-	fmt.Printf("> Host: %s\n", req.Host)
-	fmt.Printf("> Content-Length: %d\n", req.ContentLength)
-	for k, v := range req.Header {
-		fmt.Printf("> %s: %s\n", k, v[0])
-	}
-}
-
-func printResponse(resp *http.Response) {
-	fmt.Printf("< %s %s\n", resp.Proto, resp.Status)
-	for k, v := range resp.Header {
-		fmt.Printf("< %s: %s\n", k, v[0])
-	}
-}
-
 // Get job with fullname:
 //
 //	job, err := jenkins.GetJob("path/to/job")
@@ -228,8 +353,12 @@ func printResponse(resp *http.Response) {
 //	}
 //	fmt.Println(job)
 func (c *Jenkins) GetJob(fullName string) (*Job, error) {
+	return c.GetJobCtx(context.Background(), fullName)
+}
+
+func (c *Jenkins) GetJobCtx(ctx context.Context, fullName string) (*Job, error) {
 	folder, shortName := c.resolveJob(fullName)
-	return folder.Get(shortName)
+	return folder.GetCtx(ctx, shortName)
 }
 
 // Create job with given xml config:
@@ -258,12 +387,20 @@ func (c *Jenkins) GetJob(fullName string) (*Job, error) {
 //		log.Fatalln(err)
 //	}
 func (c *Jenkins) CreateJob(fullName string, xml io.Reader) (*http.Response, error) {
+	return c.CreateJobCtx(context.Background(), fullName, xml)
+}
+
+func (c *Jenkins) CreateJobCtx(ctx context.Context, fullName string, xml io.Reader) (*http.Response, error) {
 	folder, shortName := c.resolveJob(fullName)
-	return folder.Create(shortName, xml)
+	return folder.CreateCtx(ctx, shortName, xml)
 }
 
 func (c *Jenkins) DeleteJob(fullName string) (*http.Response, error) {
-	return NewJob(c.Name2URL(fullName), "Job", c).Delete()
+	return c.DeleteJobCtx(context.Background(), fullName)
+}
+
+func (c *Jenkins) DeleteJobCtx(ctx context.Context, fullName string) (*http.Response, error) {
+	return NewJob(c.Name2URL(fullName), "Job", c).DeleteCtx(ctx)
 }
 
 func (c *Jenkins) resolveJob(fullName string) (*Job, string) {
@@ -296,10 +433,15 @@ func (c *Jenkins) URL2Name(url string) (string, error) {
 
 // Get jenkins version number
 func (c *Jenkins) GetVersion() (string, error) {
-	resp, err := c.Request("HEAD", "", nil)
+	return c.GetVersionCtx(context.Background())
+}
+
+func (c *Jenkins) GetVersionCtx(ctx context.Context) (string, error) {
+	resp, err := c.RequestCtx(ctx, "HEAD", "", nil)
 	if err != nil {
 		return "", err
 	}
+	resp.Body.Close()
 	return resp.Header.Get("X-Jenkins"), nil
 }
 
@@ -311,41 +453,77 @@ func (c *Jenkins) GetVersion() (string, error) {
 //	// with parameters
 //	jenkins.BuildJob("your job", jenkins.ReqParams{"ARG1": "ARG1_VALUE"})
 func (c *Jenkins) BuildJob(fullName string, params url.Values) (*OneQueueItem, error) {
-	return NewJob(c.Name2URL(fullName), "Job", c).Build(params)
+	return c.BuildJobCtx(context.Background(), fullName, params)
+}
+
+func (c *Jenkins) BuildJobCtx(ctx context.Context, fullName string, params url.Values) (*OneQueueItem, error) {
+	return NewJob(c.Name2URL(fullName), "Job", c).BuildCtx(ctx, params)
 }
 
 // List job with depth
 func (c *Jenkins) ListJobs(depth int) ([]*Job, error) {
+	return c.ListJobsCtx(context.Background(), depth)
+}
+
+func (c *Jenkins) ListJobsCtx(ctx context.Context, depth int) ([]*Job, error) {
 	job := NewJob(c.URL, "Folder", c)
-	return job.List(depth)
+	return job.ListCtx(ctx, depth)
 }
 
 func (c *Jenkins) Restart() (*http.Response, error) {
-	return c.Request("POST", "restart", nil)
+	return c.RestartCtx(context.Background())
+}
+
+func (c *Jenkins) RestartCtx(ctx context.Context) (*http.Response, error) {
+	return c.RequestCtx(ctx, "POST", "restart", nil)
 }
 
 func (c *Jenkins) SafeRestart() (*http.Response, error) {
-	return c.Request("POST", "safeRestart", nil)
+	return c.SafeRestartCtx(context.Background())
+}
+
+func (c *Jenkins) SafeRestartCtx(ctx context.Context) (*http.Response, error) {
+	return c.RequestCtx(ctx, "POST", "safeRestart", nil)
 }
 
 func (c *Jenkins) Exit() (*http.Response, error) {
-	return c.Request("POST", "exit", nil)
+	return c.ExitCtx(context.Background())
+}
+
+func (c *Jenkins) ExitCtx(ctx context.Context) (*http.Response, error) {
+	return c.RequestCtx(ctx, "POST", "exit", nil)
 }
 
 func (c *Jenkins) SafeExit() (*http.Response, error) {
-	return c.Request("POST", "safeExit", nil)
+	return c.SafeExitCtx(context.Background())
+}
+
+func (c *Jenkins) SafeExitCtx(ctx context.Context) (*http.Response, error) {
+	return c.RequestCtx(ctx, "POST", "safeExit", nil)
 }
 
 func (c *Jenkins) QuiteDown() (*http.Response, error) {
-	return c.Request("POST", "quietDown", nil)
+	return c.QuiteDownCtx(context.Background())
+}
+
+func (c *Jenkins) QuiteDownCtx(ctx context.Context) (*http.Response, error) {
+	return c.RequestCtx(ctx, "POST", "quietDown", nil)
 }
 
 func (c *Jenkins) CancelQuiteDown() (*http.Response, error) {
-	return c.Request("POST", "cancelQuietDown", nil)
+	return c.CancelQuiteDownCtx(context.Background())
+}
+
+func (c *Jenkins) CancelQuiteDownCtx(ctx context.Context) (*http.Response, error) {
+	return c.RequestCtx(ctx, "POST", "cancelQuietDown", nil)
 }
 
 func (c *Jenkins) ReloadJCasC() (*http.Response, error) {
-	return c.Request("POST", "configuration-as-code/reload", nil)
+	return c.ReloadJCasCCtx(context.Background())
+}
+
+func (c *Jenkins) ReloadJCasCCtx(ctx context.Context) (*http.Response, error) {
+	return c.RequestCtx(ctx, "POST", "configuration-as-code/reload", nil)
 }
 
 // func (c *Jenkins) ExportJCasC(name string) error {
@@ -357,12 +535,16 @@ func (c *Jenkins) ReloadJCasC() (*http.Response, error) {
 // }
 
 func (c *Jenkins) ValidateJenkinsfile(content string) (string, error) {
+	return c.ValidateJenkinsfileCtx(context.Background(), content)
+}
+
+func (c *Jenkins) ValidateJenkinsfileCtx(ctx context.Context, content string) (string, error) {
 	v := url.Values{}
 	v.Add("jenkinsfile", content)
-	return readResponseToString(c, "POST", "pipeline-model-converter/validate?"+v.Encode(), nil)
+	return readResponseToString(ctx, c, "POST", "pipeline-model-converter/validate?"+v.Encode(), nil)
 }
-func readResponseToString(r Requester, method, url string, body io.Reader) (string, error) {
-	resp, err := r.Request(method, url, body)
+func readResponseToString(ctx context.Context, r Requester, method, url string, body io.Reader) (string, error) {
+	resp, err := r.RequestCtx(ctx, method, url, body)
 	if err != nil {
 		return "", err
 	}
@@ -374,8 +556,20 @@ func readResponseToString(r Requester, method, url string, body io.Reader) (stri
 	return string(data), nil
 }
 
+// close body of response which is not read, e.g. response of POST
+func closeResponse(resp *http.Response, err error) error {
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
 func (c *Jenkins) RunScript(script string) (string, error) {
+	return c.RunScriptCtx(context.Background(), script)
+}
+
+func (c *Jenkins) RunScriptCtx(ctx context.Context, script string) (string, error) {
 	v := url.Values{}
 	v.Add("script", script)
-	return readResponseToString(c, "POST", "scriptText?"+v.Encode(), nil)
+	return readResponseToString(ctx, c, "POST", "scriptText?"+v.Encode(), nil)
 }
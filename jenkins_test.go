@@ -1,23 +1,34 @@
 package jenkins
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/joelee2012/go-jenkins/jenkinstest"
 	"github.com/stretchr/testify/assert"
 )
 
 var (
-	jenkins   *Jenkins
-	folder    *Job
-	pipeline  *Job
-	pipeline2 *Job
-	jobConf   = `<?xml version='1.1' encoding='UTF-8'?>
+	jenkins *Jenkins
+	// jenkinstest server which jenkins refers, nil if tests run against live
+	// jenkins
+	server         *jenkinstest.Server
+	jenkinsVersion string
+	folder         *Job
+	pipeline       *Job
+	pipeline2      *Job
+	jobConf        = `<?xml version='1.1' encoding='UTF-8'?>
 <flow-definition plugin="workflow-job">
   <definition class="org.jenkinsci.plugins.workflow.cps.CpsFlowDefinition" plugin="workflow-cps">
     <script>echo  &quot;JENKINS_VERSION&quot;</script>
@@ -85,15 +96,16 @@ var (
 </hudson.model.ListView>`
 )
 
-func setup() error {
+func setup(url, user, password, version string) error {
 	log.Println("execute setup function")
 	var err error
-	jenkins, err = New(os.Getenv("JENKINS_URL"), os.Getenv("JENKINS_USER"), os.Getenv("JENKINS_PASSWORD"))
+	jenkins, err = New(url, user, password)
 	if err != nil {
 		return err
 	}
+	jenkinsVersion = version
 
-	jobConf = strings.ReplaceAll(jobConf, "JENKINS_VERSION", os.Getenv("JENKINS_VERSION"))
+	jobConf = strings.ReplaceAll(jobConf, "JENKINS_VERSION", version)
 	confs := []string{folderConf, folderConf, jobConf, paramsJobConf}
 	names := []string{"folder", "folder/folder1", "folder/pipeline", "folder/pipeline2"}
 
@@ -121,6 +133,43 @@ func setup() error {
 	return nil
 }
 
+// builds on jenkinstest echo what pipelines created by setup echo
+func echoBuild(job string, params url.Values) jenkinstest.BuildScript {
+	switch job {
+	case "folder/pipeline":
+		return jenkinstest.BuildScript{Log: jenkinsVersion + "\n"}
+	case "folder/pipeline2":
+		return jenkinstest.BuildScript{Log: params.Get("ARG1") + "\n"}
+	}
+	return jenkinstest.BuildScript{}
+}
+
+// start a fake jenkins which issues crumb and delegates other requests to h
+func newFakeJenkins(t *testing.T, h http.HandlerFunc) *Jenkins {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/crumbIssuer/api/json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"crumbRequestField":"Jenkins-Crumb","crumb":"fake-crumb"}`)
+	})
+	mux.HandleFunc("/", h)
+	return newTestJenkins(t, mux)
+}
+
+func newTestJenkins(t *testing.T, h http.Handler) *Jenkins {
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	j, err := New(srv.URL, "admin", "1234")
+	assert.Nil(t, err)
+	return j
+}
+
+// skip tests which need what jenkinstest can not serve, e.g. groovy
+func skipUnlessLive(t *testing.T) {
+	t.Helper()
+	if server != nil {
+		t.Skip("JENKINS_URL is not set")
+	}
+}
+
 func tearsdown() {
 	jenkins.DeleteJob("folder")
 }
@@ -139,7 +188,7 @@ func TestNewJenkins(t *testing.T) {
 func TestGetVersion(t *testing.T) {
 	version, err := jenkins.GetVersion()
 	assert.Nil(t, err)
-	assert.Equal(t, os.Getenv("JENKINS_VERSION"), version)
+	assert.Equal(t, jenkinsVersion, version)
 }
 
 func TestName2Url(t *testing.T) {
@@ -156,8 +205,9 @@ func TestName2Url(t *testing.T) {
 		{"/job/job", "job/job/job/job/"},
 		{"job/job", "job/job/job/job/"},
 	}
+	j := newTestJenkins(t, http.NotFoundHandler())
 	for _, test := range tests {
-		assert.Equal(t, jenkins.URL+test.expect, jenkins.Name2URL(test.given))
+		assert.Equal(t, j.URL+test.expect, j.Name2URL(test.given))
 	}
 }
 
@@ -169,11 +219,12 @@ func TestUrl2Name(t *testing.T) {
 		{"job/job", "job/job/job/job/"},
 		{"job/job", "job/job/job/job"},
 	}
+	j := newTestJenkins(t, http.NotFoundHandler())
 	for _, test := range tests {
-		name, _ := jenkins.URL2Name(jenkins.URL + test.given)
+		name, _ := j.URL2Name(j.URL + test.given)
 		assert.Equal(t, test.expect, name)
 	}
-	_, err := jenkins.URL2Name("http://0.0.0.1/job/folder1/")
+	_, err := j.URL2Name("http://0.0.0.1/job/folder1/")
 	assert.NotNil(t, err)
 }
 
@@ -185,7 +236,7 @@ func TestGetJob(t *testing.T) {
 
 	// check job does not exist
 	job, err = jenkins.GetJob("folder/notexist")
-	assert.NotNil(t, err)
+	assert.ErrorIs(t, err, ErrNotFound)
 	assert.Nil(t, job)
 	// wrong path
 	job, err = jenkins.GetJob(pipeline.FullName + "/notexist")
@@ -232,7 +283,7 @@ func TestBuildJob(t *testing.T) {
 		return nil
 	})
 	assert.Nil(t, err)
-	assert.Contains(t, strings.Join(output, ""), os.Getenv("JENKINS_VERSION"))
+	assert.Contains(t, strings.Join(output, ""), jenkinsVersion)
 
 	// test job.GetBuild
 	build1, err := pipeline.GetBuild(build.Number)
@@ -299,12 +350,14 @@ func TestSystemCredentials(t *testing.T) {
 }
 
 func TestRunScript(t *testing.T) {
+	skipUnlessLive(t)
 	output, err := jenkins.RunScript(`println("hi, go-jenkins")`)
 	assert.Nil(t, err)
 	assert.Equal(t, "hi, go-jenkins\n", output)
 }
 
 func TestValidateJenkinsfile(t *testing.T) {
+	skipUnlessLive(t)
 	output, err := jenkins.ValidateJenkinsfile("")
 	assert.Nil(t, err)
 	assert.Contains(t, output, "did not contain the 'pipeline' step")
@@ -333,12 +386,93 @@ func TestQuiteDown(t *testing.T) {
 	assert.False(t, status.QuietingDown)
 }
 
+func TestRequestCtxCancel(t *testing.T) {
+	j := newFakeJenkins(t, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := j.GetJobCtx(ctx, "folder/pipeline")
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	_, err = j.RunScriptCtx(ctx, "println('hi')")
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestConcurrentUse(t *testing.T) {
+	var crumbs int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/crumbIssuer/api/json", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&crumbs, 1)
+		time.Sleep(10 * time.Millisecond)
+		w.Header().Set("Set-Cookie", "JSESSIONID=session")
+		fmt.Fprint(w, `{"crumbRequestField":"Jenkins-Crumb","crumb":"fake-crumb"}`)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Jenkins-Crumb") != "fake-crumb" || r.Header.Get("Cookie") != "JSESSIONID=session" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Query().Get("tree") == "description" {
+			fmt.Fprint(w, `{"description":"concurrent"}`)
+			return
+		}
+		fmt.Fprint(w, `{"jobs":[],"views":[],"computer":[],"items":[],"credentials":[]}`)
+	})
+	j := newTestJenkins(t, mux)
+	job := NewJob(j.URL+"job/folder/", "Folder", j)
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Same(t, j.Nodes(), j.Nodes())
+			assert.Same(t, j.Queue(), j.Queue())
+			assert.Same(t, j.Views(), j.Views())
+			assert.Same(t, j.Credentials(), j.Credentials())
+			assert.Same(t, job.Views(), job.Views())
+			assert.Same(t, job.Credentials(), job.Credentials())
+			assert.NotNil(t, j.Client())
+			description, err := job.GetDescription()
+			assert.Nil(t, err)
+			assert.Equal(t, "concurrent", description)
+			_, err = j.Nodes().List()
+			assert.Nil(t, err)
+			_, err = j.Queue().List()
+			assert.Nil(t, err)
+			_, err = j.Views().List()
+			assert.Nil(t, err)
+			_, err = job.Credentials().List()
+			assert.Nil(t, err)
+			_, err = job.Disable()
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+	// crumb is fetched once for all goroutines
+	assert.Equal(t, int32(1), crumbs)
+	// header of client is not modified by requests
+	assert.Empty(t, j.Header.Get("Jenkins-Crumb"))
+}
+
+// Tests run against live jenkins if JENKINS_URL is set, otherwise against
+// jenkinstest server, others use fake jenkins or replay cassettes in testdata.
 func TestMain(m *testing.M) {
-	if err := setup(); err != nil {
+	var err error
+	if os.Getenv("JENKINS_URL") == "" {
+		server = jenkinstest.NewServer()
+		server.HandleBuild(echoBuild)
+		err = setup(server.URL, "admin", "1234", jenkinstest.DefaultVersion)
+	} else {
+		err = setup(os.Getenv("JENKINS_URL"), os.Getenv("JENKINS_USER"), os.Getenv("JENKINS_PASSWORD"), os.Getenv("JENKINS_VERSION"))
+	}
+	if err != nil {
 		tearsdown()
 		log.Fatal(err)
 	}
 	exitCode := m.Run()
 	tearsdown()
+	if server != nil {
+		server.Close()
+	}
 	os.Exit(exitCode)
 }
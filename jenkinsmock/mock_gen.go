@@ -0,0 +1,2876 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package jenkinsmock
+
+import (
+	"context"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+
+	"github.com/joelee2012/go-jenkins"
+)
+
+// Requester is a mock of jenkins.Requester, methods return result of
+// XxxFunc if set, zero values otherwise.
+type Requester struct {
+	Recorder
+	RequestFunc    func(method string, entry string, body io.Reader) (*http.Response, error)
+	RequestCtxFunc func(ctx context.Context, method string, entry string, body io.Reader) (*http.Response, error)
+}
+
+var _ jenkins.Requester = (*Requester)(nil)
+
+func (m *Requester) Request(method string, entry string, body io.Reader) (*http.Response, error) {
+	m.record("Request", method, entry, body)
+	if m.RequestFunc != nil {
+		return m.RequestFunc(method, entry, body)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Requester) RequestCtx(ctx context.Context, method string, entry string, body io.Reader) (*http.Response, error) {
+	m.record("RequestCtx", ctx, method, entry, body)
+	if m.RequestCtxFunc != nil {
+		return m.RequestCtxFunc(ctx, method, entry, body)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+// Item is a mock of jenkins.ItemAPI, methods return result of
+// XxxFunc if set, zero values otherwise.
+type Item struct {
+	Recorder
+	RequestFunc    func(method string, entry string, body io.Reader) (*http.Response, error)
+	RequestCtxFunc func(ctx context.Context, method string, entry string, body io.Reader) (*http.Response, error)
+	ApiJsonFunc    func(v any, opts *jenkins.ApiJsonOpts) error
+	ApiJsonCtxFunc func(ctx context.Context, v any, opts *jenkins.ApiJsonOpts) error
+	StringFunc     func() string
+}
+
+var _ jenkins.ItemAPI = (*Item)(nil)
+
+func (m *Item) Request(method string, entry string, body io.Reader) (*http.Response, error) {
+	m.record("Request", method, entry, body)
+	if m.RequestFunc != nil {
+		return m.RequestFunc(method, entry, body)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Item) RequestCtx(ctx context.Context, method string, entry string, body io.Reader) (*http.Response, error) {
+	m.record("RequestCtx", ctx, method, entry, body)
+	if m.RequestCtxFunc != nil {
+		return m.RequestCtxFunc(ctx, method, entry, body)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Item) ApiJson(v any, opts *jenkins.ApiJsonOpts) error {
+	m.record("ApiJson", v, opts)
+	if m.ApiJsonFunc != nil {
+		return m.ApiJsonFunc(v, opts)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *Item) ApiJsonCtx(ctx context.Context, v any, opts *jenkins.ApiJsonOpts) error {
+	m.record("ApiJsonCtx", ctx, v, opts)
+	if m.ApiJsonCtxFunc != nil {
+		return m.ApiJsonCtxFunc(ctx, v, opts)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *Item) String() string {
+	m.record("String")
+	if m.StringFunc != nil {
+		return m.StringFunc()
+	}
+	var r0 string
+	return r0
+}
+
+// Jenkins is a mock of jenkins.JenkinsAPI, methods return result of
+// XxxFunc if set, zero values otherwise.
+type Jenkins struct {
+	Recorder
+	RequestFunc                func(method string, entry string, body io.Reader) (*http.Response, error)
+	RequestCtxFunc             func(ctx context.Context, method string, entry string, body io.Reader) (*http.Response, error)
+	ApiJsonFunc                func(v any, opts *jenkins.ApiJsonOpts) error
+	ApiJsonCtxFunc             func(ctx context.Context, v any, opts *jenkins.ApiJsonOpts) error
+	StringFunc                 func() string
+	GetCrumbFunc               func() (*jenkins.Crumb, error)
+	GetCrumbCtxFunc            func(ctx context.Context) (*jenkins.Crumb, error)
+	GetJobFunc                 func(fullName string) (jenkins.JobAPI, error)
+	GetJobCtxFunc              func(ctx context.Context, fullName string) (jenkins.JobAPI, error)
+	CreateJobFunc              func(fullName string, xml io.Reader) (*http.Response, error)
+	CreateJobCtxFunc           func(ctx context.Context, fullName string, xml io.Reader) (*http.Response, error)
+	DeleteJobFunc              func(fullName string) (*http.Response, error)
+	DeleteJobCtxFunc           func(ctx context.Context, fullName string) (*http.Response, error)
+	JobExistsFunc              func(fullName string) (bool, error)
+	JobExistsCtxFunc           func(ctx context.Context, fullName string) (bool, error)
+	EnsureFolderFunc           func(fullName string) (jenkins.JobAPI, error)
+	EnsureFolderCtxFunc        func(ctx context.Context, fullName string) (jenkins.JobAPI, error)
+	CreateOrUpdateJobFunc      func(fullName string, xml io.Reader) (jenkins.JobAPI, error)
+	CreateOrUpdateJobCtxFunc   func(ctx context.Context, fullName string, xml io.Reader) (jenkins.JobAPI, error)
+	Name2URLFunc               func(fullName string) string
+	URL2NameFunc               func(url string) (string, error)
+	GetVersionFunc             func() (string, error)
+	GetVersionCtxFunc          func(ctx context.Context) (string, error)
+	BuildJobFunc               func(fullName string, params url.Values) (jenkins.QueueItemAPI, error)
+	BuildJobCtxFunc            func(ctx context.Context, fullName string, params url.Values) (jenkins.QueueItemAPI, error)
+	ListJobsFunc               func(depth int) ([]jenkins.JobAPI, error)
+	ListJobsCtxFunc            func(ctx context.Context, depth int) ([]jenkins.JobAPI, error)
+	AllJobsFunc                func(opts *jenkins.WalkOpts) iter.Seq2[jenkins.JobAPI, error]
+	AllJobsCtxFunc             func(ctx context.Context, opts *jenkins.WalkOpts) iter.Seq2[jenkins.JobAPI, error]
+	RestartFunc                func() (*http.Response, error)
+	RestartCtxFunc             func(ctx context.Context) (*http.Response, error)
+	SafeRestartFunc            func() (*http.Response, error)
+	SafeRestartCtxFunc         func(ctx context.Context) (*http.Response, error)
+	ExitFunc                   func() (*http.Response, error)
+	ExitCtxFunc                func(ctx context.Context) (*http.Response, error)
+	SafeExitFunc               func() (*http.Response, error)
+	SafeExitCtxFunc            func(ctx context.Context) (*http.Response, error)
+	QuiteDownFunc              func() (*http.Response, error)
+	QuiteDownCtxFunc           func(ctx context.Context) (*http.Response, error)
+	CancelQuiteDownFunc        func() (*http.Response, error)
+	CancelQuiteDownCtxFunc     func(ctx context.Context) (*http.Response, error)
+	ReloadJCasCFunc            func() (*http.Response, error)
+	ReloadJCasCCtxFunc         func(ctx context.Context) (*http.Response, error)
+	ValidateJenkinsfileFunc    func(content string) (string, error)
+	ValidateJenkinsfileCtxFunc func(ctx context.Context, content string) (string, error)
+	RunScriptFunc              func(script string) (string, error)
+	RunScriptCtxFunc           func(ctx context.Context, script string) (string, error)
+}
+
+var _ jenkins.JenkinsAPI = (*Jenkins)(nil)
+
+func (m *Jenkins) Request(method string, entry string, body io.Reader) (*http.Response, error) {
+	m.record("Request", method, entry, body)
+	if m.RequestFunc != nil {
+		return m.RequestFunc(method, entry, body)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) RequestCtx(ctx context.Context, method string, entry string, body io.Reader) (*http.Response, error) {
+	m.record("RequestCtx", ctx, method, entry, body)
+	if m.RequestCtxFunc != nil {
+		return m.RequestCtxFunc(ctx, method, entry, body)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) ApiJson(v any, opts *jenkins.ApiJsonOpts) error {
+	m.record("ApiJson", v, opts)
+	if m.ApiJsonFunc != nil {
+		return m.ApiJsonFunc(v, opts)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *Jenkins) ApiJsonCtx(ctx context.Context, v any, opts *jenkins.ApiJsonOpts) error {
+	m.record("ApiJsonCtx", ctx, v, opts)
+	if m.ApiJsonCtxFunc != nil {
+		return m.ApiJsonCtxFunc(ctx, v, opts)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *Jenkins) String() string {
+	m.record("String")
+	if m.StringFunc != nil {
+		return m.StringFunc()
+	}
+	var r0 string
+	return r0
+}
+
+func (m *Jenkins) GetCrumb() (*jenkins.Crumb, error) {
+	m.record("GetCrumb")
+	if m.GetCrumbFunc != nil {
+		return m.GetCrumbFunc()
+	}
+	var r0 *jenkins.Crumb
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) GetCrumbCtx(ctx context.Context) (*jenkins.Crumb, error) {
+	m.record("GetCrumbCtx", ctx)
+	if m.GetCrumbCtxFunc != nil {
+		return m.GetCrumbCtxFunc(ctx)
+	}
+	var r0 *jenkins.Crumb
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) GetJob(fullName string) (jenkins.JobAPI, error) {
+	m.record("GetJob", fullName)
+	if m.GetJobFunc != nil {
+		return m.GetJobFunc(fullName)
+	}
+	var r0 jenkins.JobAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) GetJobCtx(ctx context.Context, fullName string) (jenkins.JobAPI, error) {
+	m.record("GetJobCtx", ctx, fullName)
+	if m.GetJobCtxFunc != nil {
+		return m.GetJobCtxFunc(ctx, fullName)
+	}
+	var r0 jenkins.JobAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) CreateJob(fullName string, xml io.Reader) (*http.Response, error) {
+	m.record("CreateJob", fullName, xml)
+	if m.CreateJobFunc != nil {
+		return m.CreateJobFunc(fullName, xml)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) CreateJobCtx(ctx context.Context, fullName string, xml io.Reader) (*http.Response, error) {
+	m.record("CreateJobCtx", ctx, fullName, xml)
+	if m.CreateJobCtxFunc != nil {
+		return m.CreateJobCtxFunc(ctx, fullName, xml)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) DeleteJob(fullName string) (*http.Response, error) {
+	m.record("DeleteJob", fullName)
+	if m.DeleteJobFunc != nil {
+		return m.DeleteJobFunc(fullName)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) DeleteJobCtx(ctx context.Context, fullName string) (*http.Response, error) {
+	m.record("DeleteJobCtx", ctx, fullName)
+	if m.DeleteJobCtxFunc != nil {
+		return m.DeleteJobCtxFunc(ctx, fullName)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) JobExists(fullName string) (bool, error) {
+	m.record("JobExists", fullName)
+	if m.JobExistsFunc != nil {
+		return m.JobExistsFunc(fullName)
+	}
+	var r0 bool
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) JobExistsCtx(ctx context.Context, fullName string) (bool, error) {
+	m.record("JobExistsCtx", ctx, fullName)
+	if m.JobExistsCtxFunc != nil {
+		return m.JobExistsCtxFunc(ctx, fullName)
+	}
+	var r0 bool
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) EnsureFolder(fullName string) (jenkins.JobAPI, error) {
+	m.record("EnsureFolder", fullName)
+	if m.EnsureFolderFunc != nil {
+		return m.EnsureFolderFunc(fullName)
+	}
+	var r0 jenkins.JobAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) EnsureFolderCtx(ctx context.Context, fullName string) (jenkins.JobAPI, error) {
+	m.record("EnsureFolderCtx", ctx, fullName)
+	if m.EnsureFolderCtxFunc != nil {
+		return m.EnsureFolderCtxFunc(ctx, fullName)
+	}
+	var r0 jenkins.JobAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) CreateOrUpdateJob(fullName string, xml io.Reader) (jenkins.JobAPI, error) {
+	m.record("CreateOrUpdateJob", fullName, xml)
+	if m.CreateOrUpdateJobFunc != nil {
+		return m.CreateOrUpdateJobFunc(fullName, xml)
+	}
+	var r0 jenkins.JobAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) CreateOrUpdateJobCtx(ctx context.Context, fullName string, xml io.Reader) (jenkins.JobAPI, error) {
+	m.record("CreateOrUpdateJobCtx", ctx, fullName, xml)
+	if m.CreateOrUpdateJobCtxFunc != nil {
+		return m.CreateOrUpdateJobCtxFunc(ctx, fullName, xml)
+	}
+	var r0 jenkins.JobAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) Name2URL(fullName string) string {
+	m.record("Name2URL", fullName)
+	if m.Name2URLFunc != nil {
+		return m.Name2URLFunc(fullName)
+	}
+	var r0 string
+	return r0
+}
+
+func (m *Jenkins) URL2Name(url string) (string, error) {
+	m.record("URL2Name", url)
+	if m.URL2NameFunc != nil {
+		return m.URL2NameFunc(url)
+	}
+	var r0 string
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) GetVersion() (string, error) {
+	m.record("GetVersion")
+	if m.GetVersionFunc != nil {
+		return m.GetVersionFunc()
+	}
+	var r0 string
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) GetVersionCtx(ctx context.Context) (string, error) {
+	m.record("GetVersionCtx", ctx)
+	if m.GetVersionCtxFunc != nil {
+		return m.GetVersionCtxFunc(ctx)
+	}
+	var r0 string
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) BuildJob(fullName string, params url.Values) (jenkins.QueueItemAPI, error) {
+	m.record("BuildJob", fullName, params)
+	if m.BuildJobFunc != nil {
+		return m.BuildJobFunc(fullName, params)
+	}
+	var r0 jenkins.QueueItemAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) BuildJobCtx(ctx context.Context, fullName string, params url.Values) (jenkins.QueueItemAPI, error) {
+	m.record("BuildJobCtx", ctx, fullName, params)
+	if m.BuildJobCtxFunc != nil {
+		return m.BuildJobCtxFunc(ctx, fullName, params)
+	}
+	var r0 jenkins.QueueItemAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) ListJobs(depth int) ([]jenkins.JobAPI, error) {
+	m.record("ListJobs", depth)
+	if m.ListJobsFunc != nil {
+		return m.ListJobsFunc(depth)
+	}
+	var r0 []jenkins.JobAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) ListJobsCtx(ctx context.Context, depth int) ([]jenkins.JobAPI, error) {
+	m.record("ListJobsCtx", ctx, depth)
+	if m.ListJobsCtxFunc != nil {
+		return m.ListJobsCtxFunc(ctx, depth)
+	}
+	var r0 []jenkins.JobAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) AllJobs(opts *jenkins.WalkOpts) iter.Seq2[jenkins.JobAPI, error] {
+	m.record("AllJobs", opts)
+	if m.AllJobsFunc != nil {
+		return m.AllJobsFunc(opts)
+	}
+	var r0 iter.Seq2[jenkins.JobAPI, error]
+	return r0
+}
+
+func (m *Jenkins) AllJobsCtx(ctx context.Context, opts *jenkins.WalkOpts) iter.Seq2[jenkins.JobAPI, error] {
+	m.record("AllJobsCtx", ctx, opts)
+	if m.AllJobsCtxFunc != nil {
+		return m.AllJobsCtxFunc(ctx, opts)
+	}
+	var r0 iter.Seq2[jenkins.JobAPI, error]
+	return r0
+}
+
+func (m *Jenkins) Restart() (*http.Response, error) {
+	m.record("Restart")
+	if m.RestartFunc != nil {
+		return m.RestartFunc()
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) RestartCtx(ctx context.Context) (*http.Response, error) {
+	m.record("RestartCtx", ctx)
+	if m.RestartCtxFunc != nil {
+		return m.RestartCtxFunc(ctx)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) SafeRestart() (*http.Response, error) {
+	m.record("SafeRestart")
+	if m.SafeRestartFunc != nil {
+		return m.SafeRestartFunc()
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) SafeRestartCtx(ctx context.Context) (*http.Response, error) {
+	m.record("SafeRestartCtx", ctx)
+	if m.SafeRestartCtxFunc != nil {
+		return m.SafeRestartCtxFunc(ctx)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) Exit() (*http.Response, error) {
+	m.record("Exit")
+	if m.ExitFunc != nil {
+		return m.ExitFunc()
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) ExitCtx(ctx context.Context) (*http.Response, error) {
+	m.record("ExitCtx", ctx)
+	if m.ExitCtxFunc != nil {
+		return m.ExitCtxFunc(ctx)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) SafeExit() (*http.Response, error) {
+	m.record("SafeExit")
+	if m.SafeExitFunc != nil {
+		return m.SafeExitFunc()
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) SafeExitCtx(ctx context.Context) (*http.Response, error) {
+	m.record("SafeExitCtx", ctx)
+	if m.SafeExitCtxFunc != nil {
+		return m.SafeExitCtxFunc(ctx)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) QuiteDown() (*http.Response, error) {
+	m.record("QuiteDown")
+	if m.QuiteDownFunc != nil {
+		return m.QuiteDownFunc()
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) QuiteDownCtx(ctx context.Context) (*http.Response, error) {
+	m.record("QuiteDownCtx", ctx)
+	if m.QuiteDownCtxFunc != nil {
+		return m.QuiteDownCtxFunc(ctx)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) CancelQuiteDown() (*http.Response, error) {
+	m.record("CancelQuiteDown")
+	if m.CancelQuiteDownFunc != nil {
+		return m.CancelQuiteDownFunc()
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) CancelQuiteDownCtx(ctx context.Context) (*http.Response, error) {
+	m.record("CancelQuiteDownCtx", ctx)
+	if m.CancelQuiteDownCtxFunc != nil {
+		return m.CancelQuiteDownCtxFunc(ctx)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) ReloadJCasC() (*http.Response, error) {
+	m.record("ReloadJCasC")
+	if m.ReloadJCasCFunc != nil {
+		return m.ReloadJCasCFunc()
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) ReloadJCasCCtx(ctx context.Context) (*http.Response, error) {
+	m.record("ReloadJCasCCtx", ctx)
+	if m.ReloadJCasCCtxFunc != nil {
+		return m.ReloadJCasCCtxFunc(ctx)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) ValidateJenkinsfile(content string) (string, error) {
+	m.record("ValidateJenkinsfile", content)
+	if m.ValidateJenkinsfileFunc != nil {
+		return m.ValidateJenkinsfileFunc(content)
+	}
+	var r0 string
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) ValidateJenkinsfileCtx(ctx context.Context, content string) (string, error) {
+	m.record("ValidateJenkinsfileCtx", ctx, content)
+	if m.ValidateJenkinsfileCtxFunc != nil {
+		return m.ValidateJenkinsfileCtxFunc(ctx, content)
+	}
+	var r0 string
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) RunScript(script string) (string, error) {
+	m.record("RunScript", script)
+	if m.RunScriptFunc != nil {
+		return m.RunScriptFunc(script)
+	}
+	var r0 string
+	var r1 error
+	return r0, r1
+}
+
+func (m *Jenkins) RunScriptCtx(ctx context.Context, script string) (string, error) {
+	m.record("RunScriptCtx", ctx, script)
+	if m.RunScriptCtxFunc != nil {
+		return m.RunScriptCtxFunc(ctx, script)
+	}
+	var r0 string
+	var r1 error
+	return r0, r1
+}
+
+// Job is a mock of jenkins.JobAPI, methods return result of
+// XxxFunc if set, zero values otherwise.
+type Job struct {
+	Recorder
+	RequestFunc                          func(method string, entry string, body io.Reader) (*http.Response, error)
+	RequestCtxFunc                       func(ctx context.Context, method string, entry string, body io.Reader) (*http.Response, error)
+	ApiJsonFunc                          func(v any, opts *jenkins.ApiJsonOpts) error
+	ApiJsonCtxFunc                       func(ctx context.Context, v any, opts *jenkins.ApiJsonOpts) error
+	StringFunc                           func() string
+	RenameFunc                           func(name string) (*url.URL, error)
+	RenameCtxFunc                        func(ctx context.Context, name string) (*url.URL, error)
+	MoveFunc                             func(path string) (*url.URL, error)
+	MoveCtxFunc                          func(ctx context.Context, path string) (*url.URL, error)
+	RenameToFunc                         func(name string) (jenkins.JobAPI, error)
+	RenameToCtxFunc                      func(ctx context.Context, name string) (jenkins.JobAPI, error)
+	MoveToFunc                           func(folder string) (jenkins.JobAPI, error)
+	MoveToCtxFunc                        func(ctx context.Context, folder string) (jenkins.JobAPI, error)
+	CopyFunc                             func(src string, dest string) (*http.Response, error)
+	CopyCtxFunc                          func(ctx context.Context, src string, dest string) (*http.Response, error)
+	GetParentFunc                        func() (jenkins.JobAPI, error)
+	GetParentCtxFunc                     func(ctx context.Context) (jenkins.JobAPI, error)
+	GetConfigureFunc                     func() (string, error)
+	GetConfigureCtxFunc                  func(ctx context.Context) (string, error)
+	SetConfigureFunc                     func(xml io.Reader) (*http.Response, error)
+	SetConfigureCtxFunc                  func(ctx context.Context, xml io.Reader) (*http.Response, error)
+	GetConfigFunc                        func(v any) error
+	GetConfigCtxFunc                     func(ctx context.Context, v any) error
+	SetConfigFunc                        func(v any) (*http.Response, error)
+	SetConfigCtxFunc                     func(ctx context.Context, v any) (*http.Response, error)
+	PatchConfigureFunc                   func(edits ...jenkins.ConfigEdit) (*jenkins.PatchResult, error)
+	PatchConfigureCtxFunc                func(ctx context.Context, edits ...jenkins.ConfigEdit) (*jenkins.PatchResult, error)
+	DiffConfigureFunc                    func(other jenkins.JobAPI) (*jenkins.XMLDiff, error)
+	DiffConfigureCtxFunc                 func(ctx context.Context, other jenkins.JobAPI) (*jenkins.XMLDiff, error)
+	DisableFunc                          func() (*http.Response, error)
+	DisableCtxFunc                       func(ctx context.Context) (*http.Response, error)
+	EnableFunc                           func() (*http.Response, error)
+	EnableCtxFunc                        func(ctx context.Context) (*http.Response, error)
+	IsBuildableFunc                      func() (bool, error)
+	IsBuildableCtxFunc                   func(ctx context.Context) (bool, error)
+	GetDescriptionFunc                   func() (string, error)
+	GetDescriptionCtxFunc                func(ctx context.Context) (string, error)
+	SetDescriptionFunc                   func(description string) (*http.Response, error)
+	SetDescriptionCtxFunc                func(ctx context.Context, description string) (*http.Response, error)
+	BuildFunc                            func(param url.Values) (jenkins.QueueItemAPI, error)
+	BuildCtxFunc                         func(ctx context.Context, param url.Values) (jenkins.QueueItemAPI, error)
+	GetBuildFunc                         func(number int) (jenkins.BuildAPI, error)
+	GetBuildCtxFunc                      func(ctx context.Context, number int) (jenkins.BuildAPI, error)
+	GetFunc                              func(name string) (jenkins.JobAPI, error)
+	GetCtxFunc                           func(ctx context.Context, name string) (jenkins.JobAPI, error)
+	CreateFunc                           func(name string, xml io.Reader) (*http.Response, error)
+	CreateCtxFunc                        func(ctx context.Context, name string, xml io.Reader) (*http.Response, error)
+	ListFunc                             func(depth int) ([]jenkins.JobAPI, error)
+	ListCtxFunc                          func(ctx context.Context, depth int) ([]jenkins.JobAPI, error)
+	WalkFunc                             func(opts *jenkins.WalkOpts) iter.Seq2[jenkins.JobAPI, error]
+	WalkCtxFunc                          func(ctx context.Context, opts *jenkins.WalkOpts) iter.Seq2[jenkins.JobAPI, error]
+	GetFirstBuildFunc                    func() (jenkins.BuildAPI, error)
+	GetFirstBuildCtxFunc                 func(ctx context.Context) (jenkins.BuildAPI, error)
+	GetLastBuildFunc                     func() (jenkins.BuildAPI, error)
+	GetLastBuildCtxFunc                  func(ctx context.Context) (jenkins.BuildAPI, error)
+	GetLastCompleteBuildFunc             func() (jenkins.BuildAPI, error)
+	GetLastCompleteBuildCtxFunc          func(ctx context.Context) (jenkins.BuildAPI, error)
+	GetLastFailedBuildFunc               func() (jenkins.BuildAPI, error)
+	GetLastFailedBuildCtxFunc            func(ctx context.Context) (jenkins.BuildAPI, error)
+	GetLastStableBuildFunc               func() (jenkins.BuildAPI, error)
+	GetLastStableBuildCtxFunc            func(ctx context.Context) (jenkins.BuildAPI, error)
+	GetLastUnstableBuildFunc             func() (jenkins.BuildAPI, error)
+	GetLastUnstableBuildCtxFunc          func(ctx context.Context) (jenkins.BuildAPI, error)
+	GetLastSuccessfulBuildFunc           func() (jenkins.BuildAPI, error)
+	GetLastSuccessfulBuildCtxFunc        func(ctx context.Context) (jenkins.BuildAPI, error)
+	GetLastUnsucessfulBuildFunc          func() (jenkins.BuildAPI, error)
+	GetLastUnsucessfulBuildCtxFunc       func(ctx context.Context) (jenkins.BuildAPI, error)
+	GetBuildByNameFunc                   func(name string) (jenkins.BuildAPI, error)
+	GetBuildByNameCtxFunc                func(ctx context.Context, name string) (jenkins.BuildAPI, error)
+	DeleteFunc                           func() (*http.Response, error)
+	DeleteCtxFunc                        func(ctx context.Context) (*http.Response, error)
+	ListBuildsFunc                       func() ([]jenkins.BuildAPI, error)
+	ListBuildsCtxFunc                    func(ctx context.Context) ([]jenkins.BuildAPI, error)
+	ListAllBuildsFunc                    func(opts *jenkins.HistoryOpts) ([]*jenkins.BuildJson, error)
+	ListAllBuildsCtxFunc                 func(ctx context.Context, opts *jenkins.HistoryOpts) ([]*jenkins.BuildJson, error)
+	SetNextBuildNumberFunc               func(number int) (*http.Response, error)
+	SetNextBuildNumberCtxFunc            func(ctx context.Context, number int) (*http.Response, error)
+	GetParametersFunc                    func() ([]*jenkins.ParameterDefinition, error)
+	GetParametersCtxFunc                 func(ctx context.Context) ([]*jenkins.ParameterDefinition, error)
+	SCMPollingFunc                       func() (*http.Response, error)
+	SCMPollingCtxFunc                    func(ctx context.Context) (*http.Response, error)
+	GetMultibranchPipelineScanLogFunc    func() (string, error)
+	GetMultibranchPipelineScanLogCtxFunc func(ctx context.Context) (string, error)
+}
+
+var _ jenkins.JobAPI = (*Job)(nil)
+
+func (m *Job) Request(method string, entry string, body io.Reader) (*http.Response, error) {
+	m.record("Request", method, entry, body)
+	if m.RequestFunc != nil {
+		return m.RequestFunc(method, entry, body)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) RequestCtx(ctx context.Context, method string, entry string, body io.Reader) (*http.Response, error) {
+	m.record("RequestCtx", ctx, method, entry, body)
+	if m.RequestCtxFunc != nil {
+		return m.RequestCtxFunc(ctx, method, entry, body)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) ApiJson(v any, opts *jenkins.ApiJsonOpts) error {
+	m.record("ApiJson", v, opts)
+	if m.ApiJsonFunc != nil {
+		return m.ApiJsonFunc(v, opts)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *Job) ApiJsonCtx(ctx context.Context, v any, opts *jenkins.ApiJsonOpts) error {
+	m.record("ApiJsonCtx", ctx, v, opts)
+	if m.ApiJsonCtxFunc != nil {
+		return m.ApiJsonCtxFunc(ctx, v, opts)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *Job) String() string {
+	m.record("String")
+	if m.StringFunc != nil {
+		return m.StringFunc()
+	}
+	var r0 string
+	return r0
+}
+
+func (m *Job) Rename(name string) (*url.URL, error) {
+	m.record("Rename", name)
+	if m.RenameFunc != nil {
+		return m.RenameFunc(name)
+	}
+	var r0 *url.URL
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) RenameCtx(ctx context.Context, name string) (*url.URL, error) {
+	m.record("RenameCtx", ctx, name)
+	if m.RenameCtxFunc != nil {
+		return m.RenameCtxFunc(ctx, name)
+	}
+	var r0 *url.URL
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) Move(path string) (*url.URL, error) {
+	m.record("Move", path)
+	if m.MoveFunc != nil {
+		return m.MoveFunc(path)
+	}
+	var r0 *url.URL
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) MoveCtx(ctx context.Context, path string) (*url.URL, error) {
+	m.record("MoveCtx", ctx, path)
+	if m.MoveCtxFunc != nil {
+		return m.MoveCtxFunc(ctx, path)
+	}
+	var r0 *url.URL
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) RenameTo(name string) (jenkins.JobAPI, error) {
+	m.record("RenameTo", name)
+	if m.RenameToFunc != nil {
+		return m.RenameToFunc(name)
+	}
+	var r0 jenkins.JobAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) RenameToCtx(ctx context.Context, name string) (jenkins.JobAPI, error) {
+	m.record("RenameToCtx", ctx, name)
+	if m.RenameToCtxFunc != nil {
+		return m.RenameToCtxFunc(ctx, name)
+	}
+	var r0 jenkins.JobAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) MoveTo(folder string) (jenkins.JobAPI, error) {
+	m.record("MoveTo", folder)
+	if m.MoveToFunc != nil {
+		return m.MoveToFunc(folder)
+	}
+	var r0 jenkins.JobAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) MoveToCtx(ctx context.Context, folder string) (jenkins.JobAPI, error) {
+	m.record("MoveToCtx", ctx, folder)
+	if m.MoveToCtxFunc != nil {
+		return m.MoveToCtxFunc(ctx, folder)
+	}
+	var r0 jenkins.JobAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) Copy(src string, dest string) (*http.Response, error) {
+	m.record("Copy", src, dest)
+	if m.CopyFunc != nil {
+		return m.CopyFunc(src, dest)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) CopyCtx(ctx context.Context, src string, dest string) (*http.Response, error) {
+	m.record("CopyCtx", ctx, src, dest)
+	if m.CopyCtxFunc != nil {
+		return m.CopyCtxFunc(ctx, src, dest)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) GetParent() (jenkins.JobAPI, error) {
+	m.record("GetParent")
+	if m.GetParentFunc != nil {
+		return m.GetParentFunc()
+	}
+	var r0 jenkins.JobAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) GetParentCtx(ctx context.Context) (jenkins.JobAPI, error) {
+	m.record("GetParentCtx", ctx)
+	if m.GetParentCtxFunc != nil {
+		return m.GetParentCtxFunc(ctx)
+	}
+	var r0 jenkins.JobAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) GetConfigure() (string, error) {
+	m.record("GetConfigure")
+	if m.GetConfigureFunc != nil {
+		return m.GetConfigureFunc()
+	}
+	var r0 string
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) GetConfigureCtx(ctx context.Context) (string, error) {
+	m.record("GetConfigureCtx", ctx)
+	if m.GetConfigureCtxFunc != nil {
+		return m.GetConfigureCtxFunc(ctx)
+	}
+	var r0 string
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) SetConfigure(xml io.Reader) (*http.Response, error) {
+	m.record("SetConfigure", xml)
+	if m.SetConfigureFunc != nil {
+		return m.SetConfigureFunc(xml)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) SetConfigureCtx(ctx context.Context, xml io.Reader) (*http.Response, error) {
+	m.record("SetConfigureCtx", ctx, xml)
+	if m.SetConfigureCtxFunc != nil {
+		return m.SetConfigureCtxFunc(ctx, xml)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) GetConfig(v any) error {
+	m.record("GetConfig", v)
+	if m.GetConfigFunc != nil {
+		return m.GetConfigFunc(v)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *Job) GetConfigCtx(ctx context.Context, v any) error {
+	m.record("GetConfigCtx", ctx, v)
+	if m.GetConfigCtxFunc != nil {
+		return m.GetConfigCtxFunc(ctx, v)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *Job) SetConfig(v any) (*http.Response, error) {
+	m.record("SetConfig", v)
+	if m.SetConfigFunc != nil {
+		return m.SetConfigFunc(v)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) SetConfigCtx(ctx context.Context, v any) (*http.Response, error) {
+	m.record("SetConfigCtx", ctx, v)
+	if m.SetConfigCtxFunc != nil {
+		return m.SetConfigCtxFunc(ctx, v)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) PatchConfigure(edits ...jenkins.ConfigEdit) (*jenkins.PatchResult, error) {
+	m.record("PatchConfigure", edits)
+	if m.PatchConfigureFunc != nil {
+		return m.PatchConfigureFunc(edits...)
+	}
+	var r0 *jenkins.PatchResult
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) PatchConfigureCtx(ctx context.Context, edits ...jenkins.ConfigEdit) (*jenkins.PatchResult, error) {
+	m.record("PatchConfigureCtx", ctx, edits)
+	if m.PatchConfigureCtxFunc != nil {
+		return m.PatchConfigureCtxFunc(ctx, edits...)
+	}
+	var r0 *jenkins.PatchResult
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) DiffConfigure(other jenkins.JobAPI) (*jenkins.XMLDiff, error) {
+	m.record("DiffConfigure", other)
+	if m.DiffConfigureFunc != nil {
+		return m.DiffConfigureFunc(other)
+	}
+	var r0 *jenkins.XMLDiff
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) DiffConfigureCtx(ctx context.Context, other jenkins.JobAPI) (*jenkins.XMLDiff, error) {
+	m.record("DiffConfigureCtx", ctx, other)
+	if m.DiffConfigureCtxFunc != nil {
+		return m.DiffConfigureCtxFunc(ctx, other)
+	}
+	var r0 *jenkins.XMLDiff
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) Disable() (*http.Response, error) {
+	m.record("Disable")
+	if m.DisableFunc != nil {
+		return m.DisableFunc()
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) DisableCtx(ctx context.Context) (*http.Response, error) {
+	m.record("DisableCtx", ctx)
+	if m.DisableCtxFunc != nil {
+		return m.DisableCtxFunc(ctx)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) Enable() (*http.Response, error) {
+	m.record("Enable")
+	if m.EnableFunc != nil {
+		return m.EnableFunc()
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) EnableCtx(ctx context.Context) (*http.Response, error) {
+	m.record("EnableCtx", ctx)
+	if m.EnableCtxFunc != nil {
+		return m.EnableCtxFunc(ctx)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) IsBuildable() (bool, error) {
+	m.record("IsBuildable")
+	if m.IsBuildableFunc != nil {
+		return m.IsBuildableFunc()
+	}
+	var r0 bool
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) IsBuildableCtx(ctx context.Context) (bool, error) {
+	m.record("IsBuildableCtx", ctx)
+	if m.IsBuildableCtxFunc != nil {
+		return m.IsBuildableCtxFunc(ctx)
+	}
+	var r0 bool
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) GetDescription() (string, error) {
+	m.record("GetDescription")
+	if m.GetDescriptionFunc != nil {
+		return m.GetDescriptionFunc()
+	}
+	var r0 string
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) GetDescriptionCtx(ctx context.Context) (string, error) {
+	m.record("GetDescriptionCtx", ctx)
+	if m.GetDescriptionCtxFunc != nil {
+		return m.GetDescriptionCtxFunc(ctx)
+	}
+	var r0 string
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) SetDescription(description string) (*http.Response, error) {
+	m.record("SetDescription", description)
+	if m.SetDescriptionFunc != nil {
+		return m.SetDescriptionFunc(description)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) SetDescriptionCtx(ctx context.Context, description string) (*http.Response, error) {
+	m.record("SetDescriptionCtx", ctx, description)
+	if m.SetDescriptionCtxFunc != nil {
+		return m.SetDescriptionCtxFunc(ctx, description)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) Build(param url.Values) (jenkins.QueueItemAPI, error) {
+	m.record("Build", param)
+	if m.BuildFunc != nil {
+		return m.BuildFunc(param)
+	}
+	var r0 jenkins.QueueItemAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) BuildCtx(ctx context.Context, param url.Values) (jenkins.QueueItemAPI, error) {
+	m.record("BuildCtx", ctx, param)
+	if m.BuildCtxFunc != nil {
+		return m.BuildCtxFunc(ctx, param)
+	}
+	var r0 jenkins.QueueItemAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) GetBuild(number int) (jenkins.BuildAPI, error) {
+	m.record("GetBuild", number)
+	if m.GetBuildFunc != nil {
+		return m.GetBuildFunc(number)
+	}
+	var r0 jenkins.BuildAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) GetBuildCtx(ctx context.Context, number int) (jenkins.BuildAPI, error) {
+	m.record("GetBuildCtx", ctx, number)
+	if m.GetBuildCtxFunc != nil {
+		return m.GetBuildCtxFunc(ctx, number)
+	}
+	var r0 jenkins.BuildAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) Get(name string) (jenkins.JobAPI, error) {
+	m.record("Get", name)
+	if m.GetFunc != nil {
+		return m.GetFunc(name)
+	}
+	var r0 jenkins.JobAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) GetCtx(ctx context.Context, name string) (jenkins.JobAPI, error) {
+	m.record("GetCtx", ctx, name)
+	if m.GetCtxFunc != nil {
+		return m.GetCtxFunc(ctx, name)
+	}
+	var r0 jenkins.JobAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) Create(name string, xml io.Reader) (*http.Response, error) {
+	m.record("Create", name, xml)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(name, xml)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) CreateCtx(ctx context.Context, name string, xml io.Reader) (*http.Response, error) {
+	m.record("CreateCtx", ctx, name, xml)
+	if m.CreateCtxFunc != nil {
+		return m.CreateCtxFunc(ctx, name, xml)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) List(depth int) ([]jenkins.JobAPI, error) {
+	m.record("List", depth)
+	if m.ListFunc != nil {
+		return m.ListFunc(depth)
+	}
+	var r0 []jenkins.JobAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) ListCtx(ctx context.Context, depth int) ([]jenkins.JobAPI, error) {
+	m.record("ListCtx", ctx, depth)
+	if m.ListCtxFunc != nil {
+		return m.ListCtxFunc(ctx, depth)
+	}
+	var r0 []jenkins.JobAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) Walk(opts *jenkins.WalkOpts) iter.Seq2[jenkins.JobAPI, error] {
+	m.record("Walk", opts)
+	if m.WalkFunc != nil {
+		return m.WalkFunc(opts)
+	}
+	var r0 iter.Seq2[jenkins.JobAPI, error]
+	return r0
+}
+
+func (m *Job) WalkCtx(ctx context.Context, opts *jenkins.WalkOpts) iter.Seq2[jenkins.JobAPI, error] {
+	m.record("WalkCtx", ctx, opts)
+	if m.WalkCtxFunc != nil {
+		return m.WalkCtxFunc(ctx, opts)
+	}
+	var r0 iter.Seq2[jenkins.JobAPI, error]
+	return r0
+}
+
+func (m *Job) GetFirstBuild() (jenkins.BuildAPI, error) {
+	m.record("GetFirstBuild")
+	if m.GetFirstBuildFunc != nil {
+		return m.GetFirstBuildFunc()
+	}
+	var r0 jenkins.BuildAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) GetFirstBuildCtx(ctx context.Context) (jenkins.BuildAPI, error) {
+	m.record("GetFirstBuildCtx", ctx)
+	if m.GetFirstBuildCtxFunc != nil {
+		return m.GetFirstBuildCtxFunc(ctx)
+	}
+	var r0 jenkins.BuildAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) GetLastBuild() (jenkins.BuildAPI, error) {
+	m.record("GetLastBuild")
+	if m.GetLastBuildFunc != nil {
+		return m.GetLastBuildFunc()
+	}
+	var r0 jenkins.BuildAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) GetLastBuildCtx(ctx context.Context) (jenkins.BuildAPI, error) {
+	m.record("GetLastBuildCtx", ctx)
+	if m.GetLastBuildCtxFunc != nil {
+		return m.GetLastBuildCtxFunc(ctx)
+	}
+	var r0 jenkins.BuildAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) GetLastCompleteBuild() (jenkins.BuildAPI, error) {
+	m.record("GetLastCompleteBuild")
+	if m.GetLastCompleteBuildFunc != nil {
+		return m.GetLastCompleteBuildFunc()
+	}
+	var r0 jenkins.BuildAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) GetLastCompleteBuildCtx(ctx context.Context) (jenkins.BuildAPI, error) {
+	m.record("GetLastCompleteBuildCtx", ctx)
+	if m.GetLastCompleteBuildCtxFunc != nil {
+		return m.GetLastCompleteBuildCtxFunc(ctx)
+	}
+	var r0 jenkins.BuildAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) GetLastFailedBuild() (jenkins.BuildAPI, error) {
+	m.record("GetLastFailedBuild")
+	if m.GetLastFailedBuildFunc != nil {
+		return m.GetLastFailedBuildFunc()
+	}
+	var r0 jenkins.BuildAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) GetLastFailedBuildCtx(ctx context.Context) (jenkins.BuildAPI, error) {
+	m.record("GetLastFailedBuildCtx", ctx)
+	if m.GetLastFailedBuildCtxFunc != nil {
+		return m.GetLastFailedBuildCtxFunc(ctx)
+	}
+	var r0 jenkins.BuildAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) GetLastStableBuild() (jenkins.BuildAPI, error) {
+	m.record("GetLastStableBuild")
+	if m.GetLastStableBuildFunc != nil {
+		return m.GetLastStableBuildFunc()
+	}
+	var r0 jenkins.BuildAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) GetLastStableBuildCtx(ctx context.Context) (jenkins.BuildAPI, error) {
+	m.record("GetLastStableBuildCtx", ctx)
+	if m.GetLastStableBuildCtxFunc != nil {
+		return m.GetLastStableBuildCtxFunc(ctx)
+	}
+	var r0 jenkins.BuildAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) GetLastUnstableBuild() (jenkins.BuildAPI, error) {
+	m.record("GetLastUnstableBuild")
+	if m.GetLastUnstableBuildFunc != nil {
+		return m.GetLastUnstableBuildFunc()
+	}
+	var r0 jenkins.BuildAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) GetLastUnstableBuildCtx(ctx context.Context) (jenkins.BuildAPI, error) {
+	m.record("GetLastUnstableBuildCtx", ctx)
+	if m.GetLastUnstableBuildCtxFunc != nil {
+		return m.GetLastUnstableBuildCtxFunc(ctx)
+	}
+	var r0 jenkins.BuildAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) GetLastSuccessfulBuild() (jenkins.BuildAPI, error) {
+	m.record("GetLastSuccessfulBuild")
+	if m.GetLastSuccessfulBuildFunc != nil {
+		return m.GetLastSuccessfulBuildFunc()
+	}
+	var r0 jenkins.BuildAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) GetLastSuccessfulBuildCtx(ctx context.Context) (jenkins.BuildAPI, error) {
+	m.record("GetLastSuccessfulBuildCtx", ctx)
+	if m.GetLastSuccessfulBuildCtxFunc != nil {
+		return m.GetLastSuccessfulBuildCtxFunc(ctx)
+	}
+	var r0 jenkins.BuildAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) GetLastUnsucessfulBuild() (jenkins.BuildAPI, error) {
+	m.record("GetLastUnsucessfulBuild")
+	if m.GetLastUnsucessfulBuildFunc != nil {
+		return m.GetLastUnsucessfulBuildFunc()
+	}
+	var r0 jenkins.BuildAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) GetLastUnsucessfulBuildCtx(ctx context.Context) (jenkins.BuildAPI, error) {
+	m.record("GetLastUnsucessfulBuildCtx", ctx)
+	if m.GetLastUnsucessfulBuildCtxFunc != nil {
+		return m.GetLastUnsucessfulBuildCtxFunc(ctx)
+	}
+	var r0 jenkins.BuildAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) GetBuildByName(name string) (jenkins.BuildAPI, error) {
+	m.record("GetBuildByName", name)
+	if m.GetBuildByNameFunc != nil {
+		return m.GetBuildByNameFunc(name)
+	}
+	var r0 jenkins.BuildAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) GetBuildByNameCtx(ctx context.Context, name string) (jenkins.BuildAPI, error) {
+	m.record("GetBuildByNameCtx", ctx, name)
+	if m.GetBuildByNameCtxFunc != nil {
+		return m.GetBuildByNameCtxFunc(ctx, name)
+	}
+	var r0 jenkins.BuildAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) Delete() (*http.Response, error) {
+	m.record("Delete")
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc()
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) DeleteCtx(ctx context.Context) (*http.Response, error) {
+	m.record("DeleteCtx", ctx)
+	if m.DeleteCtxFunc != nil {
+		return m.DeleteCtxFunc(ctx)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) ListBuilds() ([]jenkins.BuildAPI, error) {
+	m.record("ListBuilds")
+	if m.ListBuildsFunc != nil {
+		return m.ListBuildsFunc()
+	}
+	var r0 []jenkins.BuildAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) ListBuildsCtx(ctx context.Context) ([]jenkins.BuildAPI, error) {
+	m.record("ListBuildsCtx", ctx)
+	if m.ListBuildsCtxFunc != nil {
+		return m.ListBuildsCtxFunc(ctx)
+	}
+	var r0 []jenkins.BuildAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) ListAllBuilds(opts *jenkins.HistoryOpts) ([]*jenkins.BuildJson, error) {
+	m.record("ListAllBuilds", opts)
+	if m.ListAllBuildsFunc != nil {
+		return m.ListAllBuildsFunc(opts)
+	}
+	var r0 []*jenkins.BuildJson
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) ListAllBuildsCtx(ctx context.Context, opts *jenkins.HistoryOpts) ([]*jenkins.BuildJson, error) {
+	m.record("ListAllBuildsCtx", ctx, opts)
+	if m.ListAllBuildsCtxFunc != nil {
+		return m.ListAllBuildsCtxFunc(ctx, opts)
+	}
+	var r0 []*jenkins.BuildJson
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) SetNextBuildNumber(number int) (*http.Response, error) {
+	m.record("SetNextBuildNumber", number)
+	if m.SetNextBuildNumberFunc != nil {
+		return m.SetNextBuildNumberFunc(number)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) SetNextBuildNumberCtx(ctx context.Context, number int) (*http.Response, error) {
+	m.record("SetNextBuildNumberCtx", ctx, number)
+	if m.SetNextBuildNumberCtxFunc != nil {
+		return m.SetNextBuildNumberCtxFunc(ctx, number)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) GetParameters() ([]*jenkins.ParameterDefinition, error) {
+	m.record("GetParameters")
+	if m.GetParametersFunc != nil {
+		return m.GetParametersFunc()
+	}
+	var r0 []*jenkins.ParameterDefinition
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) GetParametersCtx(ctx context.Context) ([]*jenkins.ParameterDefinition, error) {
+	m.record("GetParametersCtx", ctx)
+	if m.GetParametersCtxFunc != nil {
+		return m.GetParametersCtxFunc(ctx)
+	}
+	var r0 []*jenkins.ParameterDefinition
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) SCMPolling() (*http.Response, error) {
+	m.record("SCMPolling")
+	if m.SCMPollingFunc != nil {
+		return m.SCMPollingFunc()
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) SCMPollingCtx(ctx context.Context) (*http.Response, error) {
+	m.record("SCMPollingCtx", ctx)
+	if m.SCMPollingCtxFunc != nil {
+		return m.SCMPollingCtxFunc(ctx)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) GetMultibranchPipelineScanLog() (string, error) {
+	m.record("GetMultibranchPipelineScanLog")
+	if m.GetMultibranchPipelineScanLogFunc != nil {
+		return m.GetMultibranchPipelineScanLogFunc()
+	}
+	var r0 string
+	var r1 error
+	return r0, r1
+}
+
+func (m *Job) GetMultibranchPipelineScanLogCtx(ctx context.Context) (string, error) {
+	m.record("GetMultibranchPipelineScanLogCtx", ctx)
+	if m.GetMultibranchPipelineScanLogCtxFunc != nil {
+		return m.GetMultibranchPipelineScanLogCtxFunc(ctx)
+	}
+	var r0 string
+	var r1 error
+	return r0, r1
+}
+
+// Build is a mock of jenkins.BuildAPI, methods return result of
+// XxxFunc if set, zero values otherwise.
+type Build struct {
+	Recorder
+	RequestFunc            func(method string, entry string, body io.Reader) (*http.Response, error)
+	RequestCtxFunc         func(ctx context.Context, method string, entry string, body io.Reader) (*http.Response, error)
+	ApiJsonFunc            func(v any, opts *jenkins.ApiJsonOpts) error
+	ApiJsonCtxFunc         func(ctx context.Context, v any, opts *jenkins.ApiJsonOpts) error
+	StringFunc             func() string
+	IsBuildingFunc         func() (bool, error)
+	IsBuildingCtxFunc      func(ctx context.Context) (bool, error)
+	GetResultFunc          func() (string, error)
+	GetResultCtxFunc       func(ctx context.Context) (string, error)
+	DeleteFunc             func() (*http.Response, error)
+	DeleteCtxFunc          func(ctx context.Context) (*http.Response, error)
+	StopFunc               func() (*http.Response, error)
+	StopCtxFunc            func(ctx context.Context) (*http.Response, error)
+	KillFunc               func() (*http.Response, error)
+	KillCtxFunc            func(ctx context.Context) (*http.Response, error)
+	TermFunc               func() (*http.Response, error)
+	TermCtxFunc            func(ctx context.Context) (*http.Response, error)
+	GetJobFunc             func() (jenkins.JobAPI, error)
+	GetJobCtxFunc          func(ctx context.Context) (jenkins.JobAPI, error)
+	LoopLogFunc            func(f func(string) error) error
+	LoopLogCtxFunc         func(ctx context.Context, f func(string) error) error
+	LoopProgressiveLogFunc func(kind string, f func(string) error) error
+	StreamLogFunc          func(ctx context.Context, kind string, f func(string) error) error
+	GetDescriptionFunc     func() (string, error)
+	GetDescriptionCtxFunc  func(ctx context.Context) (string, error)
+	SetDescriptionFunc     func(description string) (*http.Response, error)
+	SetDescriptionCtxFunc  func(ctx context.Context, description string) (*http.Response, error)
+}
+
+var _ jenkins.BuildAPI = (*Build)(nil)
+
+func (m *Build) Request(method string, entry string, body io.Reader) (*http.Response, error) {
+	m.record("Request", method, entry, body)
+	if m.RequestFunc != nil {
+		return m.RequestFunc(method, entry, body)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Build) RequestCtx(ctx context.Context, method string, entry string, body io.Reader) (*http.Response, error) {
+	m.record("RequestCtx", ctx, method, entry, body)
+	if m.RequestCtxFunc != nil {
+		return m.RequestCtxFunc(ctx, method, entry, body)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Build) ApiJson(v any, opts *jenkins.ApiJsonOpts) error {
+	m.record("ApiJson", v, opts)
+	if m.ApiJsonFunc != nil {
+		return m.ApiJsonFunc(v, opts)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *Build) ApiJsonCtx(ctx context.Context, v any, opts *jenkins.ApiJsonOpts) error {
+	m.record("ApiJsonCtx", ctx, v, opts)
+	if m.ApiJsonCtxFunc != nil {
+		return m.ApiJsonCtxFunc(ctx, v, opts)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *Build) String() string {
+	m.record("String")
+	if m.StringFunc != nil {
+		return m.StringFunc()
+	}
+	var r0 string
+	return r0
+}
+
+func (m *Build) IsBuilding() (bool, error) {
+	m.record("IsBuilding")
+	if m.IsBuildingFunc != nil {
+		return m.IsBuildingFunc()
+	}
+	var r0 bool
+	var r1 error
+	return r0, r1
+}
+
+func (m *Build) IsBuildingCtx(ctx context.Context) (bool, error) {
+	m.record("IsBuildingCtx", ctx)
+	if m.IsBuildingCtxFunc != nil {
+		return m.IsBuildingCtxFunc(ctx)
+	}
+	var r0 bool
+	var r1 error
+	return r0, r1
+}
+
+func (m *Build) GetResult() (string, error) {
+	m.record("GetResult")
+	if m.GetResultFunc != nil {
+		return m.GetResultFunc()
+	}
+	var r0 string
+	var r1 error
+	return r0, r1
+}
+
+func (m *Build) GetResultCtx(ctx context.Context) (string, error) {
+	m.record("GetResultCtx", ctx)
+	if m.GetResultCtxFunc != nil {
+		return m.GetResultCtxFunc(ctx)
+	}
+	var r0 string
+	var r1 error
+	return r0, r1
+}
+
+func (m *Build) Delete() (*http.Response, error) {
+	m.record("Delete")
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc()
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Build) DeleteCtx(ctx context.Context) (*http.Response, error) {
+	m.record("DeleteCtx", ctx)
+	if m.DeleteCtxFunc != nil {
+		return m.DeleteCtxFunc(ctx)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Build) Stop() (*http.Response, error) {
+	m.record("Stop")
+	if m.StopFunc != nil {
+		return m.StopFunc()
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Build) StopCtx(ctx context.Context) (*http.Response, error) {
+	m.record("StopCtx", ctx)
+	if m.StopCtxFunc != nil {
+		return m.StopCtxFunc(ctx)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Build) Kill() (*http.Response, error) {
+	m.record("Kill")
+	if m.KillFunc != nil {
+		return m.KillFunc()
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Build) KillCtx(ctx context.Context) (*http.Response, error) {
+	m.record("KillCtx", ctx)
+	if m.KillCtxFunc != nil {
+		return m.KillCtxFunc(ctx)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Build) Term() (*http.Response, error) {
+	m.record("Term")
+	if m.TermFunc != nil {
+		return m.TermFunc()
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Build) TermCtx(ctx context.Context) (*http.Response, error) {
+	m.record("TermCtx", ctx)
+	if m.TermCtxFunc != nil {
+		return m.TermCtxFunc(ctx)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Build) GetJob() (jenkins.JobAPI, error) {
+	m.record("GetJob")
+	if m.GetJobFunc != nil {
+		return m.GetJobFunc()
+	}
+	var r0 jenkins.JobAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Build) GetJobCtx(ctx context.Context) (jenkins.JobAPI, error) {
+	m.record("GetJobCtx", ctx)
+	if m.GetJobCtxFunc != nil {
+		return m.GetJobCtxFunc(ctx)
+	}
+	var r0 jenkins.JobAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Build) LoopLog(f func(string) error) error {
+	m.record("LoopLog", f)
+	if m.LoopLogFunc != nil {
+		return m.LoopLogFunc(f)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *Build) LoopLogCtx(ctx context.Context, f func(string) error) error {
+	m.record("LoopLogCtx", ctx, f)
+	if m.LoopLogCtxFunc != nil {
+		return m.LoopLogCtxFunc(ctx, f)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *Build) LoopProgressiveLog(kind string, f func(string) error) error {
+	m.record("LoopProgressiveLog", kind, f)
+	if m.LoopProgressiveLogFunc != nil {
+		return m.LoopProgressiveLogFunc(kind, f)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *Build) StreamLog(ctx context.Context, kind string, f func(string) error) error {
+	m.record("StreamLog", ctx, kind, f)
+	if m.StreamLogFunc != nil {
+		return m.StreamLogFunc(ctx, kind, f)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *Build) GetDescription() (string, error) {
+	m.record("GetDescription")
+	if m.GetDescriptionFunc != nil {
+		return m.GetDescriptionFunc()
+	}
+	var r0 string
+	var r1 error
+	return r0, r1
+}
+
+func (m *Build) GetDescriptionCtx(ctx context.Context) (string, error) {
+	m.record("GetDescriptionCtx", ctx)
+	if m.GetDescriptionCtxFunc != nil {
+		return m.GetDescriptionCtxFunc(ctx)
+	}
+	var r0 string
+	var r1 error
+	return r0, r1
+}
+
+func (m *Build) SetDescription(description string) (*http.Response, error) {
+	m.record("SetDescription", description)
+	if m.SetDescriptionFunc != nil {
+		return m.SetDescriptionFunc(description)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Build) SetDescriptionCtx(ctx context.Context, description string) (*http.Response, error) {
+	m.record("SetDescriptionCtx", ctx, description)
+	if m.SetDescriptionCtxFunc != nil {
+		return m.SetDescriptionCtxFunc(ctx, description)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+// QueueItem is a mock of jenkins.QueueItemAPI, methods return result of
+// XxxFunc if set, zero values otherwise.
+type QueueItem struct {
+	Recorder
+	RequestFunc     func(method string, entry string, body io.Reader) (*http.Response, error)
+	RequestCtxFunc  func(ctx context.Context, method string, entry string, body io.Reader) (*http.Response, error)
+	ApiJsonFunc     func(v any, opts *jenkins.ApiJsonOpts) error
+	ApiJsonCtxFunc  func(ctx context.Context, v any, opts *jenkins.ApiJsonOpts) error
+	StringFunc      func() string
+	GetJobFunc      func() (jenkins.JobAPI, error)
+	GetJobCtxFunc   func(ctx context.Context) (jenkins.JobAPI, error)
+	GetBuildFunc    func() (jenkins.BuildAPI, error)
+	GetBuildCtxFunc func(ctx context.Context) (jenkins.BuildAPI, error)
+}
+
+var _ jenkins.QueueItemAPI = (*QueueItem)(nil)
+
+func (m *QueueItem) Request(method string, entry string, body io.Reader) (*http.Response, error) {
+	m.record("Request", method, entry, body)
+	if m.RequestFunc != nil {
+		return m.RequestFunc(method, entry, body)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *QueueItem) RequestCtx(ctx context.Context, method string, entry string, body io.Reader) (*http.Response, error) {
+	m.record("RequestCtx", ctx, method, entry, body)
+	if m.RequestCtxFunc != nil {
+		return m.RequestCtxFunc(ctx, method, entry, body)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *QueueItem) ApiJson(v any, opts *jenkins.ApiJsonOpts) error {
+	m.record("ApiJson", v, opts)
+	if m.ApiJsonFunc != nil {
+		return m.ApiJsonFunc(v, opts)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *QueueItem) ApiJsonCtx(ctx context.Context, v any, opts *jenkins.ApiJsonOpts) error {
+	m.record("ApiJsonCtx", ctx, v, opts)
+	if m.ApiJsonCtxFunc != nil {
+		return m.ApiJsonCtxFunc(ctx, v, opts)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *QueueItem) String() string {
+	m.record("String")
+	if m.StringFunc != nil {
+		return m.StringFunc()
+	}
+	var r0 string
+	return r0
+}
+
+func (m *QueueItem) GetJob() (jenkins.JobAPI, error) {
+	m.record("GetJob")
+	if m.GetJobFunc != nil {
+		return m.GetJobFunc()
+	}
+	var r0 jenkins.JobAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *QueueItem) GetJobCtx(ctx context.Context) (jenkins.JobAPI, error) {
+	m.record("GetJobCtx", ctx)
+	if m.GetJobCtxFunc != nil {
+		return m.GetJobCtxFunc(ctx)
+	}
+	var r0 jenkins.JobAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *QueueItem) GetBuild() (jenkins.BuildAPI, error) {
+	m.record("GetBuild")
+	if m.GetBuildFunc != nil {
+		return m.GetBuildFunc()
+	}
+	var r0 jenkins.BuildAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *QueueItem) GetBuildCtx(ctx context.Context) (jenkins.BuildAPI, error) {
+	m.record("GetBuildCtx", ctx)
+	if m.GetBuildCtxFunc != nil {
+		return m.GetBuildCtxFunc(ctx)
+	}
+	var r0 jenkins.BuildAPI
+	var r1 error
+	return r0, r1
+}
+
+// Queue is a mock of jenkins.QueueAPI, methods return result of
+// XxxFunc if set, zero values otherwise.
+type Queue struct {
+	Recorder
+	RequestFunc    func(method string, entry string, body io.Reader) (*http.Response, error)
+	RequestCtxFunc func(ctx context.Context, method string, entry string, body io.Reader) (*http.Response, error)
+	ApiJsonFunc    func(v any, opts *jenkins.ApiJsonOpts) error
+	ApiJsonCtxFunc func(ctx context.Context, v any, opts *jenkins.ApiJsonOpts) error
+	StringFunc     func() string
+	ListFunc       func() ([]jenkins.QueueItemAPI, error)
+	ListCtxFunc    func(ctx context.Context) ([]jenkins.QueueItemAPI, error)
+	GetFunc        func(id int) (jenkins.QueueItemAPI, error)
+	GetCtxFunc     func(ctx context.Context, id int) (jenkins.QueueItemAPI, error)
+	CancelFunc     func(id int) (*http.Response, error)
+	CancelCtxFunc  func(ctx context.Context, id int) (*http.Response, error)
+}
+
+var _ jenkins.QueueAPI = (*Queue)(nil)
+
+func (m *Queue) Request(method string, entry string, body io.Reader) (*http.Response, error) {
+	m.record("Request", method, entry, body)
+	if m.RequestFunc != nil {
+		return m.RequestFunc(method, entry, body)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Queue) RequestCtx(ctx context.Context, method string, entry string, body io.Reader) (*http.Response, error) {
+	m.record("RequestCtx", ctx, method, entry, body)
+	if m.RequestCtxFunc != nil {
+		return m.RequestCtxFunc(ctx, method, entry, body)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Queue) ApiJson(v any, opts *jenkins.ApiJsonOpts) error {
+	m.record("ApiJson", v, opts)
+	if m.ApiJsonFunc != nil {
+		return m.ApiJsonFunc(v, opts)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *Queue) ApiJsonCtx(ctx context.Context, v any, opts *jenkins.ApiJsonOpts) error {
+	m.record("ApiJsonCtx", ctx, v, opts)
+	if m.ApiJsonCtxFunc != nil {
+		return m.ApiJsonCtxFunc(ctx, v, opts)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *Queue) String() string {
+	m.record("String")
+	if m.StringFunc != nil {
+		return m.StringFunc()
+	}
+	var r0 string
+	return r0
+}
+
+func (m *Queue) List() ([]jenkins.QueueItemAPI, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc()
+	}
+	var r0 []jenkins.QueueItemAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Queue) ListCtx(ctx context.Context) ([]jenkins.QueueItemAPI, error) {
+	m.record("ListCtx", ctx)
+	if m.ListCtxFunc != nil {
+		return m.ListCtxFunc(ctx)
+	}
+	var r0 []jenkins.QueueItemAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Queue) Get(id int) (jenkins.QueueItemAPI, error) {
+	m.record("Get", id)
+	if m.GetFunc != nil {
+		return m.GetFunc(id)
+	}
+	var r0 jenkins.QueueItemAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Queue) GetCtx(ctx context.Context, id int) (jenkins.QueueItemAPI, error) {
+	m.record("GetCtx", ctx, id)
+	if m.GetCtxFunc != nil {
+		return m.GetCtxFunc(ctx, id)
+	}
+	var r0 jenkins.QueueItemAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Queue) Cancel(id int) (*http.Response, error) {
+	m.record("Cancel", id)
+	if m.CancelFunc != nil {
+		return m.CancelFunc(id)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Queue) CancelCtx(ctx context.Context, id int) (*http.Response, error) {
+	m.record("CancelCtx", ctx, id)
+	if m.CancelCtxFunc != nil {
+		return m.CancelCtxFunc(ctx, id)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+// Nodes is a mock of jenkins.NodesAPI, methods return result of
+// XxxFunc if set, zero values otherwise.
+type Nodes struct {
+	Recorder
+	RequestFunc         func(method string, entry string, body io.Reader) (*http.Response, error)
+	RequestCtxFunc      func(ctx context.Context, method string, entry string, body io.Reader) (*http.Response, error)
+	ApiJsonFunc         func(v any, opts *jenkins.ApiJsonOpts) error
+	ApiJsonCtxFunc      func(ctx context.Context, v any, opts *jenkins.ApiJsonOpts) error
+	StringFunc          func() string
+	GetBuildsFunc       func() ([]jenkins.BuildAPI, error)
+	GetBuildsCtxFunc    func(ctx context.Context) ([]jenkins.BuildAPI, error)
+	GetFunc             func(name string) (*jenkins.Computer, error)
+	GetCtxFunc          func(ctx context.Context, name string) (*jenkins.Computer, error)
+	ListFunc            func() ([]*jenkins.Computer, error)
+	ListCtxFunc         func(ctx context.Context) ([]*jenkins.Computer, error)
+	EnableFunc          func(name string) (*http.Response, error)
+	EnableCtxFunc       func(ctx context.Context, name string) (*http.Response, error)
+	DisableFunc         func(name string, msg string) (*http.Response, error)
+	DisableCtxFunc      func(ctx context.Context, name string, msg string) (*http.Response, error)
+	DeleteFunc          func(name string) (*http.Response, error)
+	DeleteCtxFunc       func(ctx context.Context, name string) (*http.Response, error)
+	GetConfigureFunc    func(name string) (string, error)
+	GetConfigureCtxFunc func(ctx context.Context, name string) (string, error)
+	SetConfigureFunc    func(name string, xml io.Reader) (*http.Response, error)
+	SetConfigureCtxFunc func(ctx context.Context, name string, xml io.Reader) (*http.Response, error)
+	CreateFunc          func(name string, xml io.Reader) (*http.Response, error)
+	CreateCtxFunc       func(ctx context.Context, name string, xml io.Reader) (*http.Response, error)
+}
+
+var _ jenkins.NodesAPI = (*Nodes)(nil)
+
+func (m *Nodes) Request(method string, entry string, body io.Reader) (*http.Response, error) {
+	m.record("Request", method, entry, body)
+	if m.RequestFunc != nil {
+		return m.RequestFunc(method, entry, body)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Nodes) RequestCtx(ctx context.Context, method string, entry string, body io.Reader) (*http.Response, error) {
+	m.record("RequestCtx", ctx, method, entry, body)
+	if m.RequestCtxFunc != nil {
+		return m.RequestCtxFunc(ctx, method, entry, body)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Nodes) ApiJson(v any, opts *jenkins.ApiJsonOpts) error {
+	m.record("ApiJson", v, opts)
+	if m.ApiJsonFunc != nil {
+		return m.ApiJsonFunc(v, opts)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *Nodes) ApiJsonCtx(ctx context.Context, v any, opts *jenkins.ApiJsonOpts) error {
+	m.record("ApiJsonCtx", ctx, v, opts)
+	if m.ApiJsonCtxFunc != nil {
+		return m.ApiJsonCtxFunc(ctx, v, opts)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *Nodes) String() string {
+	m.record("String")
+	if m.StringFunc != nil {
+		return m.StringFunc()
+	}
+	var r0 string
+	return r0
+}
+
+func (m *Nodes) GetBuilds() ([]jenkins.BuildAPI, error) {
+	m.record("GetBuilds")
+	if m.GetBuildsFunc != nil {
+		return m.GetBuildsFunc()
+	}
+	var r0 []jenkins.BuildAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Nodes) GetBuildsCtx(ctx context.Context) ([]jenkins.BuildAPI, error) {
+	m.record("GetBuildsCtx", ctx)
+	if m.GetBuildsCtxFunc != nil {
+		return m.GetBuildsCtxFunc(ctx)
+	}
+	var r0 []jenkins.BuildAPI
+	var r1 error
+	return r0, r1
+}
+
+func (m *Nodes) Get(name string) (*jenkins.Computer, error) {
+	m.record("Get", name)
+	if m.GetFunc != nil {
+		return m.GetFunc(name)
+	}
+	var r0 *jenkins.Computer
+	var r1 error
+	return r0, r1
+}
+
+func (m *Nodes) GetCtx(ctx context.Context, name string) (*jenkins.Computer, error) {
+	m.record("GetCtx", ctx, name)
+	if m.GetCtxFunc != nil {
+		return m.GetCtxFunc(ctx, name)
+	}
+	var r0 *jenkins.Computer
+	var r1 error
+	return r0, r1
+}
+
+func (m *Nodes) List() ([]*jenkins.Computer, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc()
+	}
+	var r0 []*jenkins.Computer
+	var r1 error
+	return r0, r1
+}
+
+func (m *Nodes) ListCtx(ctx context.Context) ([]*jenkins.Computer, error) {
+	m.record("ListCtx", ctx)
+	if m.ListCtxFunc != nil {
+		return m.ListCtxFunc(ctx)
+	}
+	var r0 []*jenkins.Computer
+	var r1 error
+	return r0, r1
+}
+
+func (m *Nodes) Enable(name string) (*http.Response, error) {
+	m.record("Enable", name)
+	if m.EnableFunc != nil {
+		return m.EnableFunc(name)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Nodes) EnableCtx(ctx context.Context, name string) (*http.Response, error) {
+	m.record("EnableCtx", ctx, name)
+	if m.EnableCtxFunc != nil {
+		return m.EnableCtxFunc(ctx, name)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Nodes) Disable(name string, msg string) (*http.Response, error) {
+	m.record("Disable", name, msg)
+	if m.DisableFunc != nil {
+		return m.DisableFunc(name, msg)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Nodes) DisableCtx(ctx context.Context, name string, msg string) (*http.Response, error) {
+	m.record("DisableCtx", ctx, name, msg)
+	if m.DisableCtxFunc != nil {
+		return m.DisableCtxFunc(ctx, name, msg)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Nodes) Delete(name string) (*http.Response, error) {
+	m.record("Delete", name)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(name)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Nodes) DeleteCtx(ctx context.Context, name string) (*http.Response, error) {
+	m.record("DeleteCtx", ctx, name)
+	if m.DeleteCtxFunc != nil {
+		return m.DeleteCtxFunc(ctx, name)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Nodes) GetConfigure(name string) (string, error) {
+	m.record("GetConfigure", name)
+	if m.GetConfigureFunc != nil {
+		return m.GetConfigureFunc(name)
+	}
+	var r0 string
+	var r1 error
+	return r0, r1
+}
+
+func (m *Nodes) GetConfigureCtx(ctx context.Context, name string) (string, error) {
+	m.record("GetConfigureCtx", ctx, name)
+	if m.GetConfigureCtxFunc != nil {
+		return m.GetConfigureCtxFunc(ctx, name)
+	}
+	var r0 string
+	var r1 error
+	return r0, r1
+}
+
+func (m *Nodes) SetConfigure(name string, xml io.Reader) (*http.Response, error) {
+	m.record("SetConfigure", name, xml)
+	if m.SetConfigureFunc != nil {
+		return m.SetConfigureFunc(name, xml)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Nodes) SetConfigureCtx(ctx context.Context, name string, xml io.Reader) (*http.Response, error) {
+	m.record("SetConfigureCtx", ctx, name, xml)
+	if m.SetConfigureCtxFunc != nil {
+		return m.SetConfigureCtxFunc(ctx, name, xml)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Nodes) Create(name string, xml io.Reader) (*http.Response, error) {
+	m.record("Create", name, xml)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(name, xml)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Nodes) CreateCtx(ctx context.Context, name string, xml io.Reader) (*http.Response, error) {
+	m.record("CreateCtx", ctx, name, xml)
+	if m.CreateCtxFunc != nil {
+		return m.CreateCtxFunc(ctx, name, xml)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+// Plugins is a mock of jenkins.PluginsAPI, methods return result of
+// XxxFunc if set, zero values otherwise.
+type Plugins struct {
+	Recorder
+	RequestFunc    func(method string, entry string, body io.Reader) (*http.Response, error)
+	RequestCtxFunc func(ctx context.Context, method string, entry string, body io.Reader) (*http.Response, error)
+	ApiJsonFunc    func(v any, opts *jenkins.ApiJsonOpts) error
+	ApiJsonCtxFunc func(ctx context.Context, v any, opts *jenkins.ApiJsonOpts) error
+	StringFunc     func() string
+	GetFunc        func(shortName string) (*jenkins.PluginJson, error)
+	GetCtxFunc     func(ctx context.Context, shortName string) (*jenkins.PluginJson, error)
+	ListFunc       func() ([]*jenkins.PluginJson, error)
+	ListCtxFunc    func(ctx context.Context) ([]*jenkins.PluginJson, error)
+}
+
+var _ jenkins.PluginsAPI = (*Plugins)(nil)
+
+func (m *Plugins) Request(method string, entry string, body io.Reader) (*http.Response, error) {
+	m.record("Request", method, entry, body)
+	if m.RequestFunc != nil {
+		return m.RequestFunc(method, entry, body)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Plugins) RequestCtx(ctx context.Context, method string, entry string, body io.Reader) (*http.Response, error) {
+	m.record("RequestCtx", ctx, method, entry, body)
+	if m.RequestCtxFunc != nil {
+		return m.RequestCtxFunc(ctx, method, entry, body)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Plugins) ApiJson(v any, opts *jenkins.ApiJsonOpts) error {
+	m.record("ApiJson", v, opts)
+	if m.ApiJsonFunc != nil {
+		return m.ApiJsonFunc(v, opts)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *Plugins) ApiJsonCtx(ctx context.Context, v any, opts *jenkins.ApiJsonOpts) error {
+	m.record("ApiJsonCtx", ctx, v, opts)
+	if m.ApiJsonCtxFunc != nil {
+		return m.ApiJsonCtxFunc(ctx, v, opts)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *Plugins) String() string {
+	m.record("String")
+	if m.StringFunc != nil {
+		return m.StringFunc()
+	}
+	var r0 string
+	return r0
+}
+
+func (m *Plugins) Get(shortName string) (*jenkins.PluginJson, error) {
+	m.record("Get", shortName)
+	if m.GetFunc != nil {
+		return m.GetFunc(shortName)
+	}
+	var r0 *jenkins.PluginJson
+	var r1 error
+	return r0, r1
+}
+
+func (m *Plugins) GetCtx(ctx context.Context, shortName string) (*jenkins.PluginJson, error) {
+	m.record("GetCtx", ctx, shortName)
+	if m.GetCtxFunc != nil {
+		return m.GetCtxFunc(ctx, shortName)
+	}
+	var r0 *jenkins.PluginJson
+	var r1 error
+	return r0, r1
+}
+
+func (m *Plugins) List() ([]*jenkins.PluginJson, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc()
+	}
+	var r0 []*jenkins.PluginJson
+	var r1 error
+	return r0, r1
+}
+
+func (m *Plugins) ListCtx(ctx context.Context) ([]*jenkins.PluginJson, error) {
+	m.record("ListCtx", ctx)
+	if m.ListCtxFunc != nil {
+		return m.ListCtxFunc(ctx)
+	}
+	var r0 []*jenkins.PluginJson
+	var r1 error
+	return r0, r1
+}
+
+// Credentials is a mock of jenkins.CredentialsAPI, methods return result of
+// XxxFunc if set, zero values otherwise.
+type Credentials struct {
+	Recorder
+	RequestFunc         func(method string, entry string, body io.Reader) (*http.Response, error)
+	RequestCtxFunc      func(ctx context.Context, method string, entry string, body io.Reader) (*http.Response, error)
+	ApiJsonFunc         func(v any, opts *jenkins.ApiJsonOpts) error
+	ApiJsonCtxFunc      func(ctx context.Context, v any, opts *jenkins.ApiJsonOpts) error
+	StringFunc          func() string
+	GetFunc             func(name string) (*jenkins.CredentialJson, error)
+	GetCtxFunc          func(ctx context.Context, name string) (*jenkins.CredentialJson, error)
+	CreateFunc          func(xml io.Reader) (*http.Response, error)
+	CreateCtxFunc       func(ctx context.Context, xml io.Reader) (*http.Response, error)
+	DeleteFunc          func(name string) (*http.Response, error)
+	DeleteCtxFunc       func(ctx context.Context, name string) (*http.Response, error)
+	GetConfigureFunc    func(name string) (string, error)
+	GetConfigureCtxFunc func(ctx context.Context, name string) (string, error)
+	SetConfigureFunc    func(name string, xml io.Reader) (*http.Response, error)
+	SetConfigureCtxFunc func(ctx context.Context, name string, xml io.Reader) (*http.Response, error)
+	ListFunc            func() ([]*jenkins.CredentialJson, error)
+	ListCtxFunc         func(ctx context.Context) ([]*jenkins.CredentialJson, error)
+}
+
+var _ jenkins.CredentialsAPI = (*Credentials)(nil)
+
+func (m *Credentials) Request(method string, entry string, body io.Reader) (*http.Response, error) {
+	m.record("Request", method, entry, body)
+	if m.RequestFunc != nil {
+		return m.RequestFunc(method, entry, body)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Credentials) RequestCtx(ctx context.Context, method string, entry string, body io.Reader) (*http.Response, error) {
+	m.record("RequestCtx", ctx, method, entry, body)
+	if m.RequestCtxFunc != nil {
+		return m.RequestCtxFunc(ctx, method, entry, body)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Credentials) ApiJson(v any, opts *jenkins.ApiJsonOpts) error {
+	m.record("ApiJson", v, opts)
+	if m.ApiJsonFunc != nil {
+		return m.ApiJsonFunc(v, opts)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *Credentials) ApiJsonCtx(ctx context.Context, v any, opts *jenkins.ApiJsonOpts) error {
+	m.record("ApiJsonCtx", ctx, v, opts)
+	if m.ApiJsonCtxFunc != nil {
+		return m.ApiJsonCtxFunc(ctx, v, opts)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *Credentials) String() string {
+	m.record("String")
+	if m.StringFunc != nil {
+		return m.StringFunc()
+	}
+	var r0 string
+	return r0
+}
+
+func (m *Credentials) Get(name string) (*jenkins.CredentialJson, error) {
+	m.record("Get", name)
+	if m.GetFunc != nil {
+		return m.GetFunc(name)
+	}
+	var r0 *jenkins.CredentialJson
+	var r1 error
+	return r0, r1
+}
+
+func (m *Credentials) GetCtx(ctx context.Context, name string) (*jenkins.CredentialJson, error) {
+	m.record("GetCtx", ctx, name)
+	if m.GetCtxFunc != nil {
+		return m.GetCtxFunc(ctx, name)
+	}
+	var r0 *jenkins.CredentialJson
+	var r1 error
+	return r0, r1
+}
+
+func (m *Credentials) Create(xml io.Reader) (*http.Response, error) {
+	m.record("Create", xml)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(xml)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Credentials) CreateCtx(ctx context.Context, xml io.Reader) (*http.Response, error) {
+	m.record("CreateCtx", ctx, xml)
+	if m.CreateCtxFunc != nil {
+		return m.CreateCtxFunc(ctx, xml)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Credentials) Delete(name string) (*http.Response, error) {
+	m.record("Delete", name)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(name)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Credentials) DeleteCtx(ctx context.Context, name string) (*http.Response, error) {
+	m.record("DeleteCtx", ctx, name)
+	if m.DeleteCtxFunc != nil {
+		return m.DeleteCtxFunc(ctx, name)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Credentials) GetConfigure(name string) (string, error) {
+	m.record("GetConfigure", name)
+	if m.GetConfigureFunc != nil {
+		return m.GetConfigureFunc(name)
+	}
+	var r0 string
+	var r1 error
+	return r0, r1
+}
+
+func (m *Credentials) GetConfigureCtx(ctx context.Context, name string) (string, error) {
+	m.record("GetConfigureCtx", ctx, name)
+	if m.GetConfigureCtxFunc != nil {
+		return m.GetConfigureCtxFunc(ctx, name)
+	}
+	var r0 string
+	var r1 error
+	return r0, r1
+}
+
+func (m *Credentials) SetConfigure(name string, xml io.Reader) (*http.Response, error) {
+	m.record("SetConfigure", name, xml)
+	if m.SetConfigureFunc != nil {
+		return m.SetConfigureFunc(name, xml)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Credentials) SetConfigureCtx(ctx context.Context, name string, xml io.Reader) (*http.Response, error) {
+	m.record("SetConfigureCtx", ctx, name, xml)
+	if m.SetConfigureCtxFunc != nil {
+		return m.SetConfigureCtxFunc(ctx, name, xml)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Credentials) List() ([]*jenkins.CredentialJson, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc()
+	}
+	var r0 []*jenkins.CredentialJson
+	var r1 error
+	return r0, r1
+}
+
+func (m *Credentials) ListCtx(ctx context.Context) ([]*jenkins.CredentialJson, error) {
+	m.record("ListCtx", ctx)
+	if m.ListCtxFunc != nil {
+		return m.ListCtxFunc(ctx)
+	}
+	var r0 []*jenkins.CredentialJson
+	var r1 error
+	return r0, r1
+}
+
+// Views is a mock of jenkins.ViewsAPI, methods return result of
+// XxxFunc if set, zero values otherwise.
+type Views struct {
+	Recorder
+	RequestFunc              func(method string, entry string, body io.Reader) (*http.Response, error)
+	RequestCtxFunc           func(ctx context.Context, method string, entry string, body io.Reader) (*http.Response, error)
+	ApiJsonFunc              func(v any, opts *jenkins.ApiJsonOpts) error
+	ApiJsonCtxFunc           func(ctx context.Context, v any, opts *jenkins.ApiJsonOpts) error
+	StringFunc               func() string
+	GetFunc                  func(name string) (*jenkins.ViewJson, error)
+	GetCtxFunc               func(ctx context.Context, name string) (*jenkins.ViewJson, error)
+	CreateFunc               func(name string, xml io.Reader) (*http.Response, error)
+	CreateCtxFunc            func(ctx context.Context, name string, xml io.Reader) (*http.Response, error)
+	DeleteFunc               func(name string) (*http.Response, error)
+	DeleteCtxFunc            func(ctx context.Context, name string) (*http.Response, error)
+	AddJobToViewFunc         func(name string, jobName string) (*http.Response, error)
+	AddJobToViewCtxFunc      func(ctx context.Context, name string, jobName string) (*http.Response, error)
+	RemoveJobFromViewFunc    func(name string, jobName string) (*http.Response, error)
+	RemoveJobFromViewCtxFunc func(ctx context.Context, name string, jobName string) (*http.Response, error)
+	GetConfigureFunc         func(name string) (string, error)
+	GetConfigureCtxFunc      func(ctx context.Context, name string) (string, error)
+	SetConfigureFunc         func(name string, xml io.Reader) (*http.Response, error)
+	SetConfigureCtxFunc      func(ctx context.Context, name string, xml io.Reader) (*http.Response, error)
+	SetDescriptionFunc       func(name string, description string) (*http.Response, error)
+	SetDescriptionCtxFunc    func(ctx context.Context, name string, description string) (*http.Response, error)
+	ListFunc                 func() ([]*jenkins.ViewJson, error)
+	ListCtxFunc              func(ctx context.Context) ([]*jenkins.ViewJson, error)
+}
+
+var _ jenkins.ViewsAPI = (*Views)(nil)
+
+func (m *Views) Request(method string, entry string, body io.Reader) (*http.Response, error) {
+	m.record("Request", method, entry, body)
+	if m.RequestFunc != nil {
+		return m.RequestFunc(method, entry, body)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Views) RequestCtx(ctx context.Context, method string, entry string, body io.Reader) (*http.Response, error) {
+	m.record("RequestCtx", ctx, method, entry, body)
+	if m.RequestCtxFunc != nil {
+		return m.RequestCtxFunc(ctx, method, entry, body)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Views) ApiJson(v any, opts *jenkins.ApiJsonOpts) error {
+	m.record("ApiJson", v, opts)
+	if m.ApiJsonFunc != nil {
+		return m.ApiJsonFunc(v, opts)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *Views) ApiJsonCtx(ctx context.Context, v any, opts *jenkins.ApiJsonOpts) error {
+	m.record("ApiJsonCtx", ctx, v, opts)
+	if m.ApiJsonCtxFunc != nil {
+		return m.ApiJsonCtxFunc(ctx, v, opts)
+	}
+	var r0 error
+	return r0
+}
+
+func (m *Views) String() string {
+	m.record("String")
+	if m.StringFunc != nil {
+		return m.StringFunc()
+	}
+	var r0 string
+	return r0
+}
+
+func (m *Views) Get(name string) (*jenkins.ViewJson, error) {
+	m.record("Get", name)
+	if m.GetFunc != nil {
+		return m.GetFunc(name)
+	}
+	var r0 *jenkins.ViewJson
+	var r1 error
+	return r0, r1
+}
+
+func (m *Views) GetCtx(ctx context.Context, name string) (*jenkins.ViewJson, error) {
+	m.record("GetCtx", ctx, name)
+	if m.GetCtxFunc != nil {
+		return m.GetCtxFunc(ctx, name)
+	}
+	var r0 *jenkins.ViewJson
+	var r1 error
+	return r0, r1
+}
+
+func (m *Views) Create(name string, xml io.Reader) (*http.Response, error) {
+	m.record("Create", name, xml)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(name, xml)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Views) CreateCtx(ctx context.Context, name string, xml io.Reader) (*http.Response, error) {
+	m.record("CreateCtx", ctx, name, xml)
+	if m.CreateCtxFunc != nil {
+		return m.CreateCtxFunc(ctx, name, xml)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Views) Delete(name string) (*http.Response, error) {
+	m.record("Delete", name)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(name)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Views) DeleteCtx(ctx context.Context, name string) (*http.Response, error) {
+	m.record("DeleteCtx", ctx, name)
+	if m.DeleteCtxFunc != nil {
+		return m.DeleteCtxFunc(ctx, name)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Views) AddJobToView(name string, jobName string) (*http.Response, error) {
+	m.record("AddJobToView", name, jobName)
+	if m.AddJobToViewFunc != nil {
+		return m.AddJobToViewFunc(name, jobName)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Views) AddJobToViewCtx(ctx context.Context, name string, jobName string) (*http.Response, error) {
+	m.record("AddJobToViewCtx", ctx, name, jobName)
+	if m.AddJobToViewCtxFunc != nil {
+		return m.AddJobToViewCtxFunc(ctx, name, jobName)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Views) RemoveJobFromView(name string, jobName string) (*http.Response, error) {
+	m.record("RemoveJobFromView", name, jobName)
+	if m.RemoveJobFromViewFunc != nil {
+		return m.RemoveJobFromViewFunc(name, jobName)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Views) RemoveJobFromViewCtx(ctx context.Context, name string, jobName string) (*http.Response, error) {
+	m.record("RemoveJobFromViewCtx", ctx, name, jobName)
+	if m.RemoveJobFromViewCtxFunc != nil {
+		return m.RemoveJobFromViewCtxFunc(ctx, name, jobName)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Views) GetConfigure(name string) (string, error) {
+	m.record("GetConfigure", name)
+	if m.GetConfigureFunc != nil {
+		return m.GetConfigureFunc(name)
+	}
+	var r0 string
+	var r1 error
+	return r0, r1
+}
+
+func (m *Views) GetConfigureCtx(ctx context.Context, name string) (string, error) {
+	m.record("GetConfigureCtx", ctx, name)
+	if m.GetConfigureCtxFunc != nil {
+		return m.GetConfigureCtxFunc(ctx, name)
+	}
+	var r0 string
+	var r1 error
+	return r0, r1
+}
+
+func (m *Views) SetConfigure(name string, xml io.Reader) (*http.Response, error) {
+	m.record("SetConfigure", name, xml)
+	if m.SetConfigureFunc != nil {
+		return m.SetConfigureFunc(name, xml)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Views) SetConfigureCtx(ctx context.Context, name string, xml io.Reader) (*http.Response, error) {
+	m.record("SetConfigureCtx", ctx, name, xml)
+	if m.SetConfigureCtxFunc != nil {
+		return m.SetConfigureCtxFunc(ctx, name, xml)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Views) SetDescription(name string, description string) (*http.Response, error) {
+	m.record("SetDescription", name, description)
+	if m.SetDescriptionFunc != nil {
+		return m.SetDescriptionFunc(name, description)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Views) SetDescriptionCtx(ctx context.Context, name string, description string) (*http.Response, error) {
+	m.record("SetDescriptionCtx", ctx, name, description)
+	if m.SetDescriptionCtxFunc != nil {
+		return m.SetDescriptionCtxFunc(ctx, name, description)
+	}
+	var r0 *http.Response
+	var r1 error
+	return r0, r1
+}
+
+func (m *Views) List() ([]*jenkins.ViewJson, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc()
+	}
+	var r0 []*jenkins.ViewJson
+	var r1 error
+	return r0, r1
+}
+
+func (m *Views) ListCtx(ctx context.Context) ([]*jenkins.ViewJson, error) {
+	m.record("ListCtx", ctx)
+	if m.ListCtxFunc != nil {
+		return m.ListCtxFunc(ctx)
+	}
+	var r0 []*jenkins.ViewJson
+	var r1 error
+	return r0, r1
+}
@@ -0,0 +1,103 @@
+package jenkinsmock_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/joelee2012/go-jenkins"
+	"github.com/joelee2012/go-jenkins/jenkinsmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func describe(job jenkins.JobAPI) (string, error) {
+	if _, err := job.SetDescription("new"); err != nil {
+		return "", err
+	}
+	return job.GetDescriptionCtx(context.Background())
+}
+
+func TestMock(t *testing.T) {
+	job := &jenkinsmock.Job{
+		GetDescriptionCtxFunc: func(ctx context.Context) (string, error) {
+			return "canned", nil
+		},
+	}
+	desc, err := describe(job)
+	assert.NoError(t, err)
+	assert.Equal(t, "canned", desc)
+	calls := job.Calls()
+	assert.Len(t, calls, 2)
+	assert.Equal(t, jenkinsmock.Call{Method: "SetDescription", Args: []any{"new"}}, calls[0])
+	assert.Equal(t, "GetDescriptionCtx", calls[1].Method)
+	assert.Len(t, job.CallsTo("SetDescription"), 1)
+
+	job.Reset()
+	assert.Empty(t, job.Calls())
+
+	wantErr := errors.New("boom")
+	job.SetDescriptionFunc = func(description string) (*http.Response, error) {
+		return nil, wantErr
+	}
+	_, err = describe(job)
+	assert.ErrorIs(t, err, wantErr)
+	assert.Empty(t, job.CallsTo("GetDescriptionCtx"))
+}
+
+// result of the last build of job, it depends on interfaces only
+func lastResult(client jenkins.JenkinsAPI, name string) (string, error) {
+	job, err := client.GetJob(name)
+	if err != nil {
+		return "", err
+	}
+	build, err := job.GetLastBuild()
+	if err != nil {
+		return "", err
+	}
+	return build.GetResult()
+}
+
+func TestMockChain(t *testing.T) {
+	build := &jenkinsmock.Build{
+		GetResultFunc: func() (string, error) { return "FAILURE", nil },
+	}
+	job := &jenkinsmock.Job{
+		GetLastBuildFunc: func() (jenkins.BuildAPI, error) { return build, nil },
+	}
+	client := &jenkinsmock.Jenkins{
+		GetJobFunc: func(fullName string) (jenkins.JobAPI, error) { return job, nil },
+	}
+	result, err := lastResult(client, "folder/job")
+	assert.NoError(t, err)
+	assert.Equal(t, "FAILURE", result)
+	assert.Equal(t, []any{"folder/job"}, client.CallsTo("GetJob")[0].Args)
+	assert.Len(t, job.CallsTo("GetLastBuild"), 1)
+	assert.Len(t, build.CallsTo("GetResult"), 1)
+}
+
+func TestMockZeroValues(t *testing.T) {
+	j := &jenkinsmock.Jenkins{}
+	version, err := j.GetVersion()
+	assert.NoError(t, err)
+	assert.Empty(t, version)
+	jobs, err := j.ListJobs(1)
+	assert.NoError(t, err)
+	assert.Nil(t, jobs)
+	assert.Nil(t, j.AllJobs(nil))
+}
+
+func TestMockConcurrent(t *testing.T) {
+	nodes := &jenkinsmock.Nodes{}
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			nodes.Enable("agent")
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, nodes.CallsTo("Enable"), 10)
+}
@@ -0,0 +1,63 @@
+// Package jenkinsmock provides mocks of the service interfaces of go-jenkins,
+// eg: jenkins.JobAPI, which record calls and return canned responses:
+//
+//	job := &jenkinsmock.Job{
+//		GetDescriptionFunc: func() (string, error) {
+//			return "canned", nil
+//		},
+//	}
+//	useJob(job)
+//	calls := job.CallsTo("GetDescription")
+//
+// Mocks are generated from interface.go by go generate.
+package jenkinsmock
+
+import (
+	"slices"
+	"sync"
+)
+
+// Call is one recorded call of mock.
+type Call struct {
+	Method string
+	Args   []any
+}
+
+// Recorder records calls of mock, it is safe for concurrent use.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (r *Recorder) record(method string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns all calls in order.
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.calls)
+}
+
+// CallsTo returns calls of method in order.
+func (r *Recorder) CallsTo(method string) []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var calls []Call
+	for _, c := range r.calls {
+		if c.Method == method {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// Reset forgets recorded calls.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
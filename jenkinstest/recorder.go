@@ -0,0 +1,399 @@
+// Package jenkinstest provides helpers to test code using go-jenkins without
+// a live Jenkins.
+//
+// Recorder captures exchanges with a real Jenkins into a cassette file once
+// and replays them afterwards:
+//
+//	mode := jenkinstest.ModeReplay
+//	if os.Getenv("RECORD") != "" {
+//		mode = jenkinstest.ModeRecord
+//	}
+//	rec, err := jenkinstest.NewRecorder("testdata/job.json", jenkinstest.WithMode(mode))
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer rec.Stop()
+//	client, _ := jenkins.New("http://localhost:8080/", "admin", "1234")
+//	client.SetClient(rec.Client())
+package jenkinstest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+type Mode int
+
+const (
+	// serve requests from cassette, real Jenkins is never contacted
+	ModeReplay Mode = iota
+	// send requests to real Jenkins and save exchanges to cassette on Stop
+	ModeRecord
+)
+
+// ErrNoInteraction is returned by replaying Recorder for request which
+// matches no unused interaction of cassette.
+var ErrNoInteraction = errors.New("jenkinstest: no matching interaction")
+
+// Cassette is the file format of recorded exchanges.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Matcher reports whether recorded request i matches req.
+type Matcher func(req *http.Request, i *Request) bool
+
+func MatchMethod(req *http.Request, i *Request) bool {
+	return req.Method == i.Method
+}
+
+func MatchPath(req *http.Request, i *Request) bool {
+	u, err := url.Parse(i.URL)
+	return err == nil && u.Path == req.URL.Path
+}
+
+// MatchQuery compares query of requests regardless of order of parameters,
+// scrubbed parameters match any value.
+func MatchQuery(req *http.Request, i *Request) bool {
+	u, err := url.Parse(i.URL)
+	if err != nil {
+		return false
+	}
+	recorded, actual := u.Query(), req.URL.Query()
+	if len(recorded) != len(actual) {
+		return false
+	}
+	for name, values := range recorded {
+		if isSensitiveParam(name) {
+			if _, ok := actual[name]; !ok {
+				return false
+			}
+			continue
+		}
+		if !slices.Equal(values, actual[name]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Matchers used by default
+var DefaultMatchers = []Matcher{MatchMethod, MatchPath, MatchQuery}
+
+// Recorder is a http.RoundTripper which records or replays exchanges, it is
+// safe for concurrent use.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+	matchers  []Matcher
+	mu        sync.Mutex
+	cassette  *Cassette
+	used      []bool
+	secrets   []string
+}
+
+type Option func(*Recorder)
+
+func WithMode(mode Mode) Option {
+	return func(r *Recorder) {
+		r.mode = mode
+	}
+}
+
+// Replace DefaultMatchers, eg: match by method and path only when query
+// contains timestamps.
+func WithMatchers(matchers ...Matcher) Option {
+	return func(r *Recorder) {
+		r.matchers = matchers
+	}
+}
+
+// Transport to send requests while recording, http.DefaultTransport if not
+// set.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(r *Recorder) {
+		r.transport = rt
+	}
+}
+
+// Scrub extra secrets, eg: api token, which are replaced in urls, headers
+// and bodies.
+func WithSecrets(secrets ...string) Option {
+	return func(r *Recorder) {
+		r.secrets = append(r.secrets, secrets...)
+	}
+}
+
+// Create recorder for cassette at path, the cassette must exist in
+// ModeReplay.
+func NewRecorder(path string, opts ...Option) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		transport: http.DefaultTransport,
+		matchers:  DefaultMatchers,
+		cassette:  &Cassette{},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, r.cassette); err != nil {
+			return nil, fmt.Errorf("jenkinstest: decode %s: %w", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+	return r, nil
+}
+
+// Client which sends requests through recorder and does not follow
+// redirect as Jenkins.Client() does.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{
+		Transport: r,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.mode == ModeReplay {
+		return r.replay(req)
+	}
+	return r.record(req)
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for n, i := range r.cassette.Interactions {
+		if r.used[n] || !r.match(req, &i.Request) {
+			continue
+		}
+		r.used[n] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", i.Response.StatusCode, http.StatusText(i.Response.StatusCode)),
+			StatusCode:    i.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        i.Response.Header.Clone(),
+			Body:          io.NopCloser(strings.NewReader(i.Response.Body)),
+			ContentLength: int64(len(i.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w for %s %s", ErrNoInteraction, req.Method, req.URL)
+}
+
+func (r *Recorder) match(req *http.Request, i *Request) bool {
+	for _, m := range r.matchers {
+		if !m(req, i) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		if reqBody, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	i := &Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: req.Header.Clone(),
+			Body:   string(reqBody),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+			Body:       string(respBody),
+		},
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.collectSecrets(req, resp, respBody)
+	r.cassette.Interactions = append(r.cassette.Interactions, i)
+	return resp, nil
+}
+
+// Stop saves cassette in ModeRecord, secrets are scrubbed before saving.
+// In ModeReplay it returns error if any interaction was not used.
+func (r *Recorder) Stop() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.mode == ModeReplay {
+		for n, used := range r.used {
+			if !used {
+				i := r.cassette.Interactions[n]
+				return fmt.Errorf("jenkinstest: interaction %s %s was not used", i.Request.Method, i.Request.URL)
+			}
+		}
+		return nil
+	}
+	for _, i := range r.cassette.Interactions {
+		r.scrub(i)
+	}
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+// replacement of scrubbed values
+const scrubbed = "REDACTED"
+
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+var sensitiveParams = []string{"token", "password", "secret"}
+
+func isSensitiveHeader(name string) bool {
+	for _, h := range sensitiveHeaders {
+		if strings.EqualFold(h, name) {
+			return true
+		}
+	}
+	// name of crumb header is configurable in Jenkins, eg: Jenkins-Crumb
+	return strings.Contains(strings.ToLower(name), "crumb")
+}
+
+func isSensitiveParam(name string) bool {
+	for _, p := range sensitiveParams {
+		if strings.EqualFold(name, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// remember secret values which may appear in other places, eg: password in
+// Authorization header and crumb in response of crumbIssuer
+func (r *Recorder) collectSecrets(req *http.Request, resp *http.Response, body []byte) {
+	if user, password, ok := req.BasicAuth(); ok {
+		r.secrets = append(r.secrets, password)
+		if auth := req.Header.Get("Authorization"); auth != "" {
+			r.secrets = append(r.secrets, strings.TrimPrefix(auth, "Basic "),
+				base64.StdEncoding.EncodeToString([]byte(user+":"+password)))
+		}
+	}
+	for name, values := range req.Header {
+		if strings.Contains(strings.ToLower(name), "crumb") {
+			r.secrets = append(r.secrets, values...)
+		}
+	}
+	for name, values := range req.URL.Query() {
+		if isSensitiveParam(name) {
+			r.secrets = append(r.secrets, values...)
+		}
+	}
+	if strings.HasSuffix(req.URL.Path, "/crumbIssuer/api/json") {
+		var crumb struct {
+			Crumb string `json:"crumb"`
+		}
+		if json.Unmarshal(body, &crumb) == nil && crumb.Crumb != "" {
+			r.secrets = append(r.secrets, crumb.Crumb)
+		}
+	}
+	for _, c := range resp.Cookies() {
+		r.secrets = append(r.secrets, c.Value)
+	}
+}
+
+func (r *Recorder) scrub(i *Interaction) {
+	scrubHeader(i.Request.Header)
+	scrubHeader(i.Response.Header)
+	if u, err := url.Parse(i.Request.URL); err == nil {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), scrubbed)
+		}
+		query := u.Query()
+		for name := range query {
+			if isSensitiveParam(name) {
+				query.Set(name, scrubbed)
+			}
+		}
+		if len(query) > 0 {
+			u.RawQuery = query.Encode()
+		}
+		i.Request.URL = u.String()
+	}
+	i.Request.Body = r.scrubString(i.Request.Body)
+	i.Response.Body = r.scrubString(i.Response.Body)
+}
+
+func scrubHeader(h http.Header) {
+	for name := range h {
+		if isSensitiveHeader(name) {
+			h[name] = []string{scrubbed}
+		}
+	}
+}
+
+func (r *Recorder) scrubString(s string) string {
+	for _, secret := range r.secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, scrubbed)
+		}
+	}
+	return s
+}
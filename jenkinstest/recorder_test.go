@@ -0,0 +1,104 @@
+package jenkinstest
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newServer(t *testing.T) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/crumbIssuer/api/json":
+			http.SetCookie(w, &http.Cookie{Name: "JSESSIONID", Value: "session-1234"})
+			w.Write([]byte(`{"crumb":"crumb-5678","crumbRequestField":"Jenkins-Crumb"}`))
+		case "/job/pipeline/api/json":
+			w.Write([]byte(`{"description":"` + r.URL.Query().Get("tree") + `"}`))
+		case "/job/pipeline/config.xml":
+			body, _ := io.ReadAll(r.Body)
+			w.Write(body)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func send(t *testing.T, c *http.Client, method, url, body string) string {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	assert.Nil(t, err)
+	req.SetBasicAuth("admin", "secret-password")
+	req.Header.Set("Jenkins-Crumb", "crumb-5678")
+	resp, err := c.Do(req)
+	if !assert.Nil(t, err) {
+		return ""
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	return string(data)
+}
+
+func TestRecordReplay(t *testing.T) {
+	srv := newServer(t)
+	path := filepath.Join(t.TempDir(), "testdata", "cassette.json")
+	rec, err := NewRecorder(path, WithMode(ModeRecord))
+	assert.Nil(t, err)
+	c := rec.Client()
+	assert.Contains(t, send(t, c, "GET", srv.URL+"/crumbIssuer/api/json", ""), "crumb-5678")
+	assert.Equal(t, `{"description":"description"}`, send(t, c, "GET", srv.URL+"/job/pipeline/api/json?tree=description&depth=0", ""))
+	assert.Equal(t, "<xml>secret-password</xml>", send(t, c, "POST", srv.URL+"/job/pipeline/config.xml?token=abcd", "<xml>secret-password</xml>"))
+	assert.Nil(t, rec.Stop())
+
+	// secrets are scrubbed
+	data, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	for _, secret := range []string{"secret-password", "crumb-5678", "session-1234", "abcd", "YWRtaW46c2VjcmV0LXBhc3N3b3Jk"} {
+		assert.NotContains(t, string(data), secret)
+	}
+	assert.Contains(t, string(data), "REDACTED")
+
+	// replay without server, query order does not matter
+	srv.Close()
+	rec, err = NewRecorder(path)
+	assert.Nil(t, err)
+	c = rec.Client()
+	assert.Contains(t, send(t, c, "GET", srv.URL+"/crumbIssuer/api/json", ""), `"crumb":"REDACTED"`)
+	assert.Equal(t, `{"description":"description"}`, send(t, c, "GET", srv.URL+"/job/pipeline/api/json?depth=0&tree=description", ""))
+	assert.NotNil(t, rec.Stop())
+	assert.Equal(t, "<xml>REDACTED</xml>", send(t, c, "POST", srv.URL+"/job/pipeline/config.xml?token=other", ""))
+	assert.Nil(t, rec.Stop())
+
+	// every interaction is used once
+	req, _ := http.NewRequest("GET", srv.URL+"/crumbIssuer/api/json", nil)
+	_, err = c.Do(req)
+	assert.True(t, errors.Is(err, ErrNoInteraction))
+}
+
+func TestReplayMatchers(t *testing.T) {
+	srv := newServer(t)
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	rec, err := NewRecorder(path, WithMode(ModeRecord), WithTransport(http.DefaultTransport))
+	assert.Nil(t, err)
+	send(t, rec.Client(), "GET", srv.URL+"/job/pipeline/api/json?tree=name", "")
+	assert.Nil(t, rec.Stop())
+
+	rec, err = NewRecorder(path)
+	assert.Nil(t, err)
+	_, err = rec.Client().Get(srv.URL + "/job/pipeline/api/json?tree=url")
+	assert.ErrorIs(t, err, ErrNoInteraction)
+
+	rec, err = NewRecorder(path, WithMatchers(MatchMethod, MatchPath))
+	assert.Nil(t, err)
+	assert.Equal(t, `{"description":"name"}`, send(t, rec.Client(), "GET", srv.URL+"/job/pipeline/api/json?tree=url", ""))
+
+	_, err = NewRecorder(filepath.Join(t.TempDir(), "missing.json"))
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+}
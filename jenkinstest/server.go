@@ -0,0 +1,1349 @@
+package jenkinstest
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// version reported by X-Jenkins header of Server
+const DefaultVersion = "2.462.3"
+
+const (
+	crumbField = "Jenkins-Crumb"
+	// name of built-in node in url
+	builtInNode = "(built-in)"
+)
+
+// BuildScript scripts the outcome of a build triggered on Server.
+type BuildScript struct {
+	// result of build, SUCCESS if empty
+	Result string
+	// console output of build, "Finished: <Result>" is appended once build
+	// is completed
+	Log string
+	// keep build running until Server.Finish is called
+	Running bool
+	// times the queue item is polled before build starts, build starts
+	// immediately if 0
+	QueuePolls int
+}
+
+// BuildHandler returns script of build for job with full name and build
+// parameters.
+type BuildHandler func(job string, params url.Values) BuildScript
+
+// Server is an in-memory Jenkins which serves enough of the remote API for
+// go-jenkins to work against it: crumb issuer, folders and jobs with
+// config.xml, builds going through queue, progressive console, nodes, plugins,
+// credentials and views:
+//
+//	srv := jenkinstest.NewServer()
+//	defer srv.Close()
+//	srv.HandleBuild(func(job string, params url.Values) jenkinstest.BuildScript {
+//		return jenkinstest.BuildScript{Result: "FAILURE", Log: "boom\n"}
+//	})
+//	client, _ := jenkins.New(srv.URL, "admin", "1234")
+//
+// It is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+	mu          sync.Mutex
+	crumb       string
+	root        *item
+	queue       []*queueItem
+	nextQueueID int
+	nodes       []*node
+	plugins     []*plugin
+	handler     BuildHandler
+	quiet       bool
+}
+
+type plugin struct {
+	shortName string
+	version   string
+}
+
+type item struct {
+	name        string
+	class       string
+	config      string
+	description string
+	disabled    bool
+	parent      *item
+	children    []*item
+	views       []*view
+	credentials []*credential
+	builds      []*build
+	nextBuild   int
+}
+
+type build struct {
+	job         *item
+	number      int
+	queueID     int
+	params      url.Values
+	result      string
+	building    bool
+	log         string
+	description string
+	started     time.Time
+	finished    time.Time
+}
+
+type queueItem struct {
+	id        int
+	job       *item
+	params    url.Values
+	script    BuildScript
+	since     time.Time
+	build     *build
+	cancelled bool
+}
+
+type node struct {
+	name      string
+	executors int
+	offline   bool
+	reason    string
+	config    string
+}
+
+type view struct {
+	name        string
+	class       string
+	config      string
+	description string
+	jobs        []string
+}
+
+type credential struct {
+	id          string
+	class       string
+	config      string
+	description string
+}
+
+// Start a new server, caller should call Close when finished.
+func NewServer() *Server {
+	s := &Server{
+		crumb:       "jenkinstest-crumb",
+		nextQueueID: 1,
+		nodes:       []*node{{name: builtInNode, executors: 2}},
+	}
+	s.root = &item{class: "hudson.model.Hudson"}
+	s.root.views = []*view{{name: "all", class: "hudson.model.AllView"}}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Script builds triggered from now on, every build succeeds by default.
+func (s *Server) HandleBuild(h BuildHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handler = h
+}
+
+// Create job with config from Go side, it is the same as createItem.
+func (s *Server) CreateJob(fullName, config string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	dir, name := path.Split(strings.Trim(fullName, "/"))
+	parent := s.lookup(dir)
+	if parent == nil || !parent.isFolder() {
+		return fmt.Errorf("jenkinstest: no such folder %q", dir)
+	}
+	return s.createItem(parent, name, config)
+}
+
+// Append text to console output of running build.
+func (s *Server) AppendLog(job string, number int, text string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, err := s.runningBuild(job, number)
+	if err != nil {
+		return err
+	}
+	b.log += text
+	return nil
+}
+
+// Complete running build which is scripted with BuildScript.Running.
+func (s *Server) Finish(job string, number int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, err := s.runningBuild(job, number)
+	if err != nil {
+		return err
+	}
+	b.finish(b.result)
+	return nil
+}
+
+// Add agent with number of executors.
+func (s *Server) AddNode(name string, executors int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nodes = append(s.nodes, &node{name: name, executors: executors})
+}
+
+// Install plugin, it is listed by pluginManager.
+func (s *Server) InstallPlugin(shortName, version string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.plugins = append(s.plugins, &plugin{shortName: shortName, version: version})
+}
+
+func (s *Server) runningBuild(job string, number int) (*build, error) {
+	it := s.lookup(job)
+	if it == nil {
+		return nil, fmt.Errorf("jenkinstest: no such job %q", job)
+	}
+	b := it.build(strconv.Itoa(number))
+	if b == nil || !b.building {
+		return nil, fmt.Errorf("jenkinstest: %s #%d is not running", job, number)
+	}
+	return b, nil
+}
+
+// find item by full name
+func (s *Server) lookup(fullName string) *item {
+	it := s.root
+	for _, name := range strings.Split(strings.Trim(fullName, "/"), "/") {
+		if name == "" {
+			continue
+		}
+		if it = it.child(name); it == nil {
+			return nil
+		}
+	}
+	return it
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w.Header().Set("X-Jenkins", DefaultVersion)
+	if r.Method == "POST" && r.Header.Get(crumbField) != s.crumb {
+		httpError(w, http.StatusForbidden, "No valid crumb was included in the request")
+		return
+	}
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if segments[0] == "" {
+		segments = nil
+	}
+	switch {
+	case hasPrefix(segments, "crumbIssuer", "api", "json"):
+		http.SetCookie(w, &http.Cookie{Name: "JSESSIONID", Value: "jenkinstest", Path: "/"})
+		s.writeJSON(w, r, map[string]any{
+			"_class":            "hudson.security.csrf.DefaultCrumbIssuer",
+			"crumb":             s.crumb,
+			"crumbRequestField": crumbField,
+		})
+		return
+	case hasPrefix(segments, "queue"):
+		s.serveQueue(w, r, segments[1:])
+		return
+	case hasPrefix(segments, "computer"):
+		s.serveComputer(w, r, segments[1:])
+		return
+	case hasPrefix(segments, "pluginManager", "api", "json") && r.Method == "GET":
+		var plugins []any
+		for _, p := range s.plugins {
+			plugins = append(plugins, map[string]any{
+				"active":    true,
+				"enabled":   true,
+				"hasUpdate": false,
+				"longName":  p.shortName,
+				"shortName": p.shortName,
+				"version":   p.version,
+			})
+		}
+		s.writeJSON(w, r, map[string]any{
+			"_class":  "hudson.LocalPluginManager",
+			"plugins": orEmpty(plugins),
+		})
+		return
+	}
+	it := s.root
+	for len(segments) >= 2 && segments[0] == "job" {
+		if it = it.child(segments[1]); it == nil {
+			httpError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+		segments = segments[2:]
+	}
+	s.serveItem(w, r, it, segments)
+}
+
+func (s *Server) serveItem(w http.ResponseWriter, r *http.Request, it *item, segments []string) {
+	query := r.URL.Query()
+	action := strings.Join(segments, "/")
+	switch {
+	case action == "" && (r.Method == "GET" || r.Method == "HEAD"):
+		w.Header().Set("Content-Type", "text/html")
+	case action == "api/json" && r.Method == "GET":
+		s.writeJSON(w, r, s.itemDoc(it))
+	case action == "config.xml" && it != s.root:
+		if r.Method == "GET" {
+			w.Header().Set("Content-Type", "application/xml")
+			io.WriteString(w, it.config)
+			return
+		}
+		config, _ := io.ReadAll(r.Body)
+		if _, err := xmlRoot(string(config)); err != nil {
+			httpError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		it.config = string(config)
+		it.disabled = strings.Contains(it.config, "<disabled>true</disabled>")
+		it.description = xmlText(it.config, "description")
+	case action == "createItem" && r.Method == "POST" && it.isFolder():
+		s.serveCreateItem(w, r, it)
+	case action == "createView" && r.Method == "POST" && it.isFolder():
+		config, _ := io.ReadAll(r.Body)
+		name := query.Get("name")
+		if it.view(name) != nil {
+			httpError(w, http.StatusBadRequest, fmt.Sprintf("A view already exists with the name %q", name))
+			return
+		}
+		class, err := xmlRoot(string(config))
+		if err != nil {
+			httpError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		it.views = append(it.views, &view{
+			name:        name,
+			class:       class,
+			config:      string(config),
+			description: xmlText(string(config), "description"),
+		})
+	case hasPrefix(segments, "view") && len(segments) >= 2:
+		s.serveView(w, r, it, segments[1], strings.Join(segments[2:], "/"))
+	case hasPrefix(segments, "credentials", "store") && len(segments) >= 5:
+		s.serveCredentials(w, r, it, segments[5:])
+	case it == s.root:
+		s.serveRoot(w, r, action)
+	case r.Method != "POST":
+		s.serveBuildOrNotFound(w, r, it, segments)
+	case action == "disable" || action == "enable":
+		it.disabled = action == "disable"
+		redirect(w, s.itemURL(it))
+	case action == "submitDescription":
+		it.description = query.Get("description")
+		redirect(w, s.itemURL(it))
+	case action == "doDelete":
+		it.parent.children = slices.DeleteFunc(it.parent.children, func(c *item) bool { return c == it })
+		redirect(w, s.itemURL(it.parent))
+	case action == "confirmRename":
+		name := query.Get("newName")
+		if name == "" || it.parent.child(name) != nil {
+			httpError(w, http.StatusBadRequest, fmt.Sprintf("invalid name %q", name))
+			return
+		}
+		it.name = name
+		redirect(w, s.itemURL(it))
+	case action == "move/move":
+		dest := s.lookup(query.Get("destination"))
+		if dest == nil || !dest.isFolder() || dest.child(it.name) != nil {
+			httpError(w, http.StatusBadRequest, fmt.Sprintf("invalid destination %q", query.Get("destination")))
+			return
+		}
+		it.parent.children = slices.DeleteFunc(it.parent.children, func(c *item) bool { return c == it })
+		it.parent = dest
+		dest.children = append(dest.children, it)
+		redirect(w, s.itemURL(it))
+	case (action == "build" || action == "buildWithParameters") && !it.isFolder():
+		s.serveBuildJob(w, r, it)
+	case action == "nextbuildnumber/submit":
+		n, err := strconv.Atoi(query.Get("nextBuildNumber"))
+		if err != nil {
+			httpError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		it.nextBuild = n
+		redirect(w, s.itemURL(it))
+	case action == "polling":
+		redirect(w, s.itemURL(it))
+	case action == "indexing/consoleText" && strings.HasSuffix(it.class, "WorkflowMultiBranchProject"):
+		io.WriteString(w, "Started\nFinished: SUCCESS\n")
+	default:
+		s.serveBuildOrNotFound(w, r, it, segments)
+	}
+}
+
+func (s *Server) serveRoot(w http.ResponseWriter, r *http.Request, action string) {
+	switch {
+	case r.Method != "POST":
+		httpError(w, http.StatusNotFound, "Not Found")
+	case action == "quietDown":
+		s.quiet = true
+	case action == "cancelQuietDown":
+		s.quiet = false
+	case slices.Contains([]string{"restart", "safeRestart", "exit", "safeExit"}, action):
+	default:
+		httpError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (s *Server) serveCreateItem(w http.ResponseWriter, r *http.Request, parent *item) {
+	query := r.URL.Query()
+	name := query.Get("name")
+	config, _ := io.ReadAll(r.Body)
+	if query.Get("mode") == "copy" {
+		from := query.Get("from")
+		src := parent.child(from)
+		if strings.HasPrefix(from, "/") {
+			src = s.lookup(from)
+		}
+		if src == nil {
+			httpError(w, http.StatusBadRequest, fmt.Sprintf("No such job: %s", from))
+			return
+		}
+		config = []byte(src.config)
+	}
+	if err := s.createItem(parent, name, string(config)); err != nil {
+		httpError(w, http.StatusBadRequest, err.Error())
+	}
+}
+
+func (s *Server) createItem(parent *item, name, config string) error {
+	if name == "" || strings.ContainsAny(name, "/\\?*%!@#$^&|<>[]:;") {
+		return fmt.Errorf("invalid name %q", name)
+	}
+	if parent.child(name) != nil {
+		return fmt.Errorf("A job already exists with the name %q", name)
+	}
+	root, err := xmlRoot(config)
+	if err != nil {
+		return err
+	}
+	it := &item{
+		name:        name,
+		class:       itemClass(root),
+		config:      config,
+		description: xmlText(config, "description"),
+		disabled:    strings.Contains(config, "<disabled>true</disabled>"),
+		parent:      parent,
+		nextBuild:   1,
+	}
+	if it.isFolder() {
+		it.views = []*view{{name: "All", class: "hudson.model.AllView"}}
+	}
+	parent.children = append(parent.children, it)
+	return nil
+}
+
+func (s *Server) serveBuildJob(w http.ResponseWriter, r *http.Request, it *item) {
+	if it.disabled {
+		httpError(w, http.StatusConflict, fmt.Sprintf("%s is disabled", it.fullName()))
+		return
+	}
+	params := url.Values{}
+	for _, p := range it.parameters() {
+		params.Set(p.Name, p.Default)
+	}
+	for k, v := range r.URL.Query() {
+		if k != "token" && k != "delay" {
+			params[k] = v
+		}
+	}
+	script := BuildScript{}
+	if s.handler != nil {
+		script = s.handler(it.fullName(), params)
+	}
+	if script.Result == "" {
+		script.Result = "SUCCESS"
+	}
+	q := &queueItem{id: s.nextQueueID, job: it, params: params, script: script, since: time.Now()}
+	s.nextQueueID++
+	s.queue = append(s.queue, q)
+	if script.QueuePolls == 0 {
+		q.start()
+	}
+	w.Header().Set("Location", fmt.Sprintf("%s/queue/item/%d/", s.URL, q.id))
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (q *queueItem) start() {
+	it := q.job
+	b := &build{
+		job:      it,
+		number:   it.nextBuild,
+		queueID:  q.id,
+		params:   q.params,
+		result:   q.script.Result,
+		building: true,
+		log:      "Started by user admin\n" + q.script.Log,
+		started:  time.Now(),
+	}
+	it.nextBuild++
+	it.builds = append(it.builds, b)
+	q.build = b
+	if !q.script.Running {
+		b.finish(b.result)
+	}
+}
+
+func (b *build) finish(result string) {
+	b.building = false
+	b.result = result
+	b.finished = time.Now()
+	if !strings.HasSuffix(b.log, "\n") && b.log != "" {
+		b.log += "\n"
+	}
+	b.log += "Finished: " + result + "\n"
+}
+
+func (s *Server) serveBuildOrNotFound(w http.ResponseWriter, r *http.Request, it *item, segments []string) {
+	if len(segments) == 0 || it.isFolder() {
+		httpError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	b := it.build(segments[0])
+	if b == nil {
+		httpError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	action := strings.Join(segments[1:], "/")
+	switch {
+	case action == "api/json" && r.Method == "GET":
+		s.writeJSON(w, r, s.buildDoc(b))
+	case action == "consoleText" && r.Method == "GET":
+		w.Header().Set("Content-Type", "text/plain")
+		io.WriteString(w, b.log)
+	case (action == "logText/progressiveText" || action == "logText/progressiveHtml") && r.Method == "GET":
+		start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+		start = min(max(start, 0), len(b.log))
+		w.Header().Set("X-Text-Size", strconv.Itoa(len(b.log)))
+		if b.building {
+			w.Header().Set("X-More-Data", "true")
+		}
+		text := b.log[start:]
+		if strings.HasSuffix(action, "Html") {
+			text = html.EscapeString(text)
+		}
+		io.WriteString(w, text)
+	case r.Method != "POST":
+		httpError(w, http.StatusNotFound, "Not Found")
+	case action == "stop" || action == "term" || action == "kill":
+		if b.building {
+			b.log += "Aborted by admin\n"
+			b.finish("ABORTED")
+		}
+		redirect(w, s.buildURL(b))
+	case action == "doDelete":
+		it.builds = slices.DeleteFunc(it.builds, func(c *build) bool { return c == b })
+		redirect(w, s.itemURL(it))
+	case action == "submitDescription":
+		b.description = r.URL.Query().Get("description")
+		redirect(w, s.buildURL(b))
+	default:
+		httpError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (s *Server) serveQueue(w http.ResponseWriter, r *http.Request, segments []string) {
+	action := strings.Join(segments, "/")
+	switch {
+	case action == "api/json" && r.Method == "GET":
+		var items []any
+		for _, q := range s.queue {
+			if q.build == nil && !q.cancelled {
+				items = append(items, s.queueDoc(q))
+			}
+		}
+		s.writeJSON(w, r, map[string]any{
+			"_class":            "hudson.model.Queue",
+			"discoverableItems": []any{},
+			"items":             orEmpty(items),
+		})
+	case action == "cancelItem" && r.Method == "POST":
+		id, _ := strconv.Atoi(r.URL.Query().Get("id"))
+		if q := s.queueItem(id); q != nil && q.build == nil {
+			q.cancelled = true
+		}
+		redirect(w, s.URL+"/queue/")
+	case len(segments) == 4 && segments[0] == "item" && segments[2] == "api" && r.Method == "GET":
+		id, _ := strconv.Atoi(segments[1])
+		q := s.queueItem(id)
+		if q == nil {
+			httpError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+		if q.build == nil && !q.cancelled {
+			if q.script.QueuePolls > 0 {
+				q.script.QueuePolls--
+			} else {
+				q.start()
+			}
+		}
+		s.writeJSON(w, r, s.queueDoc(q))
+	default:
+		httpError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (s *Server) queueItem(id int) *queueItem {
+	for _, q := range s.queue {
+		if q.id == id {
+			return q
+		}
+	}
+	return nil
+}
+
+func (s *Server) serveComputer(w http.ResponseWriter, r *http.Request, segments []string) {
+	action := strings.Join(segments, "/")
+	if action == "api/json" && r.Method == "GET" {
+		var computers []any
+		busy, total := 0, 0
+		for _, n := range s.nodes {
+			doc := s.computerDoc(n)
+			computers = append(computers, doc)
+			total += n.executors
+			if n.name == builtInNode {
+				busy += len(s.runningBuilds())
+			}
+		}
+		s.writeJSON(w, r, map[string]any{
+			"_class":         "hudson.model.ComputerSet",
+			"busyExecutors":  busy,
+			"computer":       computers,
+			"displayName":    "Nodes",
+			"totalExecutors": total,
+		})
+		return
+	}
+	if action == "doCreateItem" && r.Method == "POST" {
+		name := r.URL.Query().Get("name")
+		if name == "" || slices.ContainsFunc(s.nodes, func(n *node) bool { return n.name == name }) {
+			httpError(w, http.StatusBadRequest, fmt.Sprintf("Agent called %q already exists", name))
+			return
+		}
+		s.nodes = append(s.nodes, &node{name: name, executors: 1})
+		return
+	}
+	idx := slices.IndexFunc(s.nodes, func(n *node) bool { return len(segments) >= 2 && n.name == segments[0] })
+	if idx >= 0 && action == segments[0]+"/config.xml" && segments[0] != builtInNode {
+		s.serveNodeConfig(w, r, s.nodes[idx])
+		return
+	}
+	if len(segments) < 2 || r.Method != "POST" {
+		httpError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	if idx < 0 {
+		httpError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	n := s.nodes[idx]
+	switch strings.Join(segments[1:], "/") {
+	case "toggleOffline":
+		n.offline = !n.offline
+		n.reason = ""
+		if n.offline {
+			n.reason = r.URL.Query().Get("offlineMessage")
+		}
+	case "doDelete":
+		if n.name == builtInNode {
+			httpError(w, http.StatusBadRequest, "built-in node can not be deleted")
+			return
+		}
+		s.nodes = slices.Delete(s.nodes, idx, idx+1)
+	default:
+		httpError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (s *Server) serveView(w http.ResponseWriter, r *http.Request, it *item, name, action string) {
+	v := it.view(name)
+	if v == nil {
+		httpError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	query := r.URL.Query()
+	switch {
+	case action == "api/json" && r.Method == "GET":
+		s.writeJSON(w, r, s.viewDoc(it, v))
+	case action == "config.xml" && r.Method == "GET":
+		w.Header().Set("Content-Type", "application/xml")
+		io.WriteString(w, v.config)
+	case r.Method != "POST":
+		httpError(w, http.StatusNotFound, "Not Found")
+	case action == "config.xml":
+		config, _ := io.ReadAll(r.Body)
+		v.config = string(config)
+		v.description = xmlText(v.config, "description")
+	case action == "doDelete":
+		it.views = slices.DeleteFunc(it.views, func(c *view) bool { return c == v })
+	case action == "addJobToView":
+		if !slices.Contains(v.jobs, query.Get("name")) {
+			v.jobs = append(v.jobs, query.Get("name"))
+		}
+	case action == "removeJobFromView":
+		v.jobs = slices.DeleteFunc(v.jobs, func(name string) bool { return name == query.Get("name") })
+	case action == "submitDescription":
+		v.description = query.Get("description")
+	default:
+		httpError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+// segments after credentials/store/<store>/domain/<domain>
+func (s *Server) serveCredentials(w http.ResponseWriter, r *http.Request, it *item, segments []string) {
+	action := strings.Join(segments, "/")
+	switch {
+	case action == "api/json" && r.Method == "GET":
+		var creds []any
+		for _, c := range it.credentials {
+			creds = append(creds, map[string]any{
+				"description": c.description,
+				"displayName": c.id,
+				"fullName":    "system/_/" + c.id,
+				"id":          c.id,
+				"typeName":    credentialType(c.class),
+			})
+		}
+		s.writeJSON(w, r, map[string]any{
+			"_class":          "com.cloudbees.plugins.credentials.CredentialsStoreAction$DomainWrapper",
+			"credentials":     orEmpty(creds),
+			"description":     "Credentials that should be available irrespective of domain specification to requirements matching.",
+			"displayName":     "Global credentials (unrestricted)",
+			"fullDisplayName": "System » Global credentials (unrestricted)",
+			"fullName":        "system/_",
+			"global":          true,
+			"urlName":         "_",
+		})
+	case action == "createCredentials" && r.Method == "POST":
+		config, _ := io.ReadAll(r.Body)
+		class, err := xmlRoot(string(config))
+		if err != nil {
+			httpError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		id := xmlText(string(config), "id")
+		if id == "" || it.credential(id) != nil {
+			httpError(w, http.StatusConflict, fmt.Sprintf("invalid credential id %q", id))
+			return
+		}
+		it.credentials = append(it.credentials, &credential{
+			id:          id,
+			class:       class,
+			config:      string(config),
+			description: xmlText(string(config), "description"),
+		})
+	case len(segments) == 3 && segments[0] == "credential":
+		c := it.credential(segments[1])
+		if c == nil {
+			httpError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+		switch {
+		case segments[2] == "config.xml" && r.Method == "GET":
+			w.Header().Set("Content-Type", "application/xml")
+			// secrets are never returned by jenkins
+			io.WriteString(w, regexp.MustCompile(`<password>[^<]*</password>`).ReplaceAllString(c.config, "<password>{AQAAABAAAAAQ}</password>"))
+		case segments[2] == "config.xml" && r.Method == "POST":
+			config, _ := io.ReadAll(r.Body)
+			c.config = string(config)
+			c.description = xmlText(c.config, "description")
+		case segments[2] == "doDelete" && r.Method == "POST":
+			it.credentials = slices.DeleteFunc(it.credentials, func(o *credential) bool { return o == c })
+		default:
+			httpError(w, http.StatusNotFound, "Not Found")
+		}
+	default:
+		httpError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, r *http.Request, doc map[string]any) {
+	var v any = doc
+	if tree := r.URL.Query().Get("tree"); tree != "" {
+		fields, err := parseTree(tree)
+		if err != nil {
+			httpError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		// round trip so nested documents are generic maps and slices
+		data, _ := json.Marshal(doc)
+		json.Unmarshal(data, &v)
+		v = filterTree(v, fields)
+	}
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+	json.NewEncoder(w).Encode(v)
+}
+
+func (s *Server) itemURL(it *item) string {
+	if it.parent == nil {
+		return s.URL + "/"
+	}
+	return s.itemURL(it.parent) + "job/" + url.PathEscape(it.name) + "/"
+}
+
+func (s *Server) buildURL(b *build) string {
+	return fmt.Sprintf("%s%d/", s.itemURL(b.job), b.number)
+}
+
+func (s *Server) itemDoc(it *item) map[string]any {
+	if it == s.root {
+		return map[string]any{
+			"_class":          it.class,
+			"jobs":            s.jobsDoc(it),
+			"mode":            "NORMAL",
+			"nodeDescription": "the Jenkins controller's built-in node",
+			"nodeName":        "",
+			"numExecutors":    s.nodes[0].executors,
+			"primaryView":     s.viewRef(it, it.views[0]),
+			"quietingDown":    s.quiet,
+			"url":             s.URL + "/",
+			"useCrumbs":       true,
+			"useSecurity":     true,
+			"views":           s.viewsDoc(it),
+		}
+	}
+	doc := map[string]any{
+		"_class":          it.class,
+		"name":            it.name,
+		"fullName":        it.fullName(),
+		"displayName":     it.name,
+		"fullDisplayName": strings.ReplaceAll(it.fullName(), "/", " » "),
+		"url":             s.itemURL(it),
+		"description":     it.description,
+	}
+	if it.isFolder() {
+		doc["jobs"] = s.jobsDoc(it)
+		doc["views"] = s.viewsDoc(it)
+		doc["primaryView"] = s.viewRef(it, it.views[0])
+		return doc
+	}
+	var builds []any
+	for i := len(it.builds) - 1; i >= 0; i-- {
+		builds = append(builds, s.buildDoc(it.builds[i]))
+	}
+	inQueue := false
+	for _, q := range s.queue {
+		inQueue = inQueue || (q.job == it && q.build == nil && !q.cancelled)
+	}
+	doc["allBuilds"] = orEmpty(builds)
+	doc["builds"] = orEmpty(builds[:min(len(builds), 100)])
+	doc["buildable"] = !it.disabled
+	doc["color"] = it.color()
+	doc["inQueue"] = inQueue
+	doc["nextBuildNumber"] = it.nextBuild
+	doc["concurrentBuild"] = false
+	doc["keepDependencies"] = false
+	for name, match := range lastBuilds {
+		doc[name] = nil
+		for i := len(it.builds) - 1; i >= 0; i-- {
+			if match(it.builds[i]) {
+				doc[name] = s.buildDoc(it.builds[i])
+				break
+			}
+		}
+	}
+	doc["firstBuild"] = nil
+	if len(it.builds) > 0 {
+		doc["firstBuild"] = s.buildDoc(it.builds[0])
+	}
+	property := []any{}
+	if params := it.parameters(); len(params) > 0 {
+		var defs []any
+		for _, p := range params {
+			defs = append(defs, map[string]any{
+				"_class":      p.Class,
+				"name":        p.Name,
+				"description": p.Description,
+				"type":        strings.TrimSuffix(p.Class[strings.LastIndex(p.Class, ".")+1:], "Definition"),
+				"choices":     p.Choices,
+				"defaultParameterValue": map[string]any{
+					"_class": strings.TrimSuffix(p.Class, "Definition") + "Value",
+					"name":   p.Name,
+					"value":  p.Default,
+				},
+			})
+		}
+		property = append(property, map[string]any{
+			"_class":               "hudson.model.ParametersDefinitionProperty",
+			"parameterDefinitions": defs,
+		})
+	}
+	doc["property"] = property
+	return doc
+}
+
+// permalinks of job
+var lastBuilds = map[string]func(b *build) bool{
+	"lastBuild":             func(b *build) bool { return true },
+	"lastCompletedBuild":    func(b *build) bool { return !b.building },
+	"lastFailedBuild":       func(b *build) bool { return !b.building && b.result == "FAILURE" },
+	"lastStableBuild":       func(b *build) bool { return !b.building && b.result == "SUCCESS" },
+	"lastSuccessfulBuild":   func(b *build) bool { return !b.building && (b.result == "SUCCESS" || b.result == "UNSTABLE") },
+	"lastUnstableBuild":     func(b *build) bool { return !b.building && b.result == "UNSTABLE" },
+	"lastUnsuccessfulBuild": func(b *build) bool { return !b.building && b.result != "SUCCESS" },
+}
+
+func (s *Server) jobsDoc(it *item) []any {
+	jobs := []any{}
+	for _, child := range it.children {
+		jobs = append(jobs, s.itemDoc(child))
+	}
+	return jobs
+}
+
+func (s *Server) viewRef(it *item, v *view) map[string]any {
+	return map[string]any{
+		"_class": v.class,
+		"name":   v.name,
+		"url":    s.viewURL(it, v),
+	}
+}
+
+func (s *Server) viewURL(it *item, v *view) string {
+	if v == it.views[0] {
+		return s.itemURL(it)
+	}
+	return s.itemURL(it) + "view/" + url.PathEscape(v.name) + "/"
+}
+
+func (s *Server) viewsDoc(it *item) []any {
+	var views []any
+	for _, v := range it.views {
+		views = append(views, s.viewDoc(it, v))
+	}
+	return views
+}
+
+func (s *Server) viewDoc(it *item, v *view) map[string]any {
+	jobs := []any{}
+	for _, child := range it.children {
+		if v.class == "hudson.model.AllView" || slices.Contains(v.jobs, child.name) {
+			jobs = append(jobs, map[string]any{
+				"_class": child.class,
+				"name":   child.name,
+				"url":    s.itemURL(child),
+				"color":  child.color(),
+			})
+		}
+	}
+	return map[string]any{
+		"_class":      v.class,
+		"name":        v.name,
+		"url":         s.viewURL(it, v),
+		"description": v.description,
+		"jobs":        jobs,
+		"property":    []any{},
+	}
+}
+
+func (s *Server) buildDoc(b *build) map[string]any {
+	var result any
+	duration := int64(0)
+	if !b.building {
+		result = b.result
+		duration = b.finished.Sub(b.started).Milliseconds()
+	}
+	var description any
+	if b.description != "" {
+		description = b.description
+	}
+	actions := []any{}
+	if len(b.params) > 0 {
+		var params []any
+		for name, values := range b.params {
+			params = append(params, map[string]any{
+				"_class": "hudson.model.StringParameterValue",
+				"name":   name,
+				"value":  values[0],
+			})
+		}
+		actions = append(actions, map[string]any{
+			"_class":     "hudson.model.ParametersAction",
+			"parameters": params,
+		})
+	}
+	return map[string]any{
+		"_class":            buildClass(b.job.class),
+		"actions":           actions,
+		"building":          b.building,
+		"description":       description,
+		"displayName":       fmt.Sprintf("#%d", b.number),
+		"duration":          duration,
+		"estimatedDuration": duration,
+		"fullDisplayName":   fmt.Sprintf("%s #%d", strings.ReplaceAll(b.job.fullName(), "/", " » "), b.number),
+		"id":                strconv.Itoa(b.number),
+		"keepLog":           false,
+		"number":            b.number,
+		"queueId":           b.queueID,
+		"result":            result,
+		"timestamp":         b.started.UnixMilli(),
+		"url":               s.buildURL(b),
+	}
+}
+
+func (s *Server) queueDoc(q *queueItem) map[string]any {
+	doc := map[string]any{
+		"_class":       "hudson.model.Queue$WaitingItem",
+		"actions":      []any{},
+		"blocked":      false,
+		"buildable":    false,
+		"id":           q.id,
+		"inQueueSince": q.since.UnixMilli(),
+		"params":       "",
+		"stuck":        false,
+		"task": map[string]any{
+			"_class": q.job.class,
+			"name":   q.job.name,
+			"url":    s.itemURL(q.job),
+			"color":  q.job.color(),
+		},
+		"url": fmt.Sprintf("%s/queue/item/%d/", s.URL, q.id),
+		"why": "In the quiet period.",
+	}
+	for name, values := range q.params {
+		doc["params"] = doc["params"].(string) + "\n" + name + "=" + values[0]
+	}
+	switch {
+	case q.cancelled:
+		doc["_class"] = "hudson.model.Queue$LeftItem"
+		doc["cancelled"] = true
+		doc["executable"] = nil
+		doc["why"] = nil
+	case q.build != nil:
+		doc["_class"] = "hudson.model.Queue$LeftItem"
+		doc["cancelled"] = false
+		doc["executable"] = map[string]any{
+			"_class": buildClass(q.job.class),
+			"number": q.build.number,
+			"url":    s.buildURL(q.build),
+		}
+		doc["why"] = nil
+	}
+	return doc
+}
+
+func (s *Server) runningBuilds() []*build {
+	var builds []*build
+	var walk func(it *item)
+	walk = func(it *item) {
+		for _, b := range it.builds {
+			if b.building {
+				builds = append(builds, b)
+			}
+		}
+		for _, child := range it.children {
+			walk(child)
+		}
+	}
+	walk(s.root)
+	return builds
+}
+
+func (s *Server) serveNodeConfig(w http.ResponseWriter, r *http.Request, n *node) {
+	if r.Method == "POST" {
+		config, _ := io.ReadAll(r.Body)
+		n.config = string(config)
+		if executors, err := strconv.Atoi(xmlText(n.config, "numExecutors")); err == nil {
+			n.executors = executors
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/xml")
+	if n.config == "" {
+		fmt.Fprintf(w, "<?xml version='1.1' encoding='UTF-8'?>\n<slave>\n  <name>%s</name>\n  <numExecutors>%d</numExecutors>\n</slave>", n.name, n.executors)
+		return
+	}
+	io.WriteString(w, n.config)
+}
+
+func (s *Server) computerDoc(n *node) map[string]any {
+	executors := []any{}
+	oneOff := []any{}
+	var running []*build
+	if n.name == builtInNode {
+		running = s.runningBuilds()
+	}
+	executable := func(b *build) map[string]any {
+		doc := s.buildDoc(b)
+		if strings.HasSuffix(b.job.class, "WorkflowJob") {
+			// pipeline runs on flyweight executor
+			doc["_class"] = "org.jenkinsci.plugins.workflow.job.WorkflowRun"
+		}
+		return map[string]any{"currentExecutable": doc, "idle": false, "likelyStuck": false, "number": -1, "progress": 50}
+	}
+	for i := 0; i < n.executors; i++ {
+		executors = append(executors, map[string]any{"currentExecutable": nil, "idle": true, "likelyStuck": false, "number": i, "progress": -1})
+	}
+	for _, b := range running {
+		oneOff = append(oneOff, executable(b))
+	}
+	class, display := "hudson.slaves.SlaveComputer", n.name
+	if n.name == builtInNode {
+		class, display = "hudson.model.Hudson$MasterComputer", "Built-In Node"
+	}
+	var cause any
+	if n.offline {
+		cause = map[string]any{"_class": "hudson.slaves.OfflineCause$UserCause", "description": n.reason}
+	}
+	return map[string]any{
+		"_class":              class,
+		"assignedLabels":      []any{map[string]any{"name": n.name}},
+		"description":         "",
+		"displayName":         display,
+		"executors":           executors,
+		"idle":                len(running) == 0,
+		"jnlpAgent":           n.name != builtInNode,
+		"launchSupported":     true,
+		"manualLaunchAllowed": true,
+		"monitorData":         map[string]any{},
+		"numExecutors":        n.executors,
+		"offline":             n.offline,
+		"offlineCause":        cause,
+		"offlineCauseReason":  n.reason,
+		"oneOffExecutors":     oneOff,
+		"temporarilyOffline":  n.offline,
+	}
+}
+
+func (it *item) child(name string) *item {
+	for _, c := range it.children {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func (it *item) view(name string) *view {
+	for _, v := range it.views {
+		if v.name == name {
+			return v
+		}
+	}
+	return nil
+}
+
+func (it *item) credential(id string) *credential {
+	for _, c := range it.credentials {
+		if c.id == id {
+			return c
+		}
+	}
+	return nil
+}
+
+// build by number or permalink, eg: lastBuild
+func (it *item) build(ref string) *build {
+	if match, ok := lastBuilds[ref]; ok {
+		for i := len(it.builds) - 1; i >= 0; i-- {
+			if match(it.builds[i]) {
+				return it.builds[i]
+			}
+		}
+		return nil
+	}
+	if ref == "firstBuild" && len(it.builds) > 0 {
+		return it.builds[0]
+	}
+	number, err := strconv.Atoi(ref)
+	if err != nil {
+		return nil
+	}
+	for _, b := range it.builds {
+		if b.number == number {
+			return b
+		}
+	}
+	return nil
+}
+
+func (it *item) fullName() string {
+	if it.parent == nil || it.parent.parent == nil {
+		return it.name
+	}
+	return it.parent.fullName() + "/" + it.name
+}
+
+func (it *item) isFolder() bool {
+	if it.parent == nil {
+		return true
+	}
+	for _, suffix := range []string{".Folder", ".WorkflowMultiBranchProject", ".OrganizationFolder"} {
+		if strings.HasSuffix(it.class, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (it *item) color() string {
+	if it.isFolder() {
+		return ""
+	}
+	if it.disabled {
+		return "disabled"
+	}
+	if len(it.builds) == 0 {
+		return "notbuilt"
+	}
+	last := it.builds[len(it.builds)-1]
+	color := map[string]string{"SUCCESS": "blue", "UNSTABLE": "yellow", "FAILURE": "red", "ABORTED": "aborted"}[last.result]
+	if color == "" {
+		color = "grey"
+	}
+	if last.building {
+		color += "_anime"
+	}
+	return color
+}
+
+type parameterDefinition struct {
+	Class       string
+	Name        string   `xml:"name"`
+	Description string   `xml:"description"`
+	Default     string   `xml:"defaultValue"`
+	Choices     []string `xml:"choices>a>string"`
+}
+
+// parameter definitions declared in config of job
+func (it *item) parameters() []*parameterDefinition {
+	d := xml.NewDecoder(strings.NewReader(xml10(it.config)))
+	var params []*parameterDefinition
+	inDefinitions := false
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return params
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local == "parameterDefinitions" {
+				inDefinitions = true
+				continue
+			}
+			if inDefinitions {
+				p := &parameterDefinition{Class: t.Name.Local}
+				if d.DecodeElement(p, &t) == nil {
+					if p.Class == "hudson.model.BooleanParameterDefinition" && p.Default == "" {
+						p.Default = "false"
+					}
+					params = append(params, p)
+				}
+			}
+		case xml.EndElement:
+			if t.Name.Local == "parameterDefinitions" {
+				inDefinitions = false
+			}
+		}
+	}
+}
+
+var xmlVersion = regexp.MustCompile(`^(\s*<\?xml[^>]*version=['"])1\.1(['"])`)
+
+// encoding/xml only supports xml 1.0, which is compatible with config of
+// jenkins declared as 1.1
+func xml10(s string) string {
+	return xmlVersion.ReplaceAllString(s, "${1}1.0${2}")
+}
+
+// name of root element of xml
+func xmlRoot(s string) (string, error) {
+	d := xml.NewDecoder(strings.NewReader(xml10(s)))
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return "", fmt.Errorf("invalid xml: %w", err)
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			return start.Name.Local, nil
+		}
+	}
+}
+
+// text of first element with name at any level of xml
+func xmlText(s, name string) string {
+	d := xml.NewDecoder(strings.NewReader(xml10(s)))
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return ""
+		}
+		if start, ok := tok.(xml.StartElement); ok && start.Name.Local == name {
+			var text string
+			d.DecodeElement(&text, &start)
+			return text
+		}
+	}
+}
+
+func itemClass(root string) string {
+	switch root {
+	case "flow-definition":
+		return "org.jenkinsci.plugins.workflow.job.WorkflowJob"
+	case "project":
+		return "hudson.model.FreeStyleProject"
+	case "matrix-project":
+		return "hudson.matrix.MatrixProject"
+	}
+	return root
+}
+
+func buildClass(jobClass string) string {
+	switch jobClass {
+	case "org.jenkinsci.plugins.workflow.job.WorkflowJob":
+		return "org.jenkinsci.plugins.workflow.job.WorkflowRun"
+	case "hudson.model.FreeStyleProject":
+		return "hudson.model.FreeStyleBuild"
+	case "hudson.matrix.MatrixProject":
+		return "hudson.matrix.MatrixBuild"
+	}
+	return "hudson.model.Run"
+}
+
+func credentialType(class string) string {
+	switch class[strings.LastIndex(class, ".")+1:] {
+	case "UsernamePasswordCredentialsImpl":
+		return "Username with password"
+	case "StringCredentialsImpl":
+		return "Secret text"
+	case "FileCredentialsImpl":
+		return "Secret file"
+	case "BasicSSHUserPrivateKey":
+		return "SSH Username with private key"
+	}
+	return class
+}
+
+func hasPrefix(segments []string, prefix ...string) bool {
+	return len(segments) >= len(prefix) && slices.Equal(segments[:len(prefix)], prefix)
+}
+
+func orEmpty(v []any) []any {
+	if v == nil {
+		return []any{}
+	}
+	return v
+}
+
+func redirect(w http.ResponseWriter, location string) {
+	w.Header().Set("Location", location)
+	w.WriteHeader(http.StatusFound)
+}
+
+func httpError(w http.ResponseWriter, code int, msg string) {
+	w.Header().Set("X-Error", msg)
+	http.Error(w, msg, code)
+}
@@ -0,0 +1,260 @@
+package jenkinstest_test
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/joelee2012/go-jenkins"
+	"github.com/joelee2012/go-jenkins/jenkinstest"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	folderConf = `<?xml version='1.1' encoding='UTF-8'?>
+<com.cloudbees.hudson.plugins.folder.Folder/>`
+	jobConf = `<?xml version='1.1' encoding='UTF-8'?>
+<flow-definition plugin="workflow-job">
+  <description>pipeline</description>
+  <properties>
+    <hudson.model.ParametersDefinitionProperty>
+      <parameterDefinitions>
+        <hudson.model.StringParameterDefinition>
+          <name>ARG1</name>
+          <defaultValue>default</defaultValue>
+        </hudson.model.StringParameterDefinition>
+      </parameterDefinitions>
+    </hudson.model.ParametersDefinitionProperty>
+  </properties>
+  <disabled>false</disabled>
+</flow-definition>`
+	credConf = `<com.cloudbees.plugins.credentials.impl.UsernamePasswordCredentialsImpl>
+<id>user-id</id>
+<username>user-name</username>
+<password>user-password</password>
+<description>user id for testing</description>
+</com.cloudbees.plugins.credentials.impl.UsernamePasswordCredentialsImpl>`
+	viewConf = `<?xml version="1.1" encoding="UTF-8"?>
+<hudson.model.ListView><description>test</description></hudson.model.ListView>`
+)
+
+func setup(t *testing.T) (*jenkinstest.Server, *jenkins.Jenkins) {
+	srv := jenkinstest.NewServer()
+	t.Cleanup(srv.Close)
+	client, err := jenkins.New(srv.URL, "admin", "1234")
+	assert.Nil(t, err)
+	for _, name := range []string{"folder", "folder/folder1"} {
+		_, err := client.CreateJob(name, strings.NewReader(folderConf))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, srv.CreateJob("folder/pipeline", jobConf))
+	return srv, client
+}
+
+func TestServerJobs(t *testing.T) {
+	srv, client := setup(t)
+	version, err := client.GetVersion()
+	assert.Nil(t, err)
+	assert.Equal(t, jenkinstest.DefaultVersion, version)
+
+	folder, err := client.GetJob("folder")
+	assert.Nil(t, err)
+	assert.Equal(t, "Folder", folder.Class)
+	jobs, err := folder.List(0)
+	assert.Nil(t, err)
+	assert.Len(t, jobs, 2)
+	jobs, err = client.ListJobs(1)
+	assert.Nil(t, err)
+	assert.Len(t, jobs, 3)
+
+	pipeline, err := client.GetJob("folder/pipeline")
+	assert.Nil(t, err)
+	assert.Equal(t, "WorkflowJob", pipeline.Class)
+	conf, err := pipeline.GetConfigure()
+	assert.Nil(t, err)
+	assert.Equal(t, jobConf, conf)
+	desc, err := pipeline.GetDescription()
+	assert.Nil(t, err)
+	assert.Equal(t, "pipeline", desc)
+	params, err := pipeline.GetParameters()
+	assert.Nil(t, err)
+	assert.Equal(t, "ARG1", params[0].Name)
+	assert.Equal(t, "default", params[0].DefaultParameterValue.Value)
+
+	_, err = pipeline.Disable()
+	assert.Nil(t, err)
+	buildable, err := pipeline.IsBuildable()
+	assert.Nil(t, err)
+	assert.False(t, buildable)
+	_, err = pipeline.Enable()
+	assert.Nil(t, err)
+
+	_, err = folder.Copy("pipeline", "copied")
+	assert.Nil(t, err)
+	copied, err := client.GetJob("folder/copied")
+	assert.Nil(t, err)
+	_, err = copied.Rename("renamed")
+	assert.Nil(t, err)
+	assert.Equal(t, "folder/renamed", copied.FullName)
+	_, err = copied.Move("folder/folder1")
+	assert.Nil(t, err)
+	assert.Equal(t, "folder/folder1/renamed", copied.FullName)
+	_, err = client.DeleteJob("folder/folder1/renamed")
+	assert.Nil(t, err)
+	_, err = client.GetJob("folder/folder1/renamed")
+	assert.ErrorIs(t, err, jenkins.ErrNotFound)
+
+	_, err = client.CreateJob("folder/pipeline", strings.NewReader(jobConf))
+	assert.NotNil(t, err)
+	assert.NotNil(t, srv.CreateJob("missing/pipeline", jobConf))
+
+	var walked []string
+	for job, err := range client.AllJobs(nil) {
+		assert.Nil(t, err)
+		walked = append(walked, job.FullName)
+	}
+	assert.Equal(t, []string{"folder", "folder/folder1", "folder/pipeline"}, walked)
+}
+
+func TestServerBuild(t *testing.T) {
+	srv, client := setup(t)
+	srv.HandleBuild(func(job string, params url.Values) jenkinstest.BuildScript {
+		assert.Equal(t, "folder/pipeline", job)
+		if params.Get("ARG1") == "fail" {
+			return jenkinstest.BuildScript{Result: "FAILURE", Log: "boom\n", QueuePolls: 1}
+		}
+		return jenkinstest.BuildScript{Log: "ARG1=" + params.Get("ARG1") + "\n"}
+	})
+
+	qitem, err := client.BuildJob("folder/pipeline", nil)
+	assert.Nil(t, err)
+	build, err := qitem.GetBuild()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, build.Number)
+	result, err := build.GetResult()
+	assert.Nil(t, err)
+	assert.Equal(t, "SUCCESS", result)
+	var output string
+	assert.Nil(t, build.LoopProgressiveLog("text", func(line string) error {
+		output += line
+		return nil
+	}))
+	assert.Equal(t, "Started by user admin\nARG1=default\nFinished: SUCCESS\n", output)
+
+	// build stays in queue for one poll
+	qitem, err = client.BuildJob("folder/pipeline", url.Values{"ARG1": {"fail"}})
+	assert.Nil(t, err)
+	build, err = qitem.GetBuild()
+	assert.Nil(t, err)
+	assert.Nil(t, build)
+	build, err = qitem.GetBuild()
+	assert.Nil(t, err)
+	result, err = build.GetResult()
+	assert.Nil(t, err)
+	assert.Equal(t, "FAILURE", result)
+
+	pipeline, err := client.GetJob("folder/pipeline")
+	assert.Nil(t, err)
+	last, err := pipeline.GetLastFailedBuild()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, last.Number)
+	history, err := pipeline.ListAllBuilds(&jenkins.HistoryOpts{PageSize: 1, Results: []string{"SUCCESS"}})
+	assert.Nil(t, err)
+	assert.Len(t, history, 1)
+	assert.Equal(t, 1, history[0].Number)
+}
+
+func TestServerRunningBuild(t *testing.T) {
+	srv, client := setup(t)
+	srv.HandleBuild(func(job string, params url.Values) jenkinstest.BuildScript {
+		return jenkinstest.BuildScript{Running: true}
+	})
+	qitem, err := client.BuildJob("folder/pipeline", nil)
+	assert.Nil(t, err)
+	build, err := qitem.GetBuild()
+	assert.Nil(t, err)
+	building, err := build.IsBuilding()
+	assert.Nil(t, err)
+	assert.True(t, building)
+
+	// running build is found on executors of nodes
+	builds, err := client.Nodes().GetBuilds()
+	assert.Nil(t, err)
+	assert.Len(t, builds, 1)
+	assert.Equal(t, build.URL, builds[0].URL)
+
+	assert.Nil(t, srv.AppendLog("folder/pipeline", 1, "step 1\n"))
+	assert.Nil(t, srv.Finish("folder/pipeline", 1))
+	assert.NotNil(t, srv.Finish("folder/pipeline", 1))
+	var output string
+	assert.Nil(t, build.LoopLog(func(line string) error {
+		output += line
+		return nil
+	}))
+	assert.Equal(t, "Started by user admin\nstep 1\nFinished: SUCCESS\n", output)
+
+	// abort
+	qitem, err = client.BuildJob("folder/pipeline", nil)
+	assert.Nil(t, err)
+	build, err = qitem.GetBuild()
+	assert.Nil(t, err)
+	_, err = build.Stop()
+	assert.Nil(t, err)
+	result, err := build.GetResult()
+	assert.Nil(t, err)
+	assert.Equal(t, "ABORTED", result)
+}
+
+func TestServerServices(t *testing.T) {
+	srv, client := setup(t)
+	srv.AddNode("agent", 1)
+	nodes, err := client.Nodes().List()
+	assert.Nil(t, err)
+	assert.Len(t, nodes, 2)
+	_, err = client.Nodes().Disable("Built-In Node", "maintenance")
+	assert.Nil(t, err)
+	node, err := client.Nodes().Get("Built-In Node")
+	assert.Nil(t, err)
+	assert.True(t, node.Offline)
+	assert.Equal(t, "maintenance", node.OfflineCauseReason)
+	_, err = client.Nodes().Delete("agent")
+	assert.Nil(t, err)
+
+	folder, err := client.GetJob("folder")
+	assert.Nil(t, err)
+	for _, cm := range []*jenkins.Credentials{client.Credentials(), folder.Credentials()} {
+		_, err = cm.Create(strings.NewReader(credConf))
+		assert.Nil(t, err)
+		cred, err := cm.Get("user-id")
+		assert.Nil(t, err)
+		assert.Equal(t, "Username with password", cred.TypeName)
+		conf, err := cm.GetConfigure("user-id")
+		assert.Nil(t, err)
+		assert.NotContains(t, conf, "user-password")
+		_, err = cm.Delete("user-id")
+		assert.Nil(t, err)
+		creds, err := cm.List()
+		assert.Nil(t, err)
+		assert.Len(t, creds, 0)
+	}
+
+	_, err = client.Views().Get("all")
+	assert.Nil(t, err)
+	_, err = folder.Views().Create("testview", strings.NewReader(viewConf))
+	assert.Nil(t, err)
+	v, err := folder.Views().Get("testview")
+	assert.Nil(t, err)
+	assert.Equal(t, "test", v.Description)
+	views, err := folder.Views().List()
+	assert.Nil(t, err)
+	assert.Len(t, views, 2)
+	_, err = folder.Views().Delete("testview")
+	assert.Nil(t, err)
+
+	// crumb is required
+	resp, err := http.Post(srv.URL+"/job/folder/doDelete", "", nil)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
@@ -0,0 +1,173 @@
+package jenkinstest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// field of tree query, eg: builds[number,url]{0,10}
+type treeField struct {
+	name     string
+	children []*treeField
+	// range of array, to is -1 if open
+	from, to int
+	ranged   bool
+}
+
+// parse tree query of api/json as jenkins does
+func parseTree(tree string) ([]*treeField, error) {
+	p := &treeParser{s: tree}
+	fields, err := p.list()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.s) {
+		return nil, fmt.Errorf("unexpected %q at %d of tree %q", p.s[p.pos], p.pos, tree)
+	}
+	return fields, nil
+}
+
+type treeParser struct {
+	s   string
+	pos int
+}
+
+func (p *treeParser) peek() byte {
+	if p.pos < len(p.s) {
+		return p.s[p.pos]
+	}
+	return 0
+}
+
+func (p *treeParser) list() ([]*treeField, error) {
+	var fields []*treeField
+	for {
+		f, err := p.field()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, f)
+		if p.peek() != ',' {
+			return fields, nil
+		}
+		p.pos++
+	}
+}
+
+func (p *treeParser) field() (*treeField, error) {
+	start := p.pos
+	for p.pos < len(p.s) && !strings.ContainsRune(",[]{}", rune(p.s[p.pos])) {
+		p.pos++
+	}
+	f := &treeField{name: strings.TrimSpace(p.s[start:p.pos]), to: -1}
+	if f.name == "" {
+		return nil, fmt.Errorf("empty field at %d of tree %q", start, p.s)
+	}
+	if p.peek() == '[' {
+		p.pos++
+		children, err := p.list()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ']' {
+			return nil, fmt.Errorf("missing ] in tree %q", p.s)
+		}
+		p.pos++
+		f.children = children
+	}
+	if p.peek() == '{' {
+		end := strings.IndexByte(p.s[p.pos:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("missing } in tree %q", p.s)
+		}
+		if err := f.parseRange(p.s[p.pos+1 : p.pos+end]); err != nil {
+			return nil, err
+		}
+		p.pos += end + 1
+	}
+	return f, nil
+}
+
+// {m,n}, {m,}, {,n} or {n} which is {n,n+1}
+func (f *treeField) parseRange(r string) error {
+	f.ranged = true
+	atoi := func(s string, def int) (int, error) {
+		if s = strings.TrimSpace(s); s == "" {
+			return def, nil
+		}
+		return strconv.Atoi(s)
+	}
+	from, to, found := strings.Cut(r, ",")
+	var err error
+	if f.from, err = atoi(from, 0); err != nil {
+		return fmt.Errorf("invalid range {%s}: %w", r, err)
+	}
+	if !found {
+		f.to = f.from + 1
+		return nil
+	}
+	if f.to, err = atoi(to, -1); err != nil {
+		return fmt.Errorf("invalid range {%s}: %w", r, err)
+	}
+	return nil
+}
+
+// keep only fields selected by tree, _class is always kept
+func filterTree(v any, fields []*treeField) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := map[string]any{}
+		if class, ok := v["_class"]; ok {
+			out["_class"] = class
+		}
+		for _, f := range fields {
+			value, ok := v[f.name]
+			if !ok {
+				continue
+			}
+			if list, ok := value.([]any); ok && f.ranged {
+				value = sliceRange(list, f.from, f.to)
+			}
+			if f.children != nil {
+				value = filterTree(value, f.children)
+			} else {
+				value = shallow(value)
+			}
+			out[f.name] = value
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, e := range v {
+			out[i] = filterTree(e, fields)
+		}
+		return out
+	}
+	return v
+}
+
+func sliceRange(list []any, from, to int) []any {
+	if to < 0 || to > len(list) {
+		to = len(list)
+	}
+	if from > to {
+		from = to
+	}
+	return list[from:to]
+}
+
+// leaf object selected without children only carries its class
+func shallow(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		return filterTree(v, nil)
+	case []any:
+		out := make([]any, len(v))
+		for i, e := range v {
+			out[i] = shallow(e)
+		}
+		return out
+	}
+	return v
+}
@@ -1,7 +1,9 @@
 package jenkins
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -9,10 +11,13 @@ import (
 	"path"
 	"slices"
 	"strings"
+	"sync"
 )
 
 type Job struct {
 	*Item
+	// guards lazily created services
+	mu              sync.Mutex
 	credentials     *Credentials
 	views           *Views
 	Name            string
@@ -20,6 +25,23 @@ type Job struct {
 	FullDisplayName string
 }
 
+// fields of folder fetched by Get and List
+type jobTree struct {
+	Class string     `json:"_class"`
+	Name  string     `json:"name"`
+	URL   string     `json:"url"`
+	Jobs  []*jobTree `json:"jobs"`
+}
+
+// fields of job fetched by ListBuilds
+type buildList struct {
+	Builds []struct {
+		Class  string `json:"_class"`
+		Number int    `json:"number"`
+		URL    string `json:"url"`
+	} `json:"builds"`
+}
+
 func NewJob(url, class string, jenkins *Jenkins) *Job {
 	j := &Job{Item: NewItem(url, class, jenkins)}
 	j.setName()
@@ -27,6 +49,8 @@ func NewJob(url, class string, jenkins *Jenkins) *Job {
 }
 
 func (j *Job) Views() *Views {
+	j.mu.Lock()
+	defer j.mu.Unlock()
 	if j.views == nil {
 		j.views = &Views{Item: NewItem(j.URL, "Views", j.jenkins)}
 	}
@@ -34,84 +58,199 @@ func (j *Job) Views() *Views {
 }
 
 func (j *Job) Credentials() *Credentials {
+	j.mu.Lock()
+	defer j.mu.Unlock()
 	if j.credentials == nil {
 		j.credentials = &Credentials{Item: NewItem(j.URL+"credentials/store/folder/domain/_/", "Credentials", j.jenkins)}
 	}
 	return j.credentials
 }
 
-func (j *Job) Rename(name string) (newUrl *url.URL, err error) {
-	v := url.Values{}
-	v.Add("newName", name)
-	resp, err := j.Request("POST", "confirmRename?"+v.Encode(), nil)
+// Rename job and update j to the new location. It modifies j, so it is not
+// safe to call while j is used by other goroutines.
+//
+// Deprecated: use RenameTo, which leaves j untouched.
+func (j *Job) Rename(name string) (*url.URL, error) {
+	return j.RenameCtx(context.Background(), name)
+}
+
+func (j *Job) RenameCtx(ctx context.Context, name string) (*url.URL, error) {
+	job, err := j.RenameToCtx(ctx, name)
 	if err != nil {
 		return nil, err
 	}
+	j.relocated(job)
+	return url.Parse(j.URL)
+}
 
-	defer func() {
-		j.URL = appendSlash(newUrl.String())
-		j.setName()
-	}()
+// Move job to folder and update j to the new location. It modifies j, so it
+// is not safe to call while j is used by other goroutines.
+//
+// Deprecated: use MoveTo, which leaves j untouched.
+func (j *Job) Move(path string) (*url.URL, error) {
+	return j.MoveCtx(context.Background(), path)
+}
+
+func (j *Job) MoveCtx(ctx context.Context, path string) (*url.URL, error) {
+	job, err := j.MoveToCtx(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	j.relocated(job)
+	return url.Parse(j.URL)
+}
 
-	return resp.Location()
+// RenameTo renames job in its folder and returns the job at the new
+// location, j is left untouched. It fails with ErrAlreadyExists if name is
+// in use.
+func (j *Job) RenameTo(name string) (*Job, error) {
+	return j.RenameToCtx(context.Background(), name)
 }
 
-func (j *Job) Move(path string) (newUrl *url.URL, err error) {
+func (j *Job) RenameToCtx(ctx context.Context, name string) (*Job, error) {
+	if name == "" || strings.Contains(name, "/") {
+		return nil, fmt.Errorf("invalid job name %q", name)
+	}
+	dir, _ := path.Split(j.FullName)
 	v := url.Values{}
-	v.Add("destination", "/"+strings.Trim(path, "/"))
-	resp, err := j.Request("POST", "move/move?"+v.Encode(), nil)
+	v.Add("newName", name)
+	return j.relocate(ctx, dir, name, "confirmRename?"+v.Encode())
+}
+
+// MoveTo moves job into folder, "" or "/" for top level, and returns the job
+// at the new location, j is left untouched. It fails with
+// ErrNotAFolder if folder is not a folder and ErrAlreadyExists if it
+// contains job with the same name.
+func (j *Job) MoveTo(folder string) (*Job, error) {
+	return j.MoveToCtx(context.Background(), folder)
+}
+
+func (j *Job) MoveToCtx(ctx context.Context, folder string) (*Job, error) {
+	v := url.Values{}
+	v.Add("destination", "/"+strings.Trim(folder, "/"))
+	return j.relocate(ctx, folder, j.Name, "move/move?"+v.Encode())
+}
+
+// post entry which relocates j to folder/name, location is computed instead
+// of taken from redirect so it works with any redirect policy of client.
+// Job is read from the new location to get its url and class, the computed
+// one is returned if the read fails since the job is relocated already
+func (j *Job) relocate(ctx context.Context, folder, name, entry string) (*Job, error) {
+	folder = strings.Trim(folder, "/")
+	parent := NewJob(j.jenkins.URL, "Folder", j.jenkins)
+	if folder != "" {
+		var err error
+		if parent, err = j.jenkins.GetJobCtx(ctx, folder); err != nil {
+			return nil, err
+		}
+		if !isFolder(parent.Class) {
+			return nil, newError(ErrNotAFolder, "%s is not a folder", parent)
+		}
+	}
+	if _, err := parent.GetCtx(ctx, name); err == nil {
+		return nil, newError(ErrAlreadyExists, "job [%s] already exists", path.Join(folder, name))
+	} else if !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	resp, err := j.RequestCtx(ctx, "POST", entry, nil)
 	if err != nil {
-		return
+		return nil, err
 	}
+	resp.Body.Close()
+	// listing of destination folder changed too
+	j.jenkins.cache.invalidateItem(parent.URL)
+	job := NewJob(parent.URL+"job/"+url.PathEscape(name)+"/", j.Class, j.jenkins)
+	info, err := ApiJSONCtx[struct {
+		Class string `json:"_class"`
+		URL   string `json:"url"`
+	}](ctx, job)
+	if err != nil {
+		return job, nil
+	}
+	return NewJob(info.URL, info.Class, j.jenkins), nil
+}
 
-	defer func() {
-		j.URL = appendSlash(newUrl.String())
-		j.setName()
-	}()
-
-	return resp.Location()
+// update j to location of job, services of old location are dropped
+// only lazily created services are guarded, readers of URL are not
+func (j *Job) relocated(job *Job) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.URL = job.URL
+	j.Class = job.Class
+	j.setName()
+	j.views = nil
+	j.credentials = nil
 }
 
 func (j *Job) Copy(src, dest string) (*http.Response, error) {
+	return j.CopyCtx(context.Background(), src, dest)
+}
+
+func (j *Job) CopyCtx(ctx context.Context, src, dest string) (*http.Response, error) {
 	v := url.Values{}
 	v.Add("name", dest)
 	v.Add("mode", "copy")
 	v.Add("from", src)
-	return j.Request("POST", "createItem?"+v.Encode(), nil)
+	return j.RequestCtx(ctx, "POST", "createItem?"+v.Encode(), nil)
 }
 
 func (j *Job) GetParent() (*Job, error) {
+	return j.GetParentCtx(context.Background())
+}
+
+func (j *Job) GetParentCtx(ctx context.Context) (*Job, error) {
 	fullName, _ := j.jenkins.URL2Name(j.URL)
 	dir, _ := path.Split(strings.Trim(fullName, "/"))
 	if dir == "" {
-		return nil, fmt.Errorf("%s have no parent", j)
+		return nil, newError(ErrNotFound, "%s have no parent", j)
 	}
-	return j.jenkins.GetJob(dir)
+	return j.jenkins.GetJobCtx(ctx, dir)
 }
 
 func (j *Job) GetConfigure() (string, error) {
-	return readResponseToString(j, "GET", "config.xml", nil)
+	return j.GetConfigureCtx(context.Background())
+}
+
+func (j *Job) GetConfigureCtx(ctx context.Context) (string, error) {
+	return readResponseToString(ctx, j, "GET", "config.xml", nil)
 }
 
 func (j *Job) SetConfigure(xml io.Reader) (*http.Response, error) {
-	return j.Request("POST", "config.xml", xml)
+	return j.SetConfigureCtx(context.Background(), xml)
+}
+
+func (j *Job) SetConfigureCtx(ctx context.Context, xml io.Reader) (*http.Response, error) {
+	return j.RequestCtx(ctx, "POST", "config.xml", xml)
 }
 
 func (j *Job) Disable() (*http.Response, error) {
-	return j.Request("POST", "disable", nil)
+	return j.DisableCtx(context.Background())
+}
+
+func (j *Job) DisableCtx(ctx context.Context) (*http.Response, error) {
+	return j.RequestCtx(ctx, "POST", "disable", nil)
 }
 
 func (j *Job) Enable() (*http.Response, error) {
-	return j.Request("POST", "enable", nil)
+	return j.EnableCtx(context.Background())
+}
+
+func (j *Job) EnableCtx(ctx context.Context) (*http.Response, error) {
+	return j.RequestCtx(ctx, "POST", "enable", nil)
 }
 
 func (j *Job) IsBuildable() (bool, error) {
-	var job struct {
-		Class     string `json:"_class"`
-		Buildable bool   `json:"buildable"`
+	return j.IsBuildableCtx(context.Background())
+}
+
+func (j *Job) IsBuildableCtx(ctx context.Context) (bool, error) {
+	job, err := ApiJSONCtx[struct {
+		Buildable bool `json:"buildable"`
+	}](ctx, j)
+	if err != nil {
+		return false, err
 	}
-	err := j.ApiJson(&job, &ApiJsonOpts{Tree: "buildable"})
-	return job.Buildable, err
+	return job.Buildable, nil
 }
 
 func (j *Job) setName() {
@@ -122,20 +261,32 @@ func (j *Job) setName() {
 }
 
 func (j *Job) GetDescription() (string, error) {
+	return j.GetDescriptionCtx(context.Background())
+}
+
+func (j *Job) GetDescriptionCtx(ctx context.Context) (string, error) {
 	data := make(map[string]string)
-	if err := j.ApiJson(&data, &ApiJsonOpts{Tree: "description"}); err != nil {
+	if err := j.ApiJsonCtx(ctx, &data, &ApiJsonOpts{Tree: "description"}); err != nil {
 		return "", err
 	}
 	return data["description"], nil
 }
 
 func (j *Job) SetDescription(description string) (*http.Response, error) {
+	return j.SetDescriptionCtx(context.Background(), description)
+}
+
+func (j *Job) SetDescriptionCtx(ctx context.Context, description string) (*http.Response, error) {
 	v := url.Values{}
 	v.Add("description", description)
-	return j.Request("POST", "submitDescription?"+v.Encode(), nil)
+	return j.RequestCtx(ctx, "POST", "submitDescription?"+v.Encode(), nil)
 }
 
 func (j *Job) Build(param url.Values) (*OneQueueItem, error) {
+	return j.BuildCtx(context.Background(), param)
+}
+
+func (j *Job) BuildCtx(ctx context.Context, param url.Values) (*OneQueueItem, error) {
 	entry := func() string {
 		reserved := []string{"token", "delay"}
 		for k := range param {
@@ -146,10 +297,11 @@ func (j *Job) Build(param url.Values) (*OneQueueItem, error) {
 		return "build"
 	}()
 
-	resp, err := j.Request("POST", entry+"?"+param.Encode(), nil)
+	resp, err := j.RequestCtx(ctx, "POST", entry+"?"+param.Encode(), nil)
 	if err != nil {
 		return nil, err
 	}
+	resp.Body.Close()
 	url, err := resp.Location()
 	if err != nil {
 		return nil, err
@@ -158,28 +310,34 @@ func (j *Job) Build(param url.Values) (*OneQueueItem, error) {
 }
 
 func (j *Job) GetBuild(number int) (*Build, error) {
-	if j.Class == "Folder" || j.Class == "WorkflowMultiBranchProject" {
-		return nil, fmt.Errorf("%s have no builds", j)
+	return j.GetBuildCtx(context.Background(), number)
+}
+
+func (j *Job) GetBuildCtx(ctx context.Context, number int) (*Build, error) {
+	if isFolder(j.Class) {
+		return nil, newError(ErrNoBuilds, "%s have no builds", j)
 	}
-	jobJson := &JobJson{}
-	if err := j.ApiJson(&jobJson, &ApiJsonOpts{Tree: "builds[number,url]"}); err != nil {
-		return nil, err
+	item := NewItem(fmt.Sprintf("%s%d/", j.URL, number), "Build", j.jenkins)
+	build, err := ApiJSONCtx[buildSummary](ctx, item)
+	if errors.Is(err, ErrNotFound) {
+		return nil, newError(ErrNotFound, "%s have no builds #%d", j, number)
 	}
-
-	for _, build := range jobJson.Builds {
-		if number == build.Number {
-			return NewBuild(build.URL, build.Class, j.jenkins), nil
-		}
+	if err != nil {
+		return nil, err
 	}
-	return nil, fmt.Errorf("%s have no builds #%d", j, number)
+	return NewBuild(build.URL, build.Class, j.jenkins), nil
 }
 
 func (j *Job) Get(name string) (*Job, error) {
-	if j.Class != "Folder" && j.Class != "WorkflowMultiBranchProject" {
-		return nil, fmt.Errorf("%s have no jobs", j)
+	return j.GetCtx(context.Background(), name)
+}
+
+func (j *Job) GetCtx(ctx context.Context, name string) (*Job, error) {
+	if !isFolder(j.Class) {
+		return nil, newError(ErrNotAFolder, "%s have no jobs", j)
 	}
-	var folderJson JobJson
-	if err := j.ApiJson(&folderJson, &ApiJsonOpts{Tree: "jobs[url,name]"}); err != nil {
+	folderJson, err := ApiJSONCtx[jobTree](ctx, j)
+	if err != nil {
 		return nil, err
 	}
 	for _, job := range folderJson.Jobs {
@@ -187,32 +345,34 @@ func (j *Job) Get(name string) (*Job, error) {
 			return NewJob(job.URL, job.Class, j.jenkins), nil
 		}
 	}
-	return nil, fmt.Errorf("no such job [%s%s]", j.URL, name)
+	return nil, newError(ErrNotFound, "no such job [%s%s]", j.URL, name)
 }
 
 func (j *Job) Create(name string, xml io.Reader) (*http.Response, error) {
+	return j.CreateCtx(context.Background(), name, xml)
+}
+
+func (j *Job) CreateCtx(ctx context.Context, name string, xml io.Reader) (*http.Response, error) {
 	v := url.Values{}
 	v.Add("name", name)
-	return j.Request("POST", "createItem?"+v.Encode(), xml)
+	return j.RequestCtx(ctx, "POST", "createItem?"+v.Encode(), xml)
 }
 
 func (j *Job) List(depth int) ([]*Job, error) {
-	if j.Class != "Folder" && j.Class != "WorkflowMultiBranchProject" {
-		return nil, fmt.Errorf("%s have no jobs", j)
-	}
-	query := "jobs[url]"
-	qf := "jobs[url,%s]"
-	for i := 0; i < depth; i++ {
-		query = fmt.Sprintf(qf, query)
-	}
-	var folderJson JobJson
+	return j.ListCtx(context.Background(), depth)
+}
 
-	if err := j.ApiJson(&folderJson, &ApiJsonOpts{Tree: query}); err != nil {
+func (j *Job) ListCtx(ctx context.Context, depth int) ([]*Job, error) {
+	if !isFolder(j.Class) {
+		return nil, newError(ErrNotAFolder, "%s have no jobs", j)
+	}
+	folderJson, err := ApiJSONCtx[jobTree](ctx, j, WithTreeDepth(depth))
+	if err != nil {
 		return nil, err
 	}
 	var jobs []*Job
-	var _resolve func(item *JobJson)
-	_resolve = func(item *JobJson) {
+	var _resolve func(item *jobTree)
+	_resolve = func(item *jobTree) {
 		for _, job := range item.Jobs {
 			if len(job.Jobs) > 0 {
 				_resolve(job)
@@ -220,7 +380,7 @@ func (j *Job) List(depth int) ([]*Job, error) {
 			jobs = append(jobs, NewJob(job.URL, job.Class, j.jenkins))
 		}
 	}
-	_resolve(&folderJson)
+	_resolve(folderJson)
 	return jobs, nil
 }
 
@@ -249,12 +409,41 @@ func (j *Job) GetLastUnsucessfulBuild() (*Build, error) {
 	return j.GetBuildByName("lastUnsuccessfulBuild")
 }
 
+func (j *Job) GetFirstBuildCtx(ctx context.Context) (*Build, error) {
+	return j.GetBuildByNameCtx(ctx, "firstBuild")
+}
+func (j *Job) GetLastBuildCtx(ctx context.Context) (*Build, error) {
+	return j.GetBuildByNameCtx(ctx, "lastBuild")
+}
+func (j *Job) GetLastCompleteBuildCtx(ctx context.Context) (*Build, error) {
+	return j.GetBuildByNameCtx(ctx, "lastCompletedBuild")
+}
+func (j *Job) GetLastFailedBuildCtx(ctx context.Context) (*Build, error) {
+	return j.GetBuildByNameCtx(ctx, "lastFailedBuild")
+}
+func (j *Job) GetLastStableBuildCtx(ctx context.Context) (*Build, error) {
+	return j.GetBuildByNameCtx(ctx, "lastStableBuild")
+}
+func (j *Job) GetLastUnstableBuildCtx(ctx context.Context) (*Build, error) {
+	return j.GetBuildByNameCtx(ctx, "lastUnstableBuild")
+}
+func (j *Job) GetLastSuccessfulBuildCtx(ctx context.Context) (*Build, error) {
+	return j.GetBuildByNameCtx(ctx, "lastSuccessfulBuild")
+}
+func (j *Job) GetLastUnsucessfulBuildCtx(ctx context.Context) (*Build, error) {
+	return j.GetBuildByNameCtx(ctx, "lastUnsuccessfulBuild")
+}
+
 func (j *Job) GetBuildByName(name string) (*Build, error) {
-	if j.Class == "Folder" || j.Class == "WorkflowMultiBranchProject" {
-		return nil, fmt.Errorf("%s have no builds", j)
+	return j.GetBuildByNameCtx(context.Background(), name)
+}
+
+func (j *Job) GetBuildByNameCtx(ctx context.Context, name string) (*Build, error) {
+	if isFolder(j.Class) {
+		return nil, newError(ErrNoBuilds, "%s have no builds", j)
 	}
 	var jobJson map[string]json.RawMessage
-	if err := j.ApiJson(&jobJson, &ApiJsonOpts{Tree: name + "[url]"}); err != nil {
+	if err := j.ApiJsonCtx(ctx, &jobJson, &ApiJsonOpts{Tree: name + "[url]"}); err != nil {
 		return nil, err
 	}
 	if string(jobJson[name]) == "null" {
@@ -269,18 +458,28 @@ func (j *Job) GetBuildByName(name string) (*Build, error) {
 }
 
 func (j *Job) Delete() (*http.Response, error) {
-	return j.Request("POST", "doDelete", nil)
+	return j.DeleteCtx(context.Background())
 }
 
+func (j *Job) DeleteCtx(ctx context.Context) (*http.Response, error) {
+	return j.RequestCtx(ctx, "POST", "doDelete", nil)
+}
+
+// List builds of job, jenkins returns at most the newest 100 builds, use
+// History to walk the full build history.
 func (j *Job) ListBuilds() ([]*Build, error) {
-	if j.Class == "Folder" || j.Class == "WorkflowMultiBranchProject" {
-		return nil, fmt.Errorf("%s have no builds", j)
+	return j.ListBuildsCtx(context.Background())
+}
+
+func (j *Job) ListBuildsCtx(ctx context.Context) ([]*Build, error) {
+	if isFolder(j.Class) {
+		return nil, newError(ErrNoBuilds, "%s have no builds", j)
 	}
-	var jobJson JobJson
-	var builds []*Build
-	if err := j.ApiJson(&jobJson, &ApiJsonOpts{Tree: "builds[url]"}); err != nil {
+	jobJson, err := ApiJSONCtx[buildList](ctx, j)
+	if err != nil {
 		return nil, err
 	}
+	var builds []*Build
 
 	for _, build := range jobJson.Builds {
 		builds = append(builds, NewBuild(build.URL, build.Class, j.jenkins))
@@ -289,12 +488,20 @@ func (j *Job) ListBuilds() ([]*Build, error) {
 }
 
 func (j *Job) SetNextBuildNumber(number int) (*http.Response, error) {
-	return j.Request("POST", fmt.Sprintf("nextbuildnumber/submit?nextBuildNumber=%d", number), nil)
+	return j.SetNextBuildNumberCtx(context.Background(), number)
+}
+
+func (j *Job) SetNextBuildNumberCtx(ctx context.Context, number int) (*http.Response, error) {
+	return j.RequestCtx(ctx, "POST", fmt.Sprintf("nextbuildnumber/submit?nextBuildNumber=%d", number), nil)
 }
 
 func (j *Job) GetParameters() ([]*ParameterDefinition, error) {
+	return j.GetParametersCtx(context.Background())
+}
+
+func (j *Job) GetParametersCtx(ctx context.Context) ([]*ParameterDefinition, error) {
 	jobJson := &JobJson{}
-	if err := j.ApiJson(jobJson, nil); err != nil {
+	if err := j.ApiJsonCtx(ctx, jobJson, nil); err != nil {
 		return nil, err
 	}
 	for _, p := range jobJson.Property {
@@ -302,16 +509,24 @@ func (j *Job) GetParameters() ([]*ParameterDefinition, error) {
 			return p.ParameterDefinitions, nil
 		}
 	}
-	return nil, fmt.Errorf("%s has no parameters", j)
+	return nil, newError(ErrNotFound, "%s has no parameters", j)
 }
 
 func (j *Job) SCMPolling() (*http.Response, error) {
-	return j.Request("POST", "polling", nil)
+	return j.SCMPollingCtx(context.Background())
+}
+
+func (j *Job) SCMPollingCtx(ctx context.Context) (*http.Response, error) {
+	return j.RequestCtx(ctx, "POST", "polling", nil)
 }
 
 func (j *Job) GetMultibranchPipelineScanLog() (string, error) {
+	return j.GetMultibranchPipelineScanLogCtx(context.Background())
+}
+
+func (j *Job) GetMultibranchPipelineScanLogCtx(ctx context.Context) (string, error) {
 	if j.Class != "WorkflowMultiBranchProject" {
 		return "", fmt.Errorf("%s is not a WorkflowMultiBranchProject", j)
 	}
-	return readResponseToString(j, "POST", "indexing/consoleText", nil)
+	return readResponseToString(ctx, j, "POST", "indexing/consoleText", nil)
 }
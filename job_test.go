@@ -1,11 +1,13 @@
 package jenkins
 
 import (
-	"os"
+	"net/http"
 	"strings"
 	"testing"
 
+	"github.com/joelee2012/go-jenkins/jenkinstest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestName(t *testing.T) {
@@ -36,6 +38,84 @@ func TestRename(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestRelocateCtx(t *testing.T) {
+	srv := jenkinstest.NewServer()
+	defer srv.Close()
+	require.NoError(t, srv.CreateJob("folder", folderConf))
+	require.NoError(t, srv.CreateJob("folder/folder1", folderConf))
+	require.NoError(t, srv.CreateJob("folder/pipeline", jobConf))
+	require.NoError(t, srv.CreateJob("folder/pipeline2", jobConf))
+	require.NoError(t, srv.CreateJob("folder/folder1/pipeline2", jobConf))
+	require.NoError(t, srv.CreateJob("pipeline2", jobConf))
+	client, err := New(srv.URL, "admin", "1234")
+	require.NoError(t, err)
+	job, err := client.GetJob("folder/pipeline")
+	require.NoError(t, err)
+	oldURL := job.URL
+
+	// name in use, not a folder and missing folder leave job untouched
+	_, err = job.RenameTo("pipeline2")
+	assert.ErrorIs(t, err, ErrAlreadyExists)
+	_, err = job.MoveTo("folder/pipeline2")
+	assert.ErrorIs(t, err, ErrNotAFolder)
+	_, err = job.MoveTo("missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = job.RenameTo("a/b")
+	assert.Error(t, err)
+	assert.Equal(t, oldURL, job.URL)
+
+	renamed, err := job.RenameTo("pipeline1")
+	require.NoError(t, err)
+	assert.Equal(t, oldURL, job.URL)
+	assert.Equal(t, "folder/pipeline1", renamed.FullName)
+	assert.Equal(t, "WorkflowJob", renamed.Class)
+
+	// client following redirects
+	client.SetClient(&http.Client{})
+	moved, err := renamed.MoveTo("/folder/folder1/")
+	require.NoError(t, err)
+	assert.Equal(t, "folder/folder1/pipeline1", moved.FullName)
+	_, err = moved.MoveTo("folder/folder1")
+	assert.ErrorIs(t, err, ErrAlreadyExists)
+
+	// legacy methods update job in place and drop its services
+	views := moved.Views()
+	u, err := moved.Move("")
+	require.NoError(t, err)
+	assert.Equal(t, srv.URL+"/job/pipeline1/", u.String())
+	assert.Equal(t, "pipeline1", moved.FullName)
+	assert.NotSame(t, views, moved.Views())
+	_, err = moved.Rename("pipeline2")
+	assert.ErrorIs(t, err, ErrAlreadyExists)
+	assert.Equal(t, "pipeline1", moved.Name)
+}
+
+func TestRelocateUnconfirmed(t *testing.T) {
+	srv := jenkinstest.NewServer()
+	defer srv.Close()
+	require.NoError(t, srv.CreateJob("pipeline", jobConf))
+	// job is not readable at its new location yet
+	next := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/job/renamed/") {
+			http.Error(w, "unavailable", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+	client, err := New(srv.URL, "admin", "1234")
+	require.NoError(t, err)
+	job, err := client.GetJob("pipeline")
+	require.NoError(t, err)
+	renamed, err := job.RenameTo("renamed")
+	require.NoError(t, err)
+	assert.Equal(t, srv.URL+"/job/renamed/", renamed.URL)
+	assert.Equal(t, job.Class, renamed.Class)
+	exists, err := client.JobExists("pipeline")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
 func TestIsBuildable(t *testing.T) {
 	buildable, err := pipeline.IsBuildable()
 	assert.Nil(t, err)
@@ -86,7 +166,7 @@ func TestGetParent(t *testing.T) {
 func TestGetConfig(t *testing.T) {
 	conf, err := pipeline.GetConfigure()
 	assert.Nil(t, err)
-	assert.Contains(t, conf, os.Getenv("JENKINS_VERSION"))
+	assert.Contains(t, conf, jenkinsVersion)
 }
 
 func TestListBuilds(t *testing.T) {
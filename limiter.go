@@ -0,0 +1,244 @@
+package jenkins
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// LimitOpts configures client side limiting of requests, zero value of a
+// field means unlimited. Every attempt of a retried request is limited,
+// responses served by cache are not.
+type LimitOpts struct {
+	// requests per second and burst of reads, i.e. GET, HEAD and OPTIONS
+	ReadRate  float64
+	ReadBurst int
+	// requests per second and burst of other methods, e.g. POST
+	WriteRate  float64
+	WriteBurst int
+	// max number of requests waiting for response, a request leaves once
+	// its response header is received
+	MaxInFlight int
+}
+
+// Limit rate and concurrency of requests, see LimitOpts. Requests are held
+// until Retry-After of any 429 or 503 response has passed, waiting stops
+// when context of request is done:
+//
+//	jenkins.NewWithOptions(url, jenkins.WithLimit(jenkins.LimitOpts{
+//		ReadRate:    20,
+//		WriteRate:   2,
+//		MaxInFlight: 8,
+//	}))
+func WithLimit(opts LimitOpts) Option {
+	return func(o *JenkinsOpts) error {
+		o.Limit = &opts
+		return nil
+	}
+}
+
+type LimitStats struct {
+	InFlight int
+	// requests waiting for budget, an in-flight slot or end of pause
+	Waiting int
+	// tokens left in budgets, they are 0 if budget is unlimited
+	ReadTokens  float64
+	WriteTokens float64
+	// requests are held until then because of Retry-After, zero if never
+	PausedUntil time.Time
+	// requests which had to wait and total time they waited
+	Delayed  uint64
+	WaitTime time.Duration
+	// 429 and 503 responses received
+	Throttled uint64
+}
+
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if rate <= 0 {
+		return nil
+	}
+	b := &tokenBucket{rate: rate, burst: float64(max(burst, 1))}
+	b.tokens = b.burst
+	b.last = time.Now()
+	return b
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(b.burst, b.tokens+elapsed.Seconds()*b.rate)
+		b.last = now
+	}
+}
+
+// take one token, it returns time to wait before trying again if there is
+// none
+func (b *tokenBucket) take(now time.Time) time.Duration {
+	b.refill(now)
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+type limiter struct {
+	// guards all fields but slots
+	mu          sync.Mutex
+	read        *tokenBucket
+	write       *tokenBucket
+	slots       chan struct{}
+	pausedUntil time.Time
+	stats       LimitStats
+}
+
+func newLimiter(opts LimitOpts) *limiter {
+	l := &limiter{
+		read:  newTokenBucket(opts.ReadRate, opts.ReadBurst),
+		write: newTokenBucket(opts.WriteRate, opts.WriteBurst),
+	}
+	if opts.MaxInFlight > 0 {
+		l.slots = make(chan struct{}, opts.MaxInFlight)
+	}
+	return l
+}
+
+// Stats of limiter, it is zero if limiting is not enabled by WithLimit.
+func (c *Jenkins) LimitStats() LimitStats {
+	l := c.limiter
+	if l == nil {
+		return LimitStats{}
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	stats := l.stats
+	if l.read != nil {
+		l.read.refill(now)
+		stats.ReadTokens = l.read.tokens
+	}
+	if l.write != nil {
+		l.write.refill(now)
+		stats.WriteTokens = l.write.tokens
+	}
+	stats.PausedUntil = l.pausedUntil
+	return stats
+}
+
+func (l *limiter) interceptor(next Doer) Doer {
+	if l == nil {
+		return next
+	}
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+		if err := l.acquire(req.Context(), isIdempotent(req.Method)); err != nil {
+			return nil, err
+		}
+		resp, err := next.Do(req)
+		l.release()
+		if err == nil {
+			l.observe(resp)
+		}
+		return resp, err
+	})
+}
+
+func (l *limiter) acquire(ctx context.Context, read bool) error {
+	bucket := l.write
+	if read {
+		bucket = l.read
+	}
+	var waited time.Duration
+	defer func() {
+		if waited > 0 {
+			l.mu.Lock()
+			l.stats.Delayed++
+			l.stats.WaitTime += waited
+			l.mu.Unlock()
+		}
+	}()
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		wait := l.pausedUntil.Sub(now)
+		if wait <= 0 && bucket != nil {
+			wait = bucket.take(now)
+		}
+		l.mu.Unlock()
+		if wait <= 0 {
+			break
+		}
+		start := time.Now()
+		err := l.wait(func() error { return sleepCtx(ctx, wait) })
+		waited += time.Since(start)
+		if err != nil {
+			return err
+		}
+	}
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		default:
+			start := time.Now()
+			err := l.wait(func() error {
+				select {
+				case l.slots <- struct{}{}:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			})
+			waited += time.Since(start)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	l.mu.Lock()
+	l.stats.InFlight++
+	l.mu.Unlock()
+	return nil
+}
+
+// run f, which blocks, as a waiting request
+func (l *limiter) wait(f func() error) error {
+	l.mu.Lock()
+	l.stats.Waiting++
+	l.mu.Unlock()
+	defer func() {
+		l.mu.Lock()
+		l.stats.Waiting--
+		l.mu.Unlock()
+	}()
+	return f()
+}
+
+func (l *limiter) release() {
+	l.mu.Lock()
+	l.stats.InFlight--
+	l.mu.Unlock()
+	if l.slots != nil {
+		<-l.slots
+	}
+}
+
+// pause all requests until Retry-After of throttled response
+func (l *limiter) observe(resp *http.Response) {
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.stats.Throttled++
+	if after, ok := parseRetryAfter(resp); ok {
+		if until := time.Now().Add(after); until.After(l.pausedUntil) {
+			l.pausedUntil = until
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package jenkins
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitInFlight(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	release := make(chan struct{})
+	j := newFakeJenkins(t, func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		<-release
+	})
+	j.limiter = newLimiter(LimitOpts{MaxInFlight: 2})
+	_, err := j.GetCrumb()
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := j.Request("GET", "job/a/api/json", nil)
+			if assert.NoError(t, err) {
+				resp.Body.Close()
+			}
+		}()
+	}
+	assert.Eventually(t, func() bool {
+		stats := j.LimitStats()
+		return stats.InFlight == 2 && stats.Waiting == 3
+	}, time.Second, 5*time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(2), peak.Load())
+	stats := j.LimitStats()
+	assert.Equal(t, 0, stats.InFlight)
+	assert.Equal(t, 0, stats.Waiting)
+	assert.Equal(t, uint64(3), stats.Delayed)
+}
+
+func TestLimitRate(t *testing.T) {
+	j := newFakeJenkins(t, func(w http.ResponseWriter, r *http.Request) {})
+	j.limiter = newLimiter(LimitOpts{WriteRate: 10, WriteBurst: 1})
+	_, err := j.GetCrumb()
+	require.NoError(t, err)
+
+	start := time.Now()
+	for range 4 {
+		_, err := j.Request("POST", "job/a/build", nil)
+		require.NoError(t, err)
+	}
+	// first one uses the burst, the others wait 100ms each
+	assert.GreaterOrEqual(t, time.Since(start), 250*time.Millisecond)
+	assert.Equal(t, uint64(3), j.LimitStats().Delayed)
+
+	// reads have their own budget, which is unlimited
+	start = time.Now()
+	for range 4 {
+		_, err := j.Request("GET", "job/a/api/json", nil)
+		require.NoError(t, err)
+	}
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+
+	// waiting stops when context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = j.RequestCtx(ctx, "POST", "job/a/build", nil)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestLimitRetryAfter(t *testing.T) {
+	var throttled atomic.Bool
+	j := newFakeJenkins(t, func(w http.ResponseWriter, r *http.Request) {
+		if throttled.CompareAndSwap(false, true) {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	})
+	j.limiter = newLimiter(LimitOpts{})
+	_, err := j.Request("GET", "job/a/api/json", nil)
+	assert.Error(t, err)
+	stats := j.LimitStats()
+	assert.Equal(t, uint64(1), stats.Throttled)
+	assert.WithinDuration(t, time.Now().Add(time.Second), stats.PausedUntil, 200*time.Millisecond)
+
+	start := time.Now()
+	_, err = j.Request("GET", "job/a/api/json", nil)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 700*time.Millisecond)
+}
+
+func TestLimitStats(t *testing.T) {
+	j := newFakeJenkins(t, func(w http.ResponseWriter, r *http.Request) {})
+	assert.Equal(t, LimitStats{}, j.LimitStats())
+
+	j, err := NewWithOptions(j.URL, WithLimit(LimitOpts{ReadRate: 1, ReadBurst: 3}))
+	require.NoError(t, err)
+	stats := j.LimitStats()
+	assert.InDelta(t, 3, stats.ReadTokens, 0.01)
+	assert.Zero(t, stats.WriteTokens)
+}
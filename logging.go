@@ -0,0 +1,196 @@
+package jenkins
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const redacted = "REDACTED"
+
+// headers which carry credentials or session
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// query parameters which carry credentials, eg: build token
+var sensitiveParams = []string{"token", "password", "secret"}
+
+// values of body which carry secrets: crumb issued by crumbIssuer and
+// secrets encrypted by jenkins, eg: password in config.xml of credential.
+// Preview may end in the middle of value.
+var sensitiveBody = []*regexp.Regexp{
+	regexp.MustCompile(`("crumb"\s*:\s*")[^"]*`),
+	regexp.MustCompile(`()\{AQAA[A-Za-z0-9+/=]*\}?`),
+}
+
+// Log requests and responses to logger, see also WithLogLevel and
+// WithLogBodyPreview. Credentials, crumb and session cookie are redacted.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *JenkinsOpts) error {
+		o.Logger = logger
+		return nil
+	}
+}
+
+// Log requests and responses at level, and failed ones at errorLevel. The
+// default levels are slog.LevelDebug and slog.LevelWarn.
+func WithLogLevel(level, errorLevel slog.Level) Option {
+	return func(o *JenkinsOpts) error {
+		o.LogLevel = level
+		o.LogErrorLevel = errorLevel
+		return nil
+	}
+}
+
+// Log at most n bytes of response body, it is disabled by default. Body of
+// credentials is not logged, crumb and encrypted secrets are redacted.
+func WithLogBodyPreview(n int) Option {
+	return func(o *JenkinsOpts) error {
+		o.LogBodyPreview = n
+		return nil
+	}
+}
+
+type requestIDKey struct{}
+
+// Use id as correlation id of requests made with ctx instead of a random one
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+func requestID(ctx context.Context) string {
+	if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+		return id
+	}
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+type requestLogger struct {
+	logger      *slog.Logger
+	level       slog.Level
+	errorLevel  slog.Level
+	bodyPreview int
+}
+
+// used by Jenkins.Debug if there is no logger
+var debugLogger = &requestLogger{
+	logger:     slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	level:      slog.LevelDebug,
+	errorLevel: slog.LevelWarn,
+}
+
+func (c *Jenkins) requestLogger() *requestLogger {
+	if c.log == nil && c.Debug {
+		return debugLogger
+	}
+	return c.log
+}
+
+// log every attempt of request, all attempts of one request share one
+// correlation id
+func (l *requestLogger) interceptor(next Doer) Doer {
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+		ctx := req.Context()
+		attrs := []slog.Attr{
+			slog.String("id", requestID(ctx)),
+			slog.String("method", req.Method),
+			slog.String("url", redactURL(req.URL)),
+			slog.Int("attempt", attemptFromContext(ctx)),
+		}
+		if l.logger.Enabled(ctx, l.level) {
+			l.logger.LogAttrs(ctx, l.level, "jenkins request", append(attrs, headerAttr("header", req.Header))...)
+		}
+		start := time.Now()
+		resp, err := next.Do(req)
+		attrs = append(attrs, slog.Duration("duration", time.Since(start)))
+		if err != nil {
+			l.logger.LogAttrs(ctx, l.errorLevel, "jenkins request failed", append(attrs, slog.String("error", err.Error()))...)
+			return resp, err
+		}
+		level := l.level
+		if resp.StatusCode >= 400 {
+			level = l.errorLevel
+		}
+		if !l.logger.Enabled(ctx, level) {
+			return resp, err
+		}
+		attrs = append(attrs, slog.Int("status", resp.StatusCode), headerAttr("header", resp.Header))
+		if l.bodyPreview > 0 {
+			attrs = append(attrs, slog.String("body", previewBody(req, resp, l.bodyPreview)))
+		}
+		l.logger.LogAttrs(ctx, level, "jenkins response", attrs...)
+		return resp, err
+	})
+}
+
+func previewBody(req *http.Request, resp *http.Response, n int) string {
+	if strings.Contains(req.URL.Path, "/credentials/") {
+		return redacted
+	}
+	body := string(peekBody(resp, n))
+	for _, re := range sensitiveBody {
+		body = re.ReplaceAllString(body, "${1}"+redacted)
+	}
+	return body
+}
+
+// read first n bytes of body and put them back
+func peekBody(resp *http.Response, n int) []byte {
+	prefix, _ := io.ReadAll(io.LimitReader(resp.Body, int64(n)))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(prefix), resp.Body), resp.Body}
+	return prefix
+}
+
+func headerAttr(key string, header http.Header) slog.Attr {
+	attrs := make([]any, 0, len(header))
+	for name, values := range header {
+		value := strings.Join(values, ", ")
+		if isSensitiveHeader(name) {
+			value = redacted
+		}
+		attrs = append(attrs, slog.String(name, value))
+	}
+	return slog.Group(key, attrs...)
+}
+
+func isSensitiveHeader(name string) bool {
+	for _, h := range sensitiveHeaders {
+		if strings.EqualFold(h, name) {
+			return true
+		}
+	}
+	// name of crumb header is configurable in Jenkins, eg: Jenkins-Crumb
+	return strings.Contains(strings.ToLower(name), "crumb")
+}
+
+func redactURL(u *url.URL) string {
+	query := u.Query()
+	changed := false
+	for name := range query {
+		for _, p := range sensitiveParams {
+			if strings.EqualFold(name, p) {
+				query.Set(name, redacted)
+				changed = true
+			}
+		}
+	}
+	if !changed {
+		return u.Redacted()
+	}
+	copied := *u
+	copied.RawQuery = query.Encode()
+	return copied.Redacted()
+}
@@ -0,0 +1,107 @@
+package jenkins
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogger(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/crumbIssuer/api/json":
+			w.Header().Set("Set-Cookie", "JSESSIONID=secret-session")
+			fmt.Fprint(w, `{"crumbRequestField":"Jenkins-Crumb","crumb":"secret-crumb"}`)
+		case "/job/pipeline/api/json":
+			fmt.Fprint(w, `{"description":"a long description of pipeline"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	buf := new(bytes.Buffer)
+	logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	j, err := NewWithOptions(srv.URL, WithBasicAuth("admin", "secret-password"), WithLogger(logger), WithLogBodyPreview(16))
+	assert.Nil(t, err)
+
+	ctx := ContextWithRequestID(context.Background(), "request-1")
+	job := NewJob(j.URL+"job/pipeline/", "WorkflowJob", j)
+	description, err := job.GetDescriptionCtx(ctx)
+	assert.Nil(t, err)
+	// body is still readable after preview
+	assert.Equal(t, "a long description of pipeline", description)
+	_, err = j.RequestCtx(ctx, "POST", "job/pipeline/build?token=secret-token", nil)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	output := buf.String()
+	for _, secret := range []string{"secret-password", "secret-crumb", "secret-session", "secret-token"} {
+		assert.NotContains(t, output, secret)
+	}
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		record := map[string]any{}
+		assert.Nil(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	// crumb request, its response, then 2 requests with their responses
+	assert.Len(t, records, 6)
+	last := records[len(records)-1]
+	assert.Equal(t, "WARN", last["level"])
+	assert.Equal(t, "jenkins response", last["msg"])
+	assert.Equal(t, float64(http.StatusNotFound), last["status"])
+	assert.Contains(t, last, "duration")
+	preview := records[3]
+	assert.Equal(t, "request-1", preview["id"])
+	assert.Equal(t, `{"description":"`, preview["body"])
+	request := records[2]
+	assert.Equal(t, "DEBUG", request["level"])
+	assert.Equal(t, redacted, request["header"].(map[string]any)["Authorization"])
+	assert.Equal(t, redacted, request["header"].(map[string]any)["Jenkins-Crumb"])
+	assert.Equal(t, redacted, request["header"].(map[string]any)["Cookie"])
+}
+
+func TestLoggerLevel(t *testing.T) {
+	j := newFakeJenkins(t, func(w http.ResponseWriter, r *http.Request) {})
+	buf := new(bytes.Buffer)
+	j.log = &requestLogger{logger: slog.New(slog.NewTextHandler(buf, nil)), level: slog.LevelDebug, errorLevel: slog.LevelError}
+	_, err := j.GetVersion()
+	assert.Nil(t, err)
+	// default level of handler is info
+	assert.Empty(t, buf.String())
+}
+
+func TestLoggerBodyPreview(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/crumbIssuer/api/json":
+			fmt.Fprint(w, `{"crumbRequestField":"Jenkins-Crumb","crumb":"secret-crumb"}`)
+		case "/job/folder/config.xml":
+			fmt.Fprint(w, `<project><password>{AQAAABAAAAAQsecret}</password></project>`)
+		case "/job/folder/credentials/store/folder/domain/_/credential/id/config.xml":
+			fmt.Fprint(w, `<com.cloudbees.plugins.credentials.impl.UsernamePasswordCredentialsImpl><username>secret-user</username>`)
+		}
+	}))
+	defer srv.Close()
+	buf := new(bytes.Buffer)
+	logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	j, err := NewWithOptions(srv.URL, WithLogger(logger), WithLogBodyPreview(1024))
+	assert.Nil(t, err)
+	for _, entry := range []string{"job/folder/config.xml", "job/folder/credentials/store/folder/domain/_/credential/id/config.xml"} {
+		_, err = j.Request("GET", entry, nil)
+		assert.Nil(t, err)
+	}
+	output := buf.String()
+	for _, secret := range []string{"secret-crumb", "secret-user", "AQAAABAAAAAQsecret"} {
+		assert.NotContains(t, output, secret)
+	}
+	assert.Contains(t, output, `"body":"{\"crumbRequestField\":\"Jenkins-Crumb\",\"crumb\":\"REDACTED\"}"`)
+	assert.Contains(t, output, `"body":"<project><password>REDACTED</password></project>"`)
+}
@@ -0,0 +1,204 @@
+package jenkins
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// upper bounds in seconds of latency histogram buckets
+var DefaultLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// RequestMetric counts requests with same labels and records their latency
+type RequestMetric struct {
+	Method string
+	// path with names and numbers replaced by placeholders, eg:
+	// /job/{job}/{number}/consoleText
+	Endpoint string
+	// class of status code, eg: 2xx, or error if no response is received
+	Status string
+	Host   string
+	Count  uint64
+	// total latency in seconds
+	Sum float64
+	// cumulative count of requests for each of MetricsSnapshot.Buckets
+	Buckets []uint64
+}
+
+type MetricsSnapshot struct {
+	Buckets  []float64
+	Requests []RequestMetric
+}
+
+type metricKey struct {
+	method, endpoint, status, host string
+}
+
+type metrics struct {
+	mu      sync.Mutex
+	base    string
+	buckets []float64
+	series  map[metricKey]*RequestMetric
+}
+
+func newMetrics(baseURL string) *metrics {
+	base := "/"
+	if u, err := url.Parse(baseURL); err == nil {
+		base = appendSlash(u.Path)
+	}
+	return &metrics{base: base, buckets: DefaultLatencyBuckets, series: map[metricKey]*RequestMetric{}}
+}
+
+func (m *metrics) observe(req *http.Request, resp *http.Response, err error, latency time.Duration) {
+	status := "error"
+	if err == nil {
+		status = fmt.Sprintf("%dxx", resp.StatusCode/100)
+	}
+	key := metricKey{
+		method:   req.Method,
+		endpoint: normalizeEndpoint(m.base, req.URL.Path),
+		status:   status,
+		host:     req.URL.Host,
+	}
+	seconds := latency.Seconds()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.series[key]
+	if !ok {
+		s = &RequestMetric{Method: key.method, Endpoint: key.endpoint, Status: key.status, Host: key.host, Buckets: make([]uint64, len(m.buckets))}
+		m.series[key] = s
+	}
+	s.Count++
+	s.Sum += seconds
+	for i, bound := range m.buckets {
+		if seconds <= bound {
+			s.Buckets[i]++
+		}
+	}
+}
+
+// record every request which is sent to server
+func (m *metrics) interceptor(next Doer) Doer {
+	if m == nil {
+		return next
+	}
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+		start := time.Now()
+		resp, err := next.Do(req)
+		m.observe(req, resp, err, time.Since(start))
+		return resp, err
+	})
+}
+
+func (m *metrics) snapshot() *MetricsSnapshot {
+	if m == nil {
+		return &MetricsSnapshot{Buckets: DefaultLatencyBuckets}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	snapshot := &MetricsSnapshot{Buckets: slices.Clone(m.buckets)}
+	for _, s := range m.series {
+		copied := *s
+		copied.Buckets = slices.Clone(s.Buckets)
+		snapshot.Requests = append(snapshot.Requests, copied)
+	}
+	slices.SortFunc(snapshot.Requests, func(a, b RequestMetric) int {
+		return strings.Compare(a.Host+a.Endpoint+a.Method+a.Status, b.Host+b.Endpoint+b.Method+b.Status)
+	})
+	return snapshot
+}
+
+// Snapshot of counters and latency of requests sent by this client
+func (c *Jenkins) Metrics() *MetricsSnapshot {
+	return c.metrics.snapshot()
+}
+
+// Serve metrics in Prometheus text format:
+//
+//	http.Handle("/metrics", jenkins.MetricsHandler())
+func (c *Jenkins) MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		c.Metrics().WriteTo(w)
+	})
+}
+
+// Write snapshot in Prometheus text format
+func (s *MetricsSnapshot) WriteTo(w io.Writer) (int64, error) {
+	b := new(strings.Builder)
+	b.WriteString("# HELP jenkins_client_requests_total Number of HTTP requests sent to Jenkins.\n")
+	b.WriteString("# TYPE jenkins_client_requests_total counter\n")
+	for _, r := range s.Requests {
+		fmt.Fprintf(b, "jenkins_client_requests_total{%s} %d\n", r.labels(), r.Count)
+	}
+	b.WriteString("# HELP jenkins_client_request_duration_seconds Latency of HTTP requests sent to Jenkins.\n")
+	b.WriteString("# TYPE jenkins_client_request_duration_seconds histogram\n")
+	for _, r := range s.Requests {
+		labels := r.labels()
+		for i, bound := range s.Buckets {
+			fmt.Fprintf(b, "jenkins_client_request_duration_seconds_bucket{%s,le=\"%s\"} %d\n", labels, strconv.FormatFloat(bound, 'g', -1, 64), r.Buckets[i])
+		}
+		fmt.Fprintf(b, "jenkins_client_request_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", labels, r.Count)
+		fmt.Fprintf(b, "jenkins_client_request_duration_seconds_sum{%s} %s\n", labels, strconv.FormatFloat(r.Sum, 'g', -1, 64))
+		fmt.Fprintf(b, "jenkins_client_request_duration_seconds_count{%s} %d\n", labels, r.Count)
+	}
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func (r *RequestMetric) labels() string {
+	return fmt.Sprintf(`method="%s",endpoint="%s",status="%s",host="%s"`,
+		labelEscaper.Replace(r.Method), labelEscaper.Replace(r.Endpoint),
+		labelEscaper.Replace(r.Status), labelEscaper.Replace(r.Host))
+}
+
+// segments followed by a name or id, which is replaced by placeholder
+var endpointPlaceholders = map[string]string{
+	"job":        "{job}",
+	"view":       "{view}",
+	"computer":   "{node}",
+	"credential": "{id}",
+	"item":       "{id}",
+	"store":      "{store}",
+	"domain":     "{domain}",
+}
+
+// Collapse path relative to base into a template, so that requests to
+// different jobs, builds and nodes share one endpoint, eg:
+//
+//	/job/a/job/b/42/consoleText -> /job/{job}/{number}/consoleText
+func normalizeEndpoint(base, path string) string {
+	path = strings.TrimPrefix(path, base)
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	// api of resource, eg: api/json of computer, rather than item named api
+	isAPI := func(i int) bool {
+		return segments[i] == "api" && i == len(segments)-2 && slices.Contains([]string{"json", "xml", "python"}, segments[i+1])
+	}
+	var out []string
+	for i := 0; i < len(segments); i++ {
+		segment := segments[i]
+		placeholder, ok := endpointPlaceholders[segment]
+		switch {
+		case ok && i+1 < len(segments) && !isAPI(i+1):
+			out = append(out, segment, placeholder)
+			i++
+			// nested jobs in folders are collapsed into one
+			for segment == "job" && i+2 < len(segments) && segments[i+1] == "job" && !isAPI(i+2) {
+				i += 2
+			}
+		case segment != "" && strings.Trim(segment, "0123456789") == "":
+			out = append(out, "{number}")
+		default:
+			out = append(out, segment)
+		}
+	}
+	return "/" + strings.Join(out, "/")
+}
@@ -0,0 +1,89 @@
+package jenkins
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeEndpoint(t *testing.T) {
+	var tests = []struct {
+		given, expect string
+	}{
+		{"/", "/"},
+		{"/api/json", "/api/json"},
+		{"/crumbIssuer/api/json", "/crumbIssuer/api/json"},
+		{"/job/pipeline/api/json", "/job/{job}/api/json"},
+		{"/job/a/job/b/job/c/config.xml", "/job/{job}/config.xml"},
+		{"/job/a/job/b/42/consoleText", "/job/{job}/{number}/consoleText"},
+		{"/job/a/job/b/lastBuild/api/json", "/job/{job}/lastBuild/api/json"},
+		{"/job/folder/view/all/api/json", "/job/{job}/view/{view}/api/json"},
+		{"/queue/item/17/api/json", "/queue/item/{id}/api/json"},
+		{"/computer/api/json", "/computer/api/json"},
+		{"/job/api/api/json", "/job/{job}/api/json"},
+		{"/job/a/job/api/config.xml", "/job/{job}/config.xml"},
+		{"/job/api/job/b/api/xml", "/job/{job}/api/xml"},
+		{"/view/api/api/json", "/view/{view}/api/json"},
+		{"/computer/(built-in)/toggleOffline", "/computer/{node}/toggleOffline"},
+		{"/credentials/store/system/domain/_/credential/user-id/config.xml", "/credentials/store/{store}/domain/{domain}/credential/{id}/config.xml"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expect, normalizeEndpoint("/", test.given), test.given)
+	}
+	assert.Equal(t, "/job/{job}/api/json", normalizeEndpoint("/jenkins/", "/jenkins/job/pipeline/api/json"))
+}
+
+func TestMetrics(t *testing.T) {
+	j := newFakeJenkins(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "missing") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"description":""}`))
+	})
+	for _, name := range []string{"a", "b", "c/d"} {
+		job := NewJob(j.Name2URL(name), "WorkflowJob", j)
+		_, err := job.GetDescription()
+		assert.Nil(t, err)
+	}
+	_, err := NewJob(j.Name2URL("missing"), "WorkflowJob", j).Disable()
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	snapshot := j.Metrics()
+	assert.Len(t, snapshot.Requests, 3)
+	host := strings.TrimPrefix(j.URL, "http://")
+	host = strings.TrimSuffix(host, "/")
+	for _, r := range snapshot.Requests {
+		assert.Equal(t, host, r.Host)
+		assert.Equal(t, r.Count, r.Buckets[len(r.Buckets)-1])
+		switch r.Endpoint {
+		case "/crumbIssuer/api/json":
+			assert.Equal(t, uint64(1), r.Count)
+		case "/job/{job}/api/json":
+			assert.Equal(t, "GET", r.Method)
+			assert.Equal(t, "2xx", r.Status)
+			assert.Equal(t, uint64(3), r.Count)
+		case "/job/{job}/disable":
+			assert.Equal(t, "POST", r.Method)
+			assert.Equal(t, "4xx", r.Status)
+			assert.Equal(t, uint64(1), r.Count)
+		default:
+			t.Errorf("unexpected endpoint %s", r.Endpoint)
+		}
+	}
+
+	// prometheus text format
+	rec := httptest.NewRecorder()
+	j.MetricsHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/plain")
+	body, _ := io.ReadAll(rec.Body)
+	text := string(body)
+	assert.Contains(t, text, "# TYPE jenkins_client_requests_total counter\n")
+	assert.Contains(t, text, `jenkins_client_requests_total{method="GET",endpoint="/job/{job}/api/json",status="2xx",host="`+host+`"} 3`)
+	assert.Contains(t, text, `jenkins_client_request_duration_seconds_bucket{method="POST",endpoint="/job/{job}/disable",status="4xx",host="`+host+`",le="+Inf"} 1`)
+	assert.Contains(t, text, `jenkins_client_request_duration_seconds_count{method="POST",endpoint="/job/{job}/disable",status="4xx",host="`+host+`"} 1`)
+}
@@ -0,0 +1,454 @@
+package jenkins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+)
+
+// ConflictPolicy decides what Migrate does if item exists on target.
+type ConflictPolicy int
+
+const (
+	// keep existing item, children of existing folder are still migrated
+	ConflictSkip ConflictPolicy = iota
+	// replace config of existing item
+	ConflictOverwrite
+	// migrate as name-migrated, name-migrated-2 and so on. Credentials are
+	// skipped instead since jobs refer them by id
+	ConflictRename
+)
+
+// SecretFunc returns secrets of credential in folder of source, keys are
+// paths of elements in config.xml as ConfigEdit, e.g.
+// {"password": "s3cr3t"} for username and password credential. Migrate
+// fails the credential if a secret of source is not returned.
+type SecretFunc func(folder string, cred *CredentialJson) (map[string]string, error)
+
+type MigrateOpts struct {
+	// full name on target of item with full name on source, same name if nil,
+	// see MapPrefix
+	MapPath func(name string) string
+	// policy of existing items, ConflictSkip by default
+	OnConflict ConflictPolicy
+	// credentials of folders are not migrated if nil, since jenkins never
+	// returns their secrets
+	Secrets SecretFunc
+}
+
+// MapPrefix maps folder from and its children into folder to, other names
+// are not changed:
+//
+//	MapPrefix("team", "archive/team")("team/app") // archive/team/app
+func MapPrefix(from, to string) func(name string) string {
+	from, to = strings.Trim(from, "/"), strings.Trim(to, "/")
+	return func(name string) string {
+		switch {
+		case name == from:
+			return to
+		case from == "":
+			return strings.Trim(to+"/"+name, "/")
+		case strings.HasPrefix(name, from+"/"):
+			return strings.Trim(to+"/"+name[len(from)+1:], "/")
+		}
+		return name
+	}
+}
+
+type MigrateAction string
+
+const (
+	MigrateCreated     MigrateAction = "created"
+	MigrateOverwritten MigrateAction = "overwritten"
+	MigrateRenamed     MigrateAction = "renamed"
+	MigrateSkipped     MigrateAction = "skipped"
+	MigrateFailed      MigrateAction = "failed"
+)
+
+// kinds of MigrateItem
+const (
+	MigrateJob             = "job"
+	MigrateView            = "view"
+	MigrateCredential      = "credential"
+	MigrateNextBuildNumber = "nextBuildNumber"
+)
+
+// MigrateItem is result of migrating one item. Source and Target are full
+// name of job, or full name of folder and name of view or id of credential
+// joined with "/".
+type MigrateItem struct {
+	Kind   string
+	Source string
+	Target string
+	Action MigrateAction
+	Err    error
+}
+
+type MigrateReport struct {
+	Items []MigrateItem
+	// short names of plugins which are referred by migrated configs but not
+	// installed on target, with source items referring them
+	MissingPlugins map[string][]string
+}
+
+// Failures returns failed items.
+func (r *MigrateReport) Failures() []MigrateItem {
+	var failures []MigrateItem
+	for _, item := range r.Items {
+		if item.Err != nil {
+			failures = append(failures, item)
+		}
+	}
+	return failures
+}
+
+// Err joins errors of failed items, nil if none is failed.
+func (r *MigrateReport) Err() error {
+	var errs []error
+	for _, item := range r.Failures() {
+		errs = append(errs, fmt.Errorf("%s %s: %w", item.Kind, item.Source, item.Err))
+	}
+	return errors.Join(errs...)
+}
+
+func (c *Jenkins) Migrate(folder string, target *Jenkins, opts *MigrateOpts) (*MigrateReport, error) {
+	return c.MigrateCtx(context.Background(), folder, target, opts)
+}
+
+// Migrate folder and jobs under it to target, e.g. from another controller.
+// Folders and jobs are created from config.xml in order, along with views
+// and credentials of folders and next build numbers of jobs. Builds are not
+// migrated. Jobs of multibranch projects and organization folders are
+// generated, so they are not walked into.
+//
+// Failure of an item is recorded in the report and migration goes on, the
+// returned error is report.Err() or failure of walking source or listing
+// plugins of target. Folders are migrated as their parents if folder is
+// empty, i.e. views and credentials of jenkins itself are not migrated.
+func (c *Jenkins) MigrateCtx(ctx context.Context, folder string, target *Jenkins, opts *MigrateOpts) (*MigrateReport, error) {
+	m := &migration{src: c, dst: target, report: &MigrateReport{MissingPlugins: map[string][]string{}}, targets: map[string]string{}}
+	if opts != nil {
+		m.opts = *opts
+	}
+	if m.opts.MapPath == nil {
+		m.opts.MapPath = func(name string) string { return name }
+	}
+	plugins, err := target.Plugins().ListCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m.plugins = map[string]bool{}
+	for _, p := range plugins {
+		m.plugins[p.ShortName] = p.Active
+	}
+
+	folder = strings.Trim(folder, "/")
+	root := NewJob(c.URL, "Folder", c)
+	if folder != "" {
+		if root, err = c.GetJobCtx(ctx, folder); err != nil {
+			return nil, err
+		}
+		// parents of folder are created as plain folders
+		if dir := path.Dir(m.opts.MapPath(folder)); dir != "." {
+			if _, err := target.EnsureFolderCtx(ctx, dir); err != nil {
+				return nil, err
+			}
+		}
+		m.migrateJob(ctx, root)
+	}
+	if isFolder(root.Class) {
+		for job, err := range root.WalkCtx(ctx, &WalkOpts{Skip: func(job *Job) bool { return job.Class != "Folder" }}) {
+			if err != nil {
+				return m.report, err
+			}
+			m.migrateJob(ctx, job)
+		}
+	}
+	for _, items := range m.report.MissingPlugins {
+		slices.Sort(items)
+	}
+	return m.report, m.report.Err()
+}
+
+type migration struct {
+	src, dst *Jenkins
+	opts     MigrateOpts
+	report   *MigrateReport
+	// active plugins of target by short name
+	plugins map[string]bool
+	// full name of migrated folders on target by full name on source, empty
+	// if it is failed
+	targets map[string]string
+}
+
+func (m *migration) record(item MigrateItem) {
+	if item.Err != nil {
+		item.Action = MigrateFailed
+	}
+	m.report.Items = append(m.report.Items, item)
+}
+
+// full name on target, children of renamed folder go into renamed folder
+func (m *migration) targetName(name string) (string, error) {
+	mapped := m.opts.MapPath(name)
+	dir := path.Dir(name)
+	parent, ok := m.targets[dir]
+	if !ok {
+		return mapped, nil
+	}
+	if parent == "" {
+		return "", fmt.Errorf("folder %s is not migrated", dir)
+	}
+	if prefix := m.opts.MapPath(dir) + "/"; strings.HasPrefix(mapped, prefix) {
+		return parent + "/" + mapped[len(prefix):], nil
+	}
+	return mapped, nil
+}
+
+// collect plugins referred by config which are not installed on target
+func (m *migration) checkPlugins(source, config string) {
+	for _, name := range pluginRefs(config) {
+		if !m.plugins[name] && !slices.Contains(m.report.MissingPlugins[name], source) {
+			m.report.MissingPlugins[name] = append(m.report.MissingPlugins[name], source)
+		}
+	}
+}
+
+// short names of plugins referred by plugin attribute of elements in config
+func pluginRefs(config string) []string {
+	doc, err := parseXMLDoc(config)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	var walk func(e *xmlElement)
+	walk = func(e *xmlElement) {
+		if value, ok := e.attr("plugin"); ok {
+			name, _, _ := strings.Cut(value, "@")
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+		for _, child := range e.children {
+			if child.elem != nil {
+				walk(child.elem)
+			}
+		}
+	}
+	walk(doc)
+	return names
+}
+
+// free name for ConflictRename
+func (m *migration) rename(name string, exists func(string) (bool, error)) (string, error) {
+	for i := 1; ; i++ {
+		candidate := name + "-migrated"
+		if i > 1 {
+			candidate = fmt.Sprintf("%s-%d", candidate, i)
+		}
+		found, err := exists(candidate)
+		if err != nil || !found {
+			return candidate, err
+		}
+	}
+}
+
+func (m *migration) migrateJob(ctx context.Context, job *Job) {
+	item := MigrateItem{Kind: MigrateJob, Source: job.FullName}
+	if isFolder(job.Class) {
+		// children are not migrated unless it is done
+		m.targets[job.FullName] = ""
+	}
+	var err error
+	if item.Target, err = m.targetName(job.FullName); err != nil {
+		item.Err = err
+		m.record(item)
+		return
+	}
+	config, err := job.GetConfigureCtx(ctx)
+	if err != nil {
+		item.Err = err
+		m.record(item)
+		return
+	}
+	m.checkPlugins(item.Source, config)
+	jobExists := func(name string) (bool, error) { return m.dst.JobExistsCtx(ctx, name) }
+	existing, err := m.dst.lookupJob(ctx, item.Target)
+	exists := err == nil
+	if errors.Is(err, ErrNotFound) {
+		err = nil
+	}
+	item.Action = MigrateCreated
+	switch {
+	case err != nil:
+	case exists && isFolder(job.Class) && !isFolder(existing.Class) && m.opts.OnConflict != ConflictRename:
+		err = newError(ErrNotAFolder, "%s is not a folder", existing)
+	case exists && m.opts.OnConflict == ConflictSkip:
+		item.Action = MigrateSkipped
+	case exists && m.opts.OnConflict == ConflictOverwrite:
+		item.Action = MigrateOverwritten
+		err = closeResponse(NewJob(m.dst.Name2URL(item.Target), "Job", m.dst).SetConfigureCtx(ctx, strings.NewReader(config)))
+	case exists && m.opts.OnConflict == ConflictRename:
+		item.Action = MigrateRenamed
+		if item.Target, err = m.rename(item.Target, jobExists); err == nil {
+			err = closeResponse(m.dst.CreateJobCtx(ctx, item.Target, strings.NewReader(config)))
+		}
+	default:
+		err = closeResponse(m.dst.CreateJobCtx(ctx, item.Target, strings.NewReader(config)))
+	}
+	item.Err = err
+	m.record(item)
+	if err != nil {
+		return
+	}
+
+	dst := NewJob(m.dst.Name2URL(item.Target), job.Class, m.dst)
+	if isFolder(job.Class) {
+		m.targets[job.FullName] = item.Target
+		m.migrateViews(ctx, job, dst)
+		m.migrateCredentials(ctx, job, dst)
+		return
+	}
+	if item.Action == MigrateSkipped {
+		return
+	}
+	info, err := ApiJSONCtx[struct {
+		NextBuildNumber int `json:"nextBuildNumber"`
+	}](ctx, job)
+	if err == nil && info.NextBuildNumber > 1 {
+		err = closeResponse(dst.SetNextBuildNumberCtx(ctx, info.NextBuildNumber))
+	}
+	if err != nil || info.NextBuildNumber > 1 {
+		m.record(MigrateItem{Kind: MigrateNextBuildNumber, Source: job.FullName, Target: item.Target, Action: MigrateOverwritten, Err: err})
+	}
+}
+
+// default view of folder is created with folder
+func isDefaultView(name string) bool {
+	return strings.EqualFold(name, "all")
+}
+
+func (m *migration) migrateViews(ctx context.Context, src, dst *Job) {
+	views, err := src.Views().ListCtx(ctx)
+	if err != nil {
+		m.record(MigrateItem{Kind: MigrateView, Source: src.FullName, Err: err})
+		return
+	}
+	for _, view := range views {
+		if isDefaultView(view.Name) {
+			continue
+		}
+		item := MigrateItem{Kind: MigrateView, Source: src.FullName + "/" + view.Name}
+		name := view.Name
+		config, err := src.Views().GetConfigureCtx(ctx, name)
+		if err == nil {
+			m.checkPlugins(item.Source, config)
+			viewExists := func(name string) (bool, error) {
+				_, err := dst.Views().GetCtx(ctx, name)
+				if errors.Is(err, ErrNotFound) {
+					return false, nil
+				}
+				return err == nil, err
+			}
+			var exists bool
+			exists, err = viewExists(name)
+			item.Action = MigrateCreated
+			switch {
+			case err != nil:
+			case exists && m.opts.OnConflict == ConflictSkip:
+				item.Action = MigrateSkipped
+			case exists && m.opts.OnConflict == ConflictOverwrite:
+				item.Action = MigrateOverwritten
+				err = closeResponse(dst.Views().SetConfigureCtx(ctx, name, strings.NewReader(config)))
+			case exists && m.opts.OnConflict == ConflictRename:
+				item.Action = MigrateRenamed
+				if name, err = m.rename(name, viewExists); err == nil {
+					err = closeResponse(dst.Views().CreateCtx(ctx, name, strings.NewReader(config)))
+				}
+			default:
+				err = closeResponse(dst.Views().CreateCtx(ctx, name, strings.NewReader(config)))
+			}
+		}
+		item.Target = dst.FullName + "/" + name
+		item.Err = err
+		m.record(item)
+	}
+}
+
+func (m *migration) migrateCredentials(ctx context.Context, src, dst *Job) {
+	creds, err := src.Credentials().ListCtx(ctx)
+	if err != nil {
+		m.record(MigrateItem{Kind: MigrateCredential, Source: src.FullName, Err: err})
+		return
+	}
+	for _, cred := range creds {
+		item := MigrateItem{
+			Kind:   MigrateCredential,
+			Source: src.FullName + "/" + cred.ID,
+			Target: dst.FullName + "/" + cred.ID,
+			Action: MigrateSkipped,
+		}
+		if m.opts.Secrets == nil {
+			m.record(item)
+			continue
+		}
+		config, err := src.Credentials().GetConfigureCtx(ctx, cred.ID)
+		if err == nil {
+			m.checkPlugins(item.Source, config)
+			config, err = m.fillSecrets(src.FullName, cred, config)
+		}
+		var exists bool
+		if err == nil {
+			_, err = dst.Credentials().GetCtx(ctx, cred.ID)
+			exists = err == nil
+			if errors.Is(err, ErrNotFound) {
+				err = nil
+			}
+		}
+		switch {
+		case err != nil:
+		case exists && m.opts.OnConflict == ConflictOverwrite:
+			item.Action = MigrateOverwritten
+			err = closeResponse(dst.Credentials().SetConfigureCtx(ctx, cred.ID, strings.NewReader(config)))
+		case exists:
+		default:
+			item.Action = MigrateCreated
+			err = closeResponse(dst.Credentials().CreateCtx(ctx, strings.NewReader(config)))
+		}
+		item.Err = err
+		m.record(item)
+	}
+}
+
+// secrets of source are encrypted by its key, they are replaced by values
+// of SecretFunc
+func (m *migration) fillSecrets(folder string, cred *CredentialJson, config string) (string, error) {
+	secrets, err := m.opts.Secrets(folder, cred)
+	if err != nil {
+		return "", err
+	}
+	config, stripped, err := stripSecrets(config)
+	if err != nil {
+		return "", err
+	}
+	return setSecrets(config, cred.ID, stripped, secrets)
+}
+
+// set secrets of credential config, keys are paths of secrets which must be
+// in secrets
+func setSecrets(config, id string, keys []string, secrets map[string]string) (string, error) {
+	for _, key := range keys {
+		if _, ok := secrets[key]; !ok {
+			return "", fmt.Errorf("secret %s of credential %s is not returned by SecretFunc", key, id)
+		}
+	}
+	var edits []ConfigEdit
+	for _, key := range slices.Sorted(maps.Keys(secrets)) {
+		edits = append(edits, SetText(key, secrets[key]))
+	}
+	return applyEdits(config, edits)
+}
@@ -0,0 +1,173 @@
+package jenkins
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/joelee2012/go-jenkins/jenkinstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const migrateJobConf = `<?xml version='1.1' encoding='UTF-8'?>
+<flow-definition plugin="workflow-job@1400.v7fd111b_ec82f">
+  <description>app</description>
+  <definition class="org.jenkinsci.plugins.workflow.cps.CpsFlowDefinition" plugin="workflow-cps@3894.vd0f0248b_a_fc4">
+    <script>echo "hello"</script>
+    <sandbox>true</sandbox>
+  </definition>
+</flow-definition>`
+
+func newMigrateJenkins(t *testing.T) (*Jenkins, *Jenkins) {
+	src := jenkinstest.NewServer()
+	t.Cleanup(src.Close)
+	for _, job := range []DesiredJob{
+		{"team", folderConf},
+		{"team/app", migrateJobConf},
+		{"team/lib", folderConf},
+		{"team/lib/x", syncJobConf},
+		{"other", syncJobConf},
+	} {
+		require.NoError(t, src.CreateJob(job.Name, job.Config))
+	}
+	dst := jenkinstest.NewServer()
+	t.Cleanup(dst.Close)
+	dst.InstallPlugin("workflow-job", "1400.v7fd111b_ec82f")
+
+	source, err := New(src.URL, "admin", "1234")
+	require.NoError(t, err)
+	target, err := New(dst.URL, "admin", "1234")
+	require.NoError(t, err)
+	team, err := source.GetJob("team")
+	require.NoError(t, err)
+	_, err = team.Views().Create("mine", strings.NewReader(viewConf))
+	require.NoError(t, err)
+	_, err = team.Credentials().Create(strings.NewReader(credConf))
+	require.NoError(t, err)
+	app, err := source.GetJob("team/app")
+	require.NoError(t, err)
+	_, err = app.SetNextBuildNumber(5)
+	require.NoError(t, err)
+	return source, target
+}
+
+func migrated(report *MigrateReport) []string {
+	var items []string
+	for _, item := range report.Items {
+		items = append(items, item.Kind+" "+item.Source+" "+string(item.Action)+" "+item.Target)
+	}
+	return items
+}
+
+func TestMigrate(t *testing.T) {
+	source, target := newMigrateJenkins(t)
+	plugin, err := target.Plugins().Get("workflow-job")
+	require.NoError(t, err)
+	assert.Equal(t, "1400.v7fd111b_ec82f", plugin.Version)
+	_, err = source.Plugins().Get("workflow-job")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	secrets := func(folder string, cred *CredentialJson) (map[string]string, error) {
+		assert.Equal(t, "team", folder)
+		return map[string]string{"password": "from-vault"}, nil
+	}
+	opts := &MigrateOpts{MapPath: MapPrefix("team", "archive/team"), Secrets: secrets}
+	report, err := source.Migrate("team", target, opts)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"job team created archive/team",
+		"view team/mine created archive/team/mine",
+		"credential team/user-id created archive/team/user-id",
+		"job team/app created archive/team/app",
+		"nextBuildNumber team/app overwritten archive/team/app",
+		"job team/lib created archive/team/lib",
+		"job team/lib/x created archive/team/lib/x",
+	}, migrated(report))
+	assert.Equal(t, map[string][]string{"favorite": {"team/mine"}, "workflow-cps": {"team/app"}}, report.MissingPlugins)
+
+	app, err := target.GetJob("archive/team/app")
+	require.NoError(t, err)
+	info, err := ApiJSON[struct {
+		NextBuildNumber int `json:"nextBuildNumber"`
+	}](app)
+	require.NoError(t, err)
+	assert.Equal(t, 5, info.NextBuildNumber)
+	config, err := app.GetConfigure()
+	require.NoError(t, err)
+	assert.Equal(t, migrateJobConf, config)
+	team, err := target.GetJob("archive/team")
+	require.NoError(t, err)
+	_, err = team.Views().Get("mine")
+	require.NoError(t, err)
+	cred, err := team.Credentials().Get("user-id")
+	require.NoError(t, err)
+	assert.Equal(t, "user id for testing", cred.Description)
+	exists, err := target.JobExists("other")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// re-run skips everything
+	report, err = source.Migrate("team", target, opts)
+	require.NoError(t, err)
+	for _, item := range report.Items {
+		assert.Equal(t, MigrateSkipped, item.Action, item)
+	}
+	assert.Len(t, report.Items, 6)
+
+	// children go into renamed folder
+	opts.OnConflict = ConflictRename
+	report, err = source.Migrate("team/lib", target, opts)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"job team/lib renamed archive/team/lib-migrated",
+		"job team/lib/x created archive/team/lib-migrated/x",
+	}, migrated(report))
+
+	opts.OnConflict = ConflictOverwrite
+	opts.Secrets = nil
+	report, err = source.Migrate("team", target, opts)
+	require.NoError(t, err)
+	assert.Contains(t, migrated(report), "job team/app overwritten archive/team/app")
+	assert.Contains(t, migrated(report), "view team/mine overwritten archive/team/mine")
+	assert.Contains(t, migrated(report), "credential team/user-id skipped archive/team/user-id")
+
+	// encrypted secret of source is never posted
+	_, target = newMigrateJenkins(t)
+	opts = &MigrateOpts{Secrets: func(folder string, cred *CredentialJson) (map[string]string, error) {
+		return map[string]string{}, nil
+	}}
+	report, err = source.Migrate("team", target, opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "secret password of credential user-id")
+	team, err = target.GetJob("team")
+	require.NoError(t, err)
+	_, err = team.Credentials().Get("user-id")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = source.Migrate("missing", target, nil)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestMigrateFailure(t *testing.T) {
+	source, target := newMigrateJenkins(t)
+	// target has job where folder is migrated to
+	_, err := target.CreateOrUpdateJob("team", strings.NewReader(syncJobConf))
+	require.NoError(t, err)
+	report, err := source.Migrate("", target, nil)
+	require.Error(t, err)
+	assert.Equal(t, []string{
+		"job team failed team",
+		"job team/app failed ",
+		"job team/lib failed ",
+		"job team/lib/x failed ",
+		"job other created other",
+	}, migrated(report))
+	assert.Len(t, report.Failures(), 4)
+	assert.ErrorIs(t, err, ErrNotAFolder)
+	assert.Contains(t, err.Error(), "folder team is not migrated")
+
+	assert.Equal(t, "archive/team/app", MapPrefix("team", "archive/team")("team/app"))
+	assert.Equal(t, "teams/app", MapPrefix("team", "archive/team")("teams/app"))
+	assert.Equal(t, "archive/app", MapPrefix("", "archive")("app"))
+	assert.Equal(t, "app", MapPrefix("team", "")("team/app"))
+}
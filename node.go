@@ -1,7 +1,9 @@
 package jenkins
 
 import (
-	"fmt"
+	"context"
+	"encoding/json"
+	"io"
 	"net/http"
 	"net/url"
 	"strings"
@@ -14,10 +16,14 @@ type Nodes struct {
 var nodeNameMap = map[string]string{"master": "(master)", "Built-In Node": "(built-in)"}
 
 func (ns *Nodes) GetBuilds() ([]*Build, error) {
+	return ns.GetBuildsCtx(context.Background())
+}
+
+func (ns *Nodes) GetBuildsCtx(ctx context.Context) ([]*Build, error) {
 	compSet := &ComputerSet{}
 	var builds []*Build
 	tree := "computer[executors[currentExecutable[url]],oneOffExecutors[currentExecutable[url]]]"
-	if err := ns.ApiJson(compSet, &ApiJsonOpts{Tree: tree, Depth: 2}); err != nil {
+	if err := ns.ApiJsonCtx(ctx, compSet, &ApiJsonOpts{Tree: tree, Depth: 2}); err != nil {
 		return nil, err
 	}
 	buildConf := map[string]string{}
@@ -43,8 +49,12 @@ func (ns *Nodes) GetBuilds() ([]*Build, error) {
 }
 
 func (ns *Nodes) Get(name string) (*Computer, error) {
+	return ns.GetCtx(context.Background(), name)
+}
+
+func (ns *Nodes) GetCtx(ctx context.Context, name string) (*Computer, error) {
 	compSet := &ComputerSet{}
-	if err := ns.ApiJson(compSet, nil); err != nil {
+	if err := ns.ApiJsonCtx(ctx, compSet, nil); err != nil {
 		return nil, err
 	}
 
@@ -53,12 +63,16 @@ func (ns *Nodes) Get(name string) (*Computer, error) {
 			return c, nil
 		}
 	}
-	return nil, fmt.Errorf("no such node [%s]", name)
+	return nil, newError(ErrNotFound, "no such node [%s]", name)
 }
 
 func (ns *Nodes) List() ([]*Computer, error) {
+	return ns.ListCtx(context.Background())
+}
+
+func (ns *Nodes) ListCtx(ctx context.Context) ([]*Computer, error) {
 	compSet := &ComputerSet{}
-	if err := ns.ApiJson(compSet, nil); err != nil {
+	if err := ns.ApiJsonCtx(ctx, compSet, nil); err != nil {
 		return nil, err
 	}
 	return compSet.Computers, nil
@@ -72,15 +86,74 @@ func (ns *Nodes) covertName(name string) string {
 }
 
 func (ns *Nodes) Enable(name string) (*http.Response, error) {
-	return ns.Request("POST", ns.covertName(name)+"/toggleOffline?offlineMessage=", nil)
+	return ns.EnableCtx(context.Background(), name)
+}
+
+func (ns *Nodes) EnableCtx(ctx context.Context, name string) (*http.Response, error) {
+	return ns.RequestCtx(ctx, "POST", ns.covertName(name)+"/toggleOffline?offlineMessage=", nil)
 }
 
 func (ns *Nodes) Disable(name, msg string) (*http.Response, error) {
+	return ns.DisableCtx(context.Background(), name, msg)
+}
+
+func (ns *Nodes) DisableCtx(ctx context.Context, name, msg string) (*http.Response, error) {
 	v := url.Values{}
 	v.Add("offlineMessage", msg)
-	return ns.Request("POST", ns.covertName(name)+"/toggleOffline?"+v.Encode(), nil)
+	return ns.RequestCtx(ctx, "POST", ns.covertName(name)+"/toggleOffline?"+v.Encode(), nil)
 }
 
 func (ns *Nodes) Delete(name string) (*http.Response, error) {
-	return ns.Request("POST", ns.covertName(name)+"/doDelete", nil)
+	return ns.DeleteCtx(context.Background(), name)
+}
+
+func (ns *Nodes) DeleteCtx(ctx context.Context, name string) (*http.Response, error) {
+	return ns.RequestCtx(ctx, "POST", ns.covertName(name)+"/doDelete", nil)
+}
+
+func (ns *Nodes) GetConfigure(name string) (string, error) {
+	return ns.GetConfigureCtx(context.Background(), name)
+}
+
+func (ns *Nodes) GetConfigureCtx(ctx context.Context, name string) (string, error) {
+	return readResponseToString(ctx, ns, "GET", ns.covertName(name)+"/config.xml", nil)
+}
+
+func (ns *Nodes) SetConfigure(name string, xml io.Reader) (*http.Response, error) {
+	return ns.SetConfigureCtx(context.Background(), name, xml)
+}
+
+func (ns *Nodes) SetConfigureCtx(ctx context.Context, name string, xml io.Reader) (*http.Response, error) {
+	return ns.RequestCtx(ctx, "POST", ns.covertName(name)+"/config.xml", xml)
+}
+
+func (ns *Nodes) Create(name string, xml io.Reader) (*http.Response, error) {
+	return ns.CreateCtx(context.Background(), name, xml)
+}
+
+// Create agent with xml config, jenkins creates agent from form only, so a
+// permanent agent is created then its config.xml is replaced.
+func (ns *Nodes) CreateCtx(ctx context.Context, name string, xml io.Reader) (*http.Response, error) {
+	form, _ := json.Marshal(map[string]any{
+		"name":              name,
+		"nodeDescription":   "",
+		"numExecutors":      "1",
+		"remoteFS":          "/tmp",
+		"labelString":       "",
+		"mode":              "NORMAL",
+		"type":              "hudson.slaves.DumbSlave",
+		"retentionStrategy": map[string]string{"stapler-class": "hudson.slaves.RetentionStrategy$Always"},
+		"nodeProperties":    map[string]string{"stapler-class-bag": "true"},
+		"launcher":          map[string]string{"stapler-class": "hudson.slaves.JNLPLauncher"},
+	})
+	v := url.Values{}
+	v.Add("name", name)
+	v.Add("type", "hudson.slaves.DumbSlave")
+	v.Add("json", string(form))
+	resp, err := ns.RequestCtx(ctx, "POST", "doCreateItem?"+v.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return ns.SetConfigureCtx(ctx, name, xml)
 }
@@ -7,32 +7,35 @@ import (
 )
 
 func TestNodeGet(t *testing.T) {
-	node, err := jenkins.Nodes().Get("Built-In Node")
+	j := newReplayJenkins(t, "node_get")
+	node, err := j.Nodes().Get("Built-In Node")
 	assert.Nil(t, err)
 	assert.NotNil(t, node)
 }
 
 func TestNodeList(t *testing.T) {
-	nodes, err := jenkins.Nodes().List()
+	j := newReplayJenkins(t, "node_list")
+	nodes, err := j.Nodes().List()
 	assert.Nil(t, err)
 	assert.Len(t, nodes, 1)
 }
 
 func TestDisableNode(t *testing.T) {
+	j := newReplayJenkins(t, "node_disable")
 	// check node status
-	node, err := jenkins.Nodes().Get("Built-In Node")
+	node, err := j.Nodes().Get("Built-In Node")
 	assert.Nil(t, err)
 	assert.NotNil(t, node)
 	assert.False(t, node.Offline)
 
 	// disable and then check
-	_, err = jenkins.Nodes().Disable("Built-In Node", "test")
+	_, err = j.Nodes().Disable("Built-In Node", "test")
 	assert.Nil(t, err)
-	node, err = jenkins.Nodes().Get("Built-In Node")
+	node, err = j.Nodes().Get("Built-In Node")
 	assert.Nil(t, err)
 	assert.True(t, node.Offline)
 
 	// enable again
-	_, err = jenkins.Nodes().Enable("Built-In Node")
+	_, err = j.Nodes().Enable("Built-In Node")
 	assert.Nil(t, err)
 }
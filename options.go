@@ -0,0 +1,151 @@
+package jenkins
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Option configures JenkinsOpts for NewWithOptions
+type Option func(*JenkinsOpts) error
+
+// Authenticate with user and password or API token
+func WithBasicAuth(user, password string) Option {
+	return func(o *JenkinsOpts) error {
+		o.User = user
+		o.Password = password
+		return nil
+	}
+}
+
+// Trust server certificates signed by pool instead of system roots
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(o *JenkinsOpts) error {
+		o.RootCAs = pool
+		return nil
+	}
+}
+
+// Present certificate to server which requires mutual TLS
+func WithClientCertificate(cert tls.Certificate) Option {
+	return func(o *JenkinsOpts) error {
+		o.Certificates = append(o.Certificates, cert)
+		return nil
+	}
+}
+
+// Send requests through proxy, eg: http://proxy.example.com:3128
+func WithProxy(proxy string) Option {
+	return func(o *JenkinsOpts) error {
+		u, err := url.Parse(proxy)
+		if err != nil {
+			return fmt.Errorf("invalid proxy %q: %w", proxy, err)
+		}
+		o.Proxy = http.ProxyURL(u)
+		return nil
+	}
+}
+
+// Limit the time of every request, including reading response body
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *JenkinsOpts) error {
+		o.Timeout = timeout
+		return nil
+	}
+}
+
+// Set User-Agent header of every request
+func WithUserAgent(agent string) Option {
+	return func(o *JenkinsOpts) error {
+		o.UserAgent = agent
+		return nil
+	}
+}
+
+// Use a clone of transport as base transport, TLS and proxy options are
+// applied on top of it.
+func WithTransport(transport *http.Transport) Option {
+	return func(o *JenkinsOpts) error {
+		o.BaseTransport = transport
+		return nil
+	}
+}
+
+// Check URL and credentials by GetVersion and GetCrumb when client is created
+func WithVerifyOnConnect() Option {
+	return func(o *JenkinsOpts) error {
+		o.VerifyOnConnect = true
+		return nil
+	}
+}
+
+func (o *JenkinsOpts) newClient() *http.Client {
+	client := o.Client
+	if client.CheckRedirect == nil {
+		client.CheckRedirect = noRedirect
+	}
+	if o.BaseTransport == nil && o.RootCAs == nil && len(o.Certificates) == 0 && o.Proxy == nil {
+		return &client
+	}
+	base := o.BaseTransport
+	if base == nil {
+		base = http.DefaultTransport.(*http.Transport)
+	}
+	transport := base.Clone()
+	if o.RootCAs != nil || len(o.Certificates) > 0 {
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		if o.RootCAs != nil {
+			transport.TLSClientConfig.RootCAs = o.RootCAs
+		}
+		transport.TLSClientConfig.Certificates = append(transport.TLSClientConfig.Certificates, o.Certificates...)
+	}
+	if o.Proxy != nil {
+		transport.Proxy = o.Proxy
+	}
+	client.Transport = transport
+	return &client
+}
+
+// Create Jenkins client with options:
+//
+//	jenkins, err := jenkins.NewWithOptions("https://jenkins.example.com/",
+//		jenkins.WithBasicAuth("admin", "1234"),
+//		jenkins.WithTimeout(30*time.Second),
+//		jenkins.WithVerifyOnConnect(),
+//	)
+//	if err != nil {
+//		log.Fatalln(err)
+//	}
+func NewWithOptions(url string, opts ...Option) (*Jenkins, error) {
+	o := &JenkinsOpts{URL: url, LogLevel: slog.LevelDebug, LogErrorLevel: slog.LevelWarn}
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+	c := newJenkins(o)
+	c.SetClient(o.newClient())
+	if o.VerifyOnConnect {
+		if err := c.verify(context.Background()); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func (c *Jenkins) verify(ctx context.Context) error {
+	if _, err := c.GetVersionCtx(ctx); err != nil {
+		return fmt.Errorf("verify %s: %w", c.URL, err)
+	}
+	if _, err := c.GetCrumbCtx(ctx); err != nil {
+		return fmt.Errorf("verify %s: %w", c.URL, err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package jenkins
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTLSJenkinsServer(t *testing.T, agents *[]string) *httptest.Server {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*agents = append(*agents, r.UserAgent())
+		if user, password, _ := r.BasicAuth(); user != "admin" || password != "1234" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/crumbIssuer/api/json":
+			fmt.Fprint(w, `{"crumbRequestField":"Jenkins-Crumb","crumb":"fake-crumb"}`)
+		case "/":
+			w.Header().Set("X-Jenkins", "2.440")
+		default:
+			http.Redirect(w, r, "/", http.StatusFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestNewWithOptions(t *testing.T) {
+	var agents []string
+	srv := newTLSJenkinsServer(t, &agents)
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+
+	j, err := NewWithOptions(srv.URL,
+		WithBasicAuth("admin", "1234"),
+		WithRootCAs(pool),
+		WithUserAgent("go-jenkins-test"),
+		WithTimeout(5*time.Second),
+		WithVerifyOnConnect(),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, srv.URL+"/", j.URL)
+	assert.Equal(t, 5*time.Second, j.Client().Timeout)
+	assert.Equal(t, []string{"go-jenkins-test", "go-jenkins-test"}, agents)
+
+	// redirect is not followed
+	resp, err := j.Request("POST", "job/pipeline/confirmRename", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusFound, resp.StatusCode)
+}
+
+func TestNewWithOptionsVerifyFailed(t *testing.T) {
+	var agents []string
+	srv := newTLSJenkinsServer(t, &agents)
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+
+	// bad credentials
+	j, err := NewWithOptions(srv.URL, WithBasicAuth("admin", "wrong"), WithRootCAs(pool), WithVerifyOnConnect())
+	assert.NotNil(t, err)
+	assert.Nil(t, j)
+
+	// unknown certificate authority
+	j, err = NewWithOptions(srv.URL, WithBasicAuth("admin", "1234"), WithVerifyOnConnect())
+	assert.NotNil(t, err)
+	assert.Nil(t, j)
+
+	// verification is skipped by default
+	j, err = NewWithOptions(srv.URL, WithBasicAuth("admin", "wrong"))
+	assert.Nil(t, err)
+	assert.NotNil(t, j)
+}
+
+func TestNewWithOptionsTransport(t *testing.T) {
+	base := &http.Transport{MaxIdleConns: 7}
+	j, err := NewWithOptions("http://localhost:8080", WithTransport(base), WithProxy("http://proxy:3128"))
+	assert.Nil(t, err)
+	transport, ok := j.Client().Transport.(*http.Transport)
+	assert.True(t, ok)
+	assert.NotSame(t, base, transport)
+	assert.Equal(t, 7, transport.MaxIdleConns)
+	proxy, err := transport.Proxy(&http.Request{})
+	assert.Nil(t, err)
+	assert.Equal(t, "proxy:3128", proxy.Host)
+
+	_, err = NewWithOptions("http://localhost:8080", WithProxy("://bad"))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,222 @@
+package jenkins
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// max attempts of PatchConfigure when config is changed by others
+const maxPatchAttempts = 3
+
+// ConfigEdit is an edit of config.xml, the element is addressed by path of
+// form:
+//
+//	/project/description               absolute path from document
+//	properties/*/parameterDefinitions  relative to root element, * matches any element
+//	builders/hudson.tasks.Shell[2]     the second Shell builder, position starts from 1
+//	scm[@class='hudson.scm.NullSCM']   element with attribute
+//	parameterDefinitions/*[name='ARG'] element with child element of text
+//
+// Path must match at least one element, the edit applies to all of them.
+type ConfigEdit struct {
+	path  string
+	desc  string
+	apply func(m xmlMatch) error
+	// reports whether elements matched path in config are as edited, after
+	// are matches in the edited config
+	applied func(matches, after []xmlMatch) bool
+}
+
+func (e ConfigEdit) String() string {
+	return e.desc + " " + e.path
+}
+
+// SetText replaces content of element with text.
+func SetText(path, text string) ConfigEdit {
+	return ConfigEdit{path: path, desc: "set text", apply: func(m xmlMatch) error {
+		m.elem.setText(text)
+		return nil
+	}, applied: func(matches, after []xmlMatch) bool {
+		return len(matches) > 0 && !slices.ContainsFunc(matches, func(m xmlMatch) bool { return m.elem.text() != text })
+	}}
+}
+
+// SetAttr sets attribute of element, it is added if element has not.
+func SetAttr(path, name, value string) ConfigEdit {
+	return ConfigEdit{path: path, desc: "set attribute " + name, apply: func(m xmlMatch) error {
+		m.elem.setAttr(name, value)
+		return nil
+	}, applied: func(matches, after []xmlMatch) bool {
+		return len(matches) > 0 && !slices.ContainsFunc(matches, func(m xmlMatch) bool {
+			v, ok := m.elem.attr(name)
+			return !ok || v != value
+		})
+	}}
+}
+
+// InsertElement appends xml fragment as the last children of element, e.g.
+//
+//	InsertElement("builders", "<hudson.tasks.Shell><command>make</command></hudson.tasks.Shell>")
+func InsertElement(path, fragment string) ConfigEdit {
+	return ConfigEdit{path: path, desc: "insert element", apply: func(m xmlMatch) error {
+		// parse for each element, so nodes are not shared
+		nodes, err := parseXMLFragment(fragment)
+		if err != nil {
+			return fmt.Errorf("invalid fragment: %w", err)
+		}
+		m.elem.appendNodes(nodes)
+		return nil
+	}, applied: func(matches, after []xmlMatch) bool {
+		nodes, err := parseXMLFragment(fragment)
+		if err != nil || len(matches) == 0 {
+			return false
+		}
+		return !slices.ContainsFunc(matches, func(m xmlMatch) bool {
+			return slices.ContainsFunc(nodes, func(n *xmlNode) bool {
+				return n.elem != nil && !slices.ContainsFunc(m.elem.children, func(c *xmlNode) bool {
+					return c.elem != nil && c.elem.canonicalString() == n.elem.canonicalString()
+				})
+			})
+		})
+	}}
+}
+
+// RemoveElement removes element.
+func RemoveElement(path string) ConfigEdit {
+	return ConfigEdit{path: path, desc: "remove element", apply: func(m xmlMatch) error {
+		if m.parent == nil {
+			return fmt.Errorf("can not remove document")
+		}
+		m.parent.removeChild(m.elem)
+		return nil
+	}, applied: func(matches, after []xmlMatch) bool {
+		// path with position may match the next sibling after removal
+		return slices.EqualFunc(matches, after, func(a, b xmlMatch) bool {
+			return a.elem.canonicalString() == b.elem.canonicalString()
+		})
+	}}
+}
+
+// apply edits on config, returns the edited config
+func applyEdits(config string, edits []ConfigEdit) (string, error) {
+	doc, err := parseXMLDoc(config)
+	if err != nil {
+		return "", err
+	}
+	for _, edit := range edits {
+		matches, err := doc.selectPath(edit.path)
+		if err != nil {
+			return "", err
+		}
+		if len(matches) == 0 {
+			return "", newError(ErrNotFound, "%s: no element matched", edit)
+		}
+		for _, m := range matches {
+			if err := edit.apply(m); err != nil {
+				return "", fmt.Errorf("%s: %w", edit, err)
+			}
+		}
+	}
+	return doc.String(), nil
+}
+
+// PatchResult is result of PatchConfigure.
+type PatchResult struct {
+	// config.xml before and after edits
+	Before, After string
+	// unified diff of Before and After in canonical form, see DiffXML,
+	// empty if nothing is changed
+	Diff string
+	// number of times config.xml is read and edited
+	Attempts int
+}
+
+// PatchConfigure applies edits on config.xml of job. The config is read
+// again before it is posted, edits are applied on the new config if it is
+// changed by others, ErrConflict is returned if it is still changed after 3
+// attempts. Config is not posted if edits change nothing.
+//
+// After it is posted, the config is read once more to verify that elements
+// edited are as edited, ErrConflict is returned if they are not, e.g. another
+// write lands after ours. Other changes, e.g. defaults added by jenkins, are
+// ignored. Jenkins has no conditional write of config.xml, so this is
+// best-effort: a write of others between the last read and the post is
+// overwritten.
+func (j *Job) PatchConfigure(edits ...ConfigEdit) (*PatchResult, error) {
+	return j.PatchConfigureCtx(context.Background(), edits...)
+}
+
+func (j *Job) PatchConfigureCtx(ctx context.Context, edits ...ConfigEdit) (*PatchResult, error) {
+	before, err := j.GetConfigureCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for attempt := 1; attempt <= maxPatchAttempts; attempt++ {
+		after, err := applyEdits(before, edits)
+		if err != nil {
+			return nil, err
+		}
+		diff, err := DiffXML(before, after)
+		if err != nil {
+			return nil, err
+		}
+		result := &PatchResult{
+			Before:   before,
+			After:    after,
+			Diff:     diff.Unified("a/config.xml", "b/config.xml"),
+			Attempts: attempt,
+		}
+		if after == before {
+			return result, nil
+		}
+		current, err := j.GetConfigureCtx(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if current != before {
+			before = current
+			continue
+		}
+		resp, err := j.SetConfigureCtx(ctx, strings.NewReader(after))
+		if err != nil {
+			return nil, err
+		}
+		resp.Body.Close()
+		if current, err = j.GetConfigureCtx(ctx); err != nil {
+			return nil, err
+		}
+		if err := verifyEdits(current, after, edits); err != nil {
+			return nil, newError(ErrConflict, "config.xml of %s is changed after it is posted: %v", j, err)
+		}
+		return result, nil
+	}
+	return nil, newError(ErrConflict, "config.xml of %s is changed during %d attempts", j, maxPatchAttempts)
+}
+
+// check that elements edited in after are the same in config
+func verifyEdits(config, after string, edits []ConfigEdit) error {
+	doc, err := parseXMLDoc(config)
+	if err != nil {
+		return err
+	}
+	edited, err := parseXMLDoc(after)
+	if err != nil {
+		return err
+	}
+	for _, edit := range edits {
+		matches, err := doc.selectPath(edit.path)
+		if err != nil {
+			return err
+		}
+		want, err := edited.selectPath(edit.path)
+		if err != nil {
+			return err
+		}
+		if !edit.applied(matches, want) {
+			return fmt.Errorf("%s is not in effect", edit)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+package jenkins
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const patchConf = `<?xml version='1.1' encoding='UTF-8'?>
+<project>
+  <!-- managed by go-jenkins -->
+  <description>old &amp; busted</description>
+  <properties>
+    <hudson.model.ParametersDefinitionProperty>
+      <parameterDefinitions>
+        <hudson.model.StringParameterDefinition>
+          <name>ARG1</name>
+          <defaultValue>a</defaultValue>
+        </hudson.model.StringParameterDefinition>
+        <hudson.model.StringParameterDefinition>
+          <name>ARG2</name>
+          <defaultValue>b</defaultValue>
+        </hudson.model.StringParameterDefinition>
+      </parameterDefinitions>
+    </hudson.model.ParametersDefinitionProperty>
+  </properties>
+  <scm class='hudson.scm.NullSCM'/>
+  <builders/>
+</project>`
+
+func TestPatchEdits(t *testing.T) {
+	// unedited document is kept byte for byte
+	text, err := applyEdits(patchConf, nil)
+	require.NoError(t, err)
+	assert.Equal(t, patchConf, text)
+
+	var tests = []struct {
+		edit   ConfigEdit
+		expect string
+	}{
+		{SetText("description", "new <shiny>"), `<description>new &lt;shiny&gt;</description>`},
+		{SetText("/project/description", ""), `<description></description>`},
+		{SetText("properties/*/parameterDefinitions/*[name='ARG2']/defaultValue", "c"), `
+          <name>ARG2</name>
+          <defaultValue>c</defaultValue>`},
+		{SetText("properties//parameterDefinitions/*[2]/defaultValue", "c"), ``},
+		{SetAttr("scm[@class='hudson.scm.NullSCM']", "class", `a"b`), `<scm class="a&quot;b"/>`},
+		{SetAttr("scm", "plugin", "x@1"), `<scm class="hudson.scm.NullSCM" plugin="x@1"/>`},
+		{InsertElement("builders", "<hudson.tasks.Shell><command>make</command></hudson.tasks.Shell>"),
+			`<builders><hudson.tasks.Shell><command>make</command></hudson.tasks.Shell></builders>`},
+		{InsertElement("properties/*/parameterDefinitions", "<p><name>ARG3</name></p>"), `
+          <defaultValue>b</defaultValue>
+        </hudson.model.StringParameterDefinition>
+        <p><name>ARG3</name></p>
+      </parameterDefinitions>`},
+		{RemoveElement("properties/*/parameterDefinitions/*[name='ARG1']"), `
+      <parameterDefinitions>
+        <hudson.model.StringParameterDefinition>
+          <name>ARG2</name>`},
+		{RemoveElement("properties"), `  <description>old &amp; busted</description>
+  <scm class='hudson.scm.NullSCM'/>`},
+	}
+	for _, test := range tests {
+		text, err := applyEdits(patchConf, []ConfigEdit{test.edit})
+		if test.expect == "" {
+			assert.Error(t, err, test.edit)
+			continue
+		}
+		require.NoError(t, err, test.edit)
+		assert.Contains(t, text, test.expect, test.edit)
+		assert.Contains(t, text, "<?xml version='1.1' encoding='UTF-8'?>\n<project>\n  <!-- managed by go-jenkins -->", test.edit)
+	}
+
+	for _, edit := range []ConfigEdit{
+		SetText("missing", "x"),
+		SetText("properties/*[3]", "x"),
+		SetAttr("scm[@class='other']", "class", "x"),
+	} {
+		_, err := applyEdits(patchConf, []ConfigEdit{edit})
+		assert.ErrorIs(t, err, ErrNotFound, edit)
+	}
+	for _, edit := range []ConfigEdit{
+		SetText("a[x]", "x"),
+		SetText("a[0]", "x"),
+		SetText("a[name='x'", "x"),
+		InsertElement("builders", "<a>"),
+		RemoveElement("/project/.."),
+	} {
+		_, err := applyEdits(patchConf, []ConfigEdit{edit})
+		assert.Error(t, err, edit)
+	}
+	_, err = applyEdits("<a><b></a>", nil)
+	assert.Error(t, err)
+}
+
+func TestPatchDiff(t *testing.T) {
+	assert.Equal(t, "", unifiedDiff("a", "b", "x\ny\n", "x\ny\n"))
+	assert.Equal(t, "--- a\n+++ b\n@@ -1,2 +1,2 @@\n x\n-y\n+z\n", unifiedDiff("a", "b", "x\ny", "x\nz"))
+	assert.Equal(t, "--- a\n+++ b\n@@ -0,0 +1 @@\n+x\n", unifiedDiff("a", "b", "", "x"))
+
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+	b := "1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\n11\ntwelve\n"
+	expect := `--- a
++++ b
+@@ -1,6 +1,6 @@
+ 1
+ 2
+-3
++three
+ 4
+ 5
+ 6
+@@ -9,4 +9,4 @@
+ 9
+ 10
+ 11
+-12
++twelve
+`
+	assert.Equal(t, expect, unifiedDiff("a", "b", a, b))
+}
+
+// serve config.xml, change is called on each read to simulate other writers
+func newPatchJenkins(t *testing.T, change func(reads int, config string) string) (*Job, func() (string, int)) {
+	var mu sync.Mutex
+	config, reads, writes := patchConf, 0, 0
+	client := newFakeJenkins(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path != "/job/p/config.xml" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Method == http.MethodPost {
+			data, _ := io.ReadAll(r.Body)
+			config = string(data)
+			writes++
+			return
+		}
+		reads++
+		if change != nil {
+			config = change(reads, config)
+		}
+		io.WriteString(w, config)
+	})
+	job := NewJob(client.URL+"job/p/", "hudson.model.FreeStyleProject", client)
+	return job, func() (string, int) {
+		mu.Lock()
+		defer mu.Unlock()
+		return config, writes
+	}
+}
+
+func TestPatchConfigure(t *testing.T) {
+	job, state := newPatchJenkins(t, nil)
+	result, err := job.PatchConfigure(SetText("description", "new"), SetAttr("scm", "plugin", "x@1"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Attempts)
+	assert.Equal(t, patchConf, result.Before)
+	assert.Equal(t, `--- a/config.xml
++++ b/config.xml
+@@ -1,5 +1,5 @@
+ <project>
+-  <description>old &amp; busted</description>
++  <description>new</description>
+   <properties>
+     <hudson.model.ParametersDefinitionProperty>
+       <parameterDefinitions>
+@@ -14,6 +14,6 @@
+       </parameterDefinitions>
+     </hudson.model.ParametersDefinitionProperty>
+   </properties>
+-  <scm class="hudson.scm.NullSCM"/>
++  <scm class="hudson.scm.NullSCM" plugin="x@1"/>
+   <builders/>
+ </project>
+`, result.Diff)
+	config, writes := state()
+	assert.Equal(t, result.After, config)
+	assert.Equal(t, 1, writes)
+
+	// nothing to post
+	result, err = job.PatchConfigure(SetText("description", "new"))
+	require.NoError(t, err)
+	assert.Empty(t, result.Diff)
+	_, writes = state()
+	assert.Equal(t, 1, writes)
+
+	_, err = job.PatchConfigure(SetText("missing", "new"))
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestPatchConfigureConflict(t *testing.T) {
+	// changed once between read and write, edits are applied on new config
+	job, state := newPatchJenkins(t, func(reads int, config string) string {
+		if reads == 2 {
+			return config + "\n<!-- changed -->"
+		}
+		return config
+	})
+	result, err := job.PatchConfigure(SetText("description", "new"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Attempts)
+	config, writes := state()
+	assert.Equal(t, 1, writes)
+	assert.Contains(t, config, "<description>new</description>")
+	assert.Contains(t, config, "<!-- changed -->")
+
+	// changed on every read
+	job, state = newPatchJenkins(t, func(reads int, config string) string {
+		return config + "\n<!-- changed -->"
+	})
+	_, err = job.PatchConfigure(SetText("description", "new"))
+	assert.ErrorIs(t, err, ErrConflict)
+	config, writes = state()
+	assert.Equal(t, 0, writes)
+	assert.NotContains(t, config, "<description>new</description>")
+}
+
+func TestPatchConfigureVerify(t *testing.T) {
+	// saved is called with config posted and returns config jenkins keeps,
+	// e.g. written by another writer right after ours
+	var mu sync.Mutex
+	config, writes := patchConf, 0
+	var saved func(config string) string
+	client := newFakeJenkins(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Method == http.MethodPost {
+			data, _ := io.ReadAll(r.Body)
+			config = saved(string(data))
+			writes++
+			return
+		}
+		io.WriteString(w, config)
+	})
+	job := NewJob(client.URL+"job/p/", "hudson.model.FreeStyleProject", client)
+
+	// other writes land after ours and keep edited elements
+	saved = func(config string) string {
+		config = strings.ReplaceAll(config, `"`, "'")
+		return strings.Replace(config, "</builders>", "<hudson.tasks.Shell/></builders>", 1)
+	}
+	result, err := job.PatchConfigure(InsertElement("builders", "<x/>"), SetAttr("scm", "plugin", "x@1"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Attempts)
+	assert.Equal(t, 1, writes)
+	assert.Equal(t, 1, strings.Count(config, "<x/>"))
+	assert.Contains(t, config, "<scm class='hudson.scm.NullSCM' plugin='x@1'/>")
+
+	// other write overwrites ours, edits are not applied again
+	saved = func(string) string { return patchConf }
+	for _, edit := range []ConfigEdit{
+		SetText("description", "new"),
+		SetAttr("scm", "plugin", "x@2"),
+		InsertElement("builders", "<y/>"),
+		RemoveElement("properties"),
+	} {
+		_, err = job.PatchConfigure(edit)
+		assert.ErrorIs(t, err, ErrConflict, edit)
+	}
+	assert.Equal(t, 5, writes)
+	assert.Equal(t, patchConf, config)
+}
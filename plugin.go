@@ -1,5 +1,41 @@
 package jenkins
 
+import "context"
+
 type Plugin struct {
 	*Item
 }
+
+type Plugins struct {
+	*Item
+}
+
+func (ps *Plugins) Get(shortName string) (*PluginJson, error) {
+	return ps.GetCtx(context.Background(), shortName)
+}
+
+func (ps *Plugins) GetCtx(ctx context.Context, shortName string) (*PluginJson, error) {
+	plugins, err := ps.ListCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range plugins {
+		if p.ShortName == shortName {
+			return p, nil
+		}
+	}
+	return nil, newError(ErrNotFound, "no such plugin [%s]", shortName)
+}
+
+// List installed plugins.
+func (ps *Plugins) List() ([]*PluginJson, error) {
+	return ps.ListCtx(context.Background())
+}
+
+func (ps *Plugins) ListCtx(ctx context.Context) ([]*PluginJson, error) {
+	manager := &PluginManager{}
+	if err := ps.ApiJsonCtx(ctx, manager, &ApiJsonOpts{Depth: 1}); err != nil {
+		return nil, err
+	}
+	return manager.Plugins, nil
+}
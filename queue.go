@@ -1,13 +1,17 @@
 package jenkins
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"sync"
 )
 
 type OneQueueItem struct {
 	*Item
-	ID    int
+	ID int
+	// guards build which is cached once it is available
+	mu    sync.Mutex
 	build *Build
 }
 
@@ -20,22 +24,35 @@ func NewQueueItem(url string, jenkins *Jenkins) *OneQueueItem {
 }
 
 func (q *OneQueueItem) GetJob() (*Job, error) {
+	return q.GetJobCtx(context.Background())
+}
+
+func (q *OneQueueItem) GetJobCtx(ctx context.Context) (*Job, error) {
 	var queueJson QueueItem
-	if err := q.ApiJson(&queueJson, nil); err != nil {
+	if err := q.ApiJsonCtx(ctx, &queueJson, nil); err != nil {
 		return nil, err
 	}
 	if parseClass(queueJson.Class) == "BuildableItem" {
-		return q.build.GetJob()
+		q.mu.Lock()
+		build := q.build
+		q.mu.Unlock()
+		return build.GetJobCtx(ctx)
 	}
 	return NewJob(queueJson.Task.URL, queueJson.Task.Class, q.jenkins), nil
 }
 
 func (q *OneQueueItem) GetBuild() (*Build, error) {
+	return q.GetBuildCtx(context.Background())
+}
+
+func (q *OneQueueItem) GetBuildCtx(ctx context.Context) (*Build, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
 	if q.build != nil {
 		return q.build, nil
 	}
 	var queueJson QueueItem
-	if err := q.ApiJson(&queueJson, nil); err != nil {
+	if err := q.ApiJsonCtx(ctx, &queueJson, nil); err != nil {
 		return nil, err
 	}
 	var err error
@@ -43,13 +60,13 @@ func (q *OneQueueItem) GetBuild() (*Build, error) {
 	case "LeftItem":
 		q.build = NewBuild(queueJson.Executable.URL, queueJson.Executable.Class, q.jenkins)
 	case "BuildableItem", "WaitingItem":
-		q.build, err = q.getWaitingBuild()
+		q.build, err = q.getWaitingBuild(ctx)
 	}
 	return q.build, err
 }
 
-func (q *OneQueueItem) getWaitingBuild() (*Build, error) {
-	builds, err := q.jenkins.Nodes().GetBuilds()
+func (q *OneQueueItem) getWaitingBuild(ctx context.Context) (*Build, error) {
+	builds, err := q.jenkins.Nodes().GetBuildsCtx(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -58,7 +75,7 @@ func (q *OneQueueItem) getWaitingBuild() (*Build, error) {
 		QueueId int    `json:"queueId"`
 	}
 	for _, build := range builds {
-		if err := build.ApiJson(&buildJson, &ApiJsonOpts{Tree: "queueId"}); err != nil {
+		if err := build.ApiJsonCtx(ctx, &buildJson, &ApiJsonOpts{Tree: "queueId"}); err != nil {
 			return nil, err
 		}
 		if buildJson.QueueId == q.ID {
@@ -74,8 +91,12 @@ type Queue struct {
 }
 
 func (q *Queue) List() ([]*OneQueueItem, error) {
+	return q.ListCtx(context.Background())
+}
+
+func (q *Queue) ListCtx(ctx context.Context) ([]*OneQueueItem, error) {
 	queue := &QueueJson{}
-	if err := q.ApiJson(queue, nil); err != nil {
+	if err := q.ApiJsonCtx(ctx, queue, nil); err != nil {
 		return nil, err
 	}
 	var items []*OneQueueItem
@@ -86,8 +107,12 @@ func (q *Queue) List() ([]*OneQueueItem, error) {
 }
 
 func (q *Queue) Get(id int) (*OneQueueItem, error) {
+	return q.GetCtx(context.Background(), id)
+}
+
+func (q *Queue) GetCtx(ctx context.Context, id int) (*OneQueueItem, error) {
 	var queue QueueJson
-	if err := q.ApiJson(&queue, nil); err != nil {
+	if err := q.ApiJsonCtx(ctx, &queue, nil); err != nil {
 		return nil, err
 	}
 	for _, item := range queue.Items {
@@ -95,9 +120,13 @@ func (q *Queue) Get(id int) (*OneQueueItem, error) {
 			return NewQueueItem(item.URL, q.jenkins), nil
 		}
 	}
-	return nil, fmt.Errorf("no such queue item #%d", id)
+	return nil, newError(ErrNotFound, "no such queue item #%d", id)
 }
 
 func (q *Queue) Cancel(id int) (*http.Response, error) {
-	return q.Request("POST", fmt.Sprintf("cancelItem?id=%d", id), nil)
+	return q.CancelCtx(context.Background(), id)
+}
+
+func (q *Queue) CancelCtx(ctx context.Context, id int) (*http.Response, error) {
+	return q.RequestCtx(ctx, "POST", fmt.Sprintf("cancelItem?id=%d", id), nil)
 }
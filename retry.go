@@ -0,0 +1,181 @@
+package jenkins
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy decides whether and when a failed request is sent again.
+// Idempotent requests (GET, HEAD) are retried on 5xx and network errors,
+// other requests are retried only if they were never sent, eg: connection
+// refused. A request rejected because of invalid crumb is always sent once
+// more with a new crumb, regardless of policy.
+type RetryPolicy struct {
+	// max number of attempts including the first one, 0 or 1 disables retry
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// randomize backoff by +/- Jitter fraction, eg: 0.2
+	Jitter float64
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    4,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     30 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
+// Retry failed requests with policy, eg:
+//
+//	jenkins.NewWithOptions(url, jenkins.WithRetryPolicy(jenkins.DefaultRetryPolicy))
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *JenkinsOpts) error {
+		o.RetryPolicy = &policy
+		return nil
+	}
+}
+
+func (p *RetryPolicy) canRetry(attempt int) bool {
+	return p != nil && attempt < p.MaxAttempts
+}
+
+func (p *RetryPolicy) retryError(method string, err error) bool {
+	return notSent(err) || isIdempotent(method)
+}
+
+func (p *RetryPolicy) retryStatus(method string, status int) bool {
+	return isIdempotent(method) && status >= 500 && status != http.StatusNotImplemented
+}
+
+// delay before next attempt, it is not less than Retry-After of resp
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	d := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	d *= 1 + p.Jitter*(2*rand.Float64()-1)
+	delay := time.Duration(d)
+	if after, ok := parseRetryAfter(resp); ok && after > delay {
+		delay = after
+	}
+	return delay
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS":
+		return true
+	}
+	return false
+}
+
+// whether err occurred before request was written to server
+func notSent(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && (opErr.Op == "dial" || opErr.Op == "proxyconnect") {
+		return true
+	}
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr)
+}
+
+// parse Retry-After header in seconds or http date
+func parseRetryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// retry request according to policy of c and re-issue crumb once if it is
+// rejected
+func (c *Jenkins) retryInterceptor(next Doer) Doer {
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+		ctx := req.Context()
+		reissued := false
+		for attempt := 1; ; attempt++ {
+			r, err := requestForAttempt(req, attempt)
+			if err != nil {
+				return nil, err
+			}
+			resp, err := next.Do(r)
+			if err != nil {
+				if ctx.Err() != nil || !c.retry.canRetry(attempt) || !c.retry.retryError(req.Method, err) {
+					return nil, err
+				}
+			} else {
+				if resp.StatusCode < 400 {
+					return resp, nil
+				}
+				if stale := crumbFromContext(ctx); stale != nil && !reissued && isCrumbRejected(resp) {
+					resp.Body.Close()
+					reissued = true
+					attempt--
+					c.resetCrumb(stale)
+					if _, err := c.GetCrumbCtx(ctx); err != nil {
+						return nil, err
+					}
+					req = req.Clone(ctx)
+					req.Header, _ = c.requestHeader(true)
+					continue
+				}
+				if !c.retry.canRetry(attempt) || !c.retry.retryStatus(req.Method, resp.StatusCode) {
+					return resp, nil
+				}
+				resp.Body.Close()
+			}
+			if err := sleepCtx(ctx, c.retry.backoff(attempt, resp)); err != nil {
+				return nil, err
+			}
+		}
+	})
+}
+
+// copy of req with attempt in context and fresh body
+func requestForAttempt(req *http.Request, attempt int) (*http.Request, error) {
+	r := req.Clone(context.WithValue(req.Context(), attemptKey{}, attempt))
+	if req.Body != nil && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		r.Body = body
+	}
+	return r, nil
+}
+
+func isCrumbRejected(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusForbidden {
+		return false
+	}
+	e := &APIError{
+		StatusCode:   resp.StatusCode,
+		Status:       resp.Status,
+		Body:         string(peekBody(resp, maxErrorBody)),
+		JenkinsError: resp.Header.Get("X-Error"),
+	}
+	return e.isCrumbInvalid()
+}
@@ -0,0 +1,139 @@
+package jenkins
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testRetryPolicy = RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond, Multiplier: 2}
+
+// fail the first n dials with connection refused
+type refusedTransport struct {
+	n int32
+	http.RoundTripper
+}
+
+func (rt *refusedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if atomic.AddInt32(&rt.n, -1) >= 0 {
+		return nil, &net.OpError{Op: "dial", Net: "tcp", Err: fmt.Errorf("connection refused")}
+	}
+	return rt.RoundTripper.RoundTrip(req)
+}
+
+func TestRetryIdempotent(t *testing.T) {
+	var calls int32
+	j := newFakeJenkins(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"description":"restarted"}`)
+	})
+	j.retry = &testRetryPolicy
+	job := NewJob(j.URL+"job/pipeline/", "WorkflowJob", j)
+	description, err := job.GetDescription()
+	assert.Nil(t, err)
+	assert.Equal(t, "restarted", description)
+	assert.Equal(t, int32(3), calls)
+
+	// give up after MaxAttempts
+	atomic.StoreInt32(&calls, -10)
+	_, err = job.GetDescription()
+	var apiErr *APIError
+	assert.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+	assert.Equal(t, int32(-7), calls)
+}
+
+func TestRetryNotIdempotent(t *testing.T) {
+	var calls int32
+	var bodies []string
+	j := newFakeJenkins(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		data, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(data))
+		if r.URL.Path != "/job/pipeline/config.xml" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+	j.retry = &testRetryPolicy
+	job := NewJob(j.URL+"job/pipeline/", "WorkflowJob", j)
+	_, err := job.Build(nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), calls)
+
+	// request is retried with same body if it was never sent
+	atomic.StoreInt32(&calls, 0)
+	bodies = nil
+	_, err = j.GetCrumb()
+	assert.Nil(t, err)
+	j.SetClient(&http.Client{Transport: &refusedTransport{n: 2, RoundTripper: http.DefaultTransport}})
+	_, err = job.SetConfigure(strings.NewReader("<project/>"))
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), calls)
+	assert.Equal(t, []string{"<project/>"}, bodies)
+}
+
+func TestCrumbReissue(t *testing.T) {
+	var crumbs, calls int32
+	var rejectAll atomic.Bool
+	mux := http.NewServeMux()
+	mux.HandleFunc("/crumbIssuer/api/json", func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&crumbs, 1)
+		fmt.Fprintf(w, `{"crumbRequestField":"Jenkins-Crumb","crumb":"crumb-%d"}`, n)
+	})
+	mux.HandleFunc("/job/pipeline/disable", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if rejectAll.Load() || r.Header.Get("Jenkins-Crumb") != "crumb-2" {
+			http.Error(w, "No valid crumb was included in the request", http.StatusForbidden)
+		}
+	})
+	j := newTestJenkins(t, mux)
+	_, err := j.GetCrumb()
+	assert.Nil(t, err)
+	job := NewJob(j.URL+"job/pipeline/", "WorkflowJob", j)
+	_, err = job.Disable()
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), crumbs)
+	assert.Equal(t, int32(2), calls)
+
+	// crumb is re-issued only once
+	rejectAll.Store(true)
+	atomic.StoreInt32(&crumbs, 10)
+	atomic.StoreInt32(&calls, 0)
+	_, err = job.Disable()
+	assert.ErrorIs(t, err, ErrCrumbInvalid)
+	assert.Equal(t, int32(11), crumbs)
+	assert.Equal(t, int32(2), calls)
+}
+
+func TestRetryCtxCancel(t *testing.T) {
+	j := newFakeJenkins(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	j.retry = &RetryPolicy{MaxAttempts: 10, InitialBackoff: time.Hour}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := NewJob(j.URL+"job/pipeline/", "WorkflowJob", j).GetDescriptionCtx(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestRetryBackoff(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second, Multiplier: 2, Jitter: 0.5}
+	for attempt, expect := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second} {
+		d := p.backoff(attempt+1, nil)
+		assert.GreaterOrEqual(t, d, expect/2)
+		assert.LessOrEqual(t, d, expect*3/2)
+	}
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"120"}}}
+	assert.Equal(t, 2*time.Minute, p.backoff(1, resp))
+}
@@ -0,0 +1,276 @@
+package jenkins
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+)
+
+// DesiredJob is a job as it should be on jenkins.
+type DesiredJob struct {
+	// full name relative to SyncOpts.Root, e.g. "team/app"
+	Name   string
+	Config string
+}
+
+// LoadDesired loads jobs from config.xml files which are laid out by folder
+// path, e.g. team/app/config.xml is job team/app, folders come before their
+// children:
+//
+//	desired, err := jenkins.LoadDesired(os.DirFS("jobs"))
+func LoadDesired(fsys fs.FS) ([]DesiredJob, error) {
+	var desired []DesiredJob
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || d.Name() != "config.xml" {
+			return err
+		}
+		name := path.Dir(p)
+		if name == "." {
+			return fmt.Errorf("%s is not in folder of job", p)
+		}
+		data, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+		desired = append(desired, DesiredJob{Name: name, Config: string(data)})
+		return nil
+	})
+	slices.SortFunc(desired, func(a, b DesiredJob) int { return compareJobNames(a.Name, b.Name) })
+	return desired, err
+}
+
+// order jobs by full name, folders come before their children
+func compareJobNames(a, b string) int {
+	return slices.Compare(strings.Split(a, "/"), strings.Split(b, "/"))
+}
+
+// SyncAction is what ApplySync does for a job.
+type SyncAction string
+
+const (
+	// job is desired but not on jenkins
+	SyncCreate SyncAction = "create"
+	// job is on jenkins but its config differs from desired
+	SyncUpdate SyncAction = "update"
+	// job is on jenkins as desired
+	SyncUnchanged SyncAction = "unchanged"
+	// job is on jenkins but not desired, it is deleted if SyncOpts.Prune
+	SyncOrphan SyncAction = "orphan"
+)
+
+// SyncEntry is planned action for a job.
+type SyncEntry struct {
+	// full name of job on jenkins
+	Name   string
+	Action SyncAction
+	// desired config.xml, empty for orphan
+	Config string
+	// diff of live and desired config.xml after comments, whitespace and
+	// order of attributes are normalized, empty if nothing changes
+	Diff string
+	// changes from live to desired config.xml of job to update, see DiffXML
+	Changes []XMLChange
+}
+
+type SyncOpts struct {
+	// full name of folder desired jobs are relative to, top level if empty.
+	// Jobs outside it are never orphans
+	Root string
+	// delete orphans on apply
+	Prune bool
+}
+
+// SyncPlan is result of comparing desired jobs with jenkins, entries are
+// ordered by full name so folders come before their children.
+type SyncPlan struct {
+	Entries []SyncEntry
+	opts    SyncOpts
+}
+
+// Count returns number of entries of action.
+func (p *SyncPlan) Count(action SyncAction) int {
+	n := 0
+	for _, entry := range p.Entries {
+		if entry.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// HasChanges reports whether ApplySync changes anything.
+func (p *SyncPlan) HasChanges() bool {
+	return p.Count(SyncCreate)+p.Count(SyncUpdate) > 0 || (p.opts.Prune && p.Count(SyncOrphan) > 0)
+}
+
+// String shows changes of plan with their diff, e.g.
+//
+//	create team/app
+//	update team/lib
+//	--- live/team/lib/config.xml
+//	+++ desired/team/lib/config.xml
+//	...
+//	orphan team/old
+//	Plan: 1 to create, 1 to update, 1 orphan, 2 unchanged
+func (p *SyncPlan) String() string {
+	var b strings.Builder
+	for _, entry := range p.Entries {
+		if entry.Action == SyncUnchanged {
+			continue
+		}
+		fmt.Fprintf(&b, "%s %s\n", entry.Action, entry.Name)
+		b.WriteString(entry.Diff)
+	}
+	orphan := "orphan"
+	if p.opts.Prune {
+		orphan = "to prune"
+	}
+	fmt.Fprintf(&b, "Plan: %d to create, %d to update, %d %s, %d unchanged\n",
+		p.Count(SyncCreate), p.Count(SyncUpdate), p.Count(SyncOrphan), orphan, p.Count(SyncUnchanged))
+	return b.String()
+}
+
+func (c *Jenkins) PlanSync(desired []DesiredJob, opts *SyncOpts) (*SyncPlan, error) {
+	return c.PlanSyncCtx(context.Background(), desired, opts)
+}
+
+// PlanSync compares desired jobs with jobs under SyncOpts.Root, nothing is
+// changed until the plan is applied:
+//
+//	plan, err := client.PlanSyncCtx(ctx, desired, &jenkins.SyncOpts{Root: "team", Prune: true})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Print(plan)
+//	err = client.ApplySyncCtx(ctx, plan)
+//
+// Jobs in multibranch projects and organization folders are generated, so
+// they are not walked into.
+func (c *Jenkins) PlanSyncCtx(ctx context.Context, desired []DesiredJob, opts *SyncOpts) (*SyncPlan, error) {
+	plan := &SyncPlan{}
+	if opts != nil {
+		plan.opts = *opts
+	}
+	root := strings.Trim(plan.opts.Root, "/")
+	plan.opts.Root = root
+	fullName := func(name string) string {
+		return strings.Trim(path.Join(root, name), "/")
+	}
+
+	folder := NewJob(c.URL, "Folder", c)
+	if root != "" {
+		var err error
+		if folder, err = c.GetJobCtx(ctx, root); err != nil {
+			return nil, err
+		}
+	}
+	live := map[string]*Job{}
+	for job, err := range folder.WalkCtx(ctx, &WalkOpts{Skip: func(job *Job) bool { return job.Class != "Folder" }}) {
+		if err != nil {
+			return nil, err
+		}
+		live[job.FullName] = job
+	}
+
+	wanted := map[string]bool{}
+	for _, d := range desired {
+		name := fullName(d.Name)
+		if name == "" || name == root || (root != "" && !strings.HasPrefix(name, root+"/")) ||
+			slices.Contains(strings.Split(d.Name, "/"), "..") {
+			return nil, fmt.Errorf("invalid name of desired job: %q", d.Name)
+		}
+		if wanted[name] {
+			return nil, newError(ErrAlreadyExists, "desired job %s is duplicated", name)
+		}
+		wanted[name] = true
+	}
+	for _, d := range desired {
+		name := fullName(d.Name)
+		want, err := canonicalXML(d.Config)
+		if err != nil {
+			return nil, fmt.Errorf("config of %s: %w", name, err)
+		}
+		entry := SyncEntry{Name: name, Config: d.Config}
+		job, ok := live[name]
+		if !ok {
+			parent := path.Dir(name)
+			if parent != "." && parent != root && !wanted[parent] && live[parent] == nil {
+				return nil, newError(ErrNotFound, "folder of %s is neither desired nor on jenkins", name)
+			}
+			entry.Action = SyncCreate
+			entry.Diff = unifiedDiff("live/"+name+"/config.xml", "desired/"+name+"/config.xml", "", want)
+			plan.Entries = append(plan.Entries, entry)
+			continue
+		}
+		text, err := job.GetConfigureCtx(ctx)
+		if err != nil {
+			return nil, err
+		}
+		diff, err := DiffXML(text, d.Config)
+		if err != nil {
+			return nil, fmt.Errorf("live config of %s: %w", name, err)
+		}
+		entry.Action = SyncUnchanged
+		if !diff.Equal() {
+			entry.Action = SyncUpdate
+			entry.Diff = diff.Unified("live/"+name+"/config.xml", "desired/"+name+"/config.xml")
+			entry.Changes = diff.Changes
+		}
+		plan.Entries = append(plan.Entries, entry)
+	}
+	for name := range live {
+		if !wanted[name] {
+			plan.Entries = append(plan.Entries, SyncEntry{Name: name, Action: SyncOrphan})
+		}
+	}
+	slices.SortFunc(plan.Entries, func(a, b SyncEntry) int { return compareJobNames(a.Name, b.Name) })
+	return plan, nil
+}
+
+func (c *Jenkins) ApplySync(plan *SyncPlan) error {
+	return c.ApplySyncCtx(context.Background(), plan)
+}
+
+// ApplySync creates and updates jobs in order of plan, then deletes orphans
+// if plan is made with SyncOpts.Prune, children of deleted folder are
+// deleted with it. It stops at the first failure.
+func (c *Jenkins) ApplySyncCtx(ctx context.Context, plan *SyncPlan) error {
+	for _, entry := range plan.Entries {
+		var resp *http.Response
+		var err error
+		switch entry.Action {
+		case SyncCreate:
+			resp, err = c.CreateJobCtx(ctx, entry.Name, strings.NewReader(entry.Config))
+		case SyncUpdate:
+			resp, err = NewJob(c.Name2URL(entry.Name), "Job", c).SetConfigureCtx(ctx, strings.NewReader(entry.Config))
+		default:
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%s %s: %w", entry.Action, entry.Name, err)
+		}
+		resp.Body.Close()
+	}
+	if !plan.opts.Prune {
+		return nil
+	}
+	var deleted []string
+	for _, entry := range plan.Entries {
+		if entry.Action != SyncOrphan || slices.ContainsFunc(deleted, func(folder string) bool {
+			return strings.HasPrefix(entry.Name, folder+"/")
+		}) {
+			continue
+		}
+		resp, err := c.DeleteJobCtx(ctx, entry.Name)
+		if err != nil {
+			return fmt.Errorf("delete %s: %w", entry.Name, err)
+		}
+		resp.Body.Close()
+		deleted = append(deleted, entry.Name)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package jenkins
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/joelee2012/go-jenkins/jenkinstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const syncJobConf = `<?xml version='1.1' encoding='UTF-8'?>
+<project>
+  <description>app</description>
+  <scm class="hudson.scm.NullSCM"/>
+  <builders>
+    <hudson.tasks.Shell>
+      <command>make</command>
+    </hudson.tasks.Shell>
+  </builders>
+</project>`
+
+// syncJobConf in different layout
+const syncJobConfFormatted = `<?xml version='1.0' encoding='UTF-8'?>
+<!-- managed in git -->
+<project><description>app</description><scm class='hudson.scm.NullSCM'></scm>
+<builders><hudson.tasks.Shell><command>make</command></hudson.tasks.Shell></builders></project>
+`
+
+func TestLoadDesired(t *testing.T) {
+	desired, err := LoadDesired(fstest.MapFS{
+		"team/config.xml":       {Data: []byte(folderConf)},
+		"team/app/config.xml":   {Data: []byte(syncJobConf)},
+		"team/app/README.md":    {Data: []byte("app")},
+		"other/empty/README.md": {Data: []byte("no job")},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []DesiredJob{{"team", folderConf}, {"team/app", syncJobConf}}, desired)
+
+	_, err = LoadDesired(fstest.MapFS{"config.xml": {Data: []byte(folderConf)}})
+	assert.Error(t, err)
+}
+
+func TestSyncPlanApply(t *testing.T) {
+	srv := jenkinstest.NewServer()
+	defer srv.Close()
+	for _, job := range []DesiredJob{
+		{"team", folderConf},
+		{"team/app", syncJobConf},
+		{"team/old", folderConf},
+		{"team/old/nested", syncJobConf},
+		{"other", syncJobConf},
+	} {
+		require.NoError(t, srv.CreateJob(job.Name, job.Config))
+	}
+	client, err := New(srv.URL, "admin", "1234")
+	require.NoError(t, err)
+
+	desired := []DesiredJob{
+		{"lib/build", syncJobConf},
+		{"app", syncJobConfFormatted},
+		{"lib", folderConf},
+	}
+	plan, err := client.PlanSync(desired, &SyncOpts{Root: "team"})
+	require.NoError(t, err)
+	var actions []string
+	for _, entry := range plan.Entries {
+		actions = append(actions, string(entry.Action)+" "+entry.Name)
+	}
+	assert.Equal(t, []string{"unchanged team/app", "create team/lib", "create team/lib/build", "orphan team/old", "orphan team/old/nested"}, actions)
+	assert.True(t, plan.HasChanges())
+	assert.Contains(t, plan.Entries[2].Diff, "+++ desired/team/lib/build/config.xml\n@@ -0,0 +1,9 @@\n+<project>\n+  <description>app</description>\n")
+	assert.Contains(t, plan.String(), "Plan: 2 to create, 0 to update, 2 orphan, 1 unchanged\n")
+
+	require.NoError(t, client.ApplySync(plan))
+	for _, name := range []string{"team/lib/build", "team/old/nested", "other"} {
+		_, err := client.GetJob(name)
+		assert.NoError(t, err, name)
+	}
+
+	// update and prune
+	desired[1].Config = syncJobConfFormatted + "<!-- -->"
+	desired[0].Config = `<project><description>new</description></project>`
+	plan, err = client.PlanSync(desired, &SyncOpts{Root: "/team/", Prune: true})
+	require.NoError(t, err)
+	assert.Equal(t, SyncUnchanged, plan.Entries[0].Action)
+	assert.Equal(t, SyncUpdate, plan.Entries[2].Action)
+	assert.Equal(t, `--- live/team/lib/build/config.xml
++++ desired/team/lib/build/config.xml
+@@ -1,9 +1,3 @@
+ <project>
+-  <description>app</description>
+-  <scm class="hudson.scm.NullSCM"/>
+-  <builders>
+-    <hudson.tasks.Shell>
+-      <command>make</command>
+-    </hudson.tasks.Shell>
+-  </builders>
++  <description>new</description>
+ </project>
+`, plan.Entries[2].Diff)
+	assert.Equal(t, XMLChange{Op: XMLChanged, Path: "/project/description", Old: "app", New: "new"}, plan.Entries[2].Changes[0])
+	assert.Len(t, plan.Entries[2].Changes, 3)
+	assert.Contains(t, plan.String(), "Plan: 0 to create, 1 to update, 2 to prune, 2 unchanged\n")
+	require.NoError(t, client.ApplySync(plan))
+
+	job, err := client.GetJob("team/lib/build")
+	require.NoError(t, err)
+	description, err := job.GetDescription()
+	require.NoError(t, err)
+	assert.Equal(t, "new", description)
+	_, err = client.GetJob("team/old")
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = client.GetJob("other")
+	assert.NoError(t, err)
+
+	plan, err = client.PlanSync(desired, &SyncOpts{Root: "team", Prune: true})
+	require.NoError(t, err)
+	assert.False(t, plan.HasChanges())
+
+	for _, desired := range [][]DesiredJob{
+		{{"missing/job", syncJobConf}},
+		{{"app", syncJobConf}, {"app/", syncJobConf}},
+		{{"../other", syncJobConf}},
+		{{"a/../../other/x", syncJobConf}},
+		{{"a/../b", syncJobConf}},
+		{{"app", "<project>"}},
+	} {
+		_, err := client.PlanSync(desired, &SyncOpts{Root: "team"})
+		assert.Error(t, err, desired)
+	}
+	for _, name := range []string{"..", "a/..", "../x", "/"} {
+		_, err := client.PlanSync([]DesiredJob{{name, syncJobConf}}, nil)
+		assert.Error(t, err, name)
+	}
+	_, err = client.PlanSync(desired, &SyncOpts{Root: "missing"})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,162 @@
+package jenkins
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// TreeOption customizes the tree query derived by ApiJSON and Tree.
+type TreeOption func(*treeOpts)
+
+type treeOpts struct {
+	depth  int
+	ranges map[string]string
+}
+
+// WithTreeDepth expands recursive types, e.g. jobs of a folder containing
+// jobs, depth extra levels below the first one, same as the nesting of
+// Job.List(depth). It is also sent to jenkins as the depth parameter.
+func WithTreeDepth(depth int) TreeOption {
+	return func(o *treeOpts) {
+		o.depth = depth
+	}
+}
+
+// WithTreeRange limits the array at field to elements [from, to), field is
+// the dot separated path of json names, e.g. "allBuilds" or "jobs.builds".
+// It overrides range given by the tree tag.
+func WithTreeRange(field string, from, to int) TreeOption {
+	return func(o *treeOpts) {
+		o.ranges[field] = fmt.Sprintf("{%d,%d}", from, to)
+	}
+}
+
+func newTreeOpts(opts []TreeOption) *treeOpts {
+	o := &treeOpts{ranges: map[string]string{}}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// Fetch api/json of r and decode it into a new T, the tree query is derived
+// from json tags of T so only decoded fields are transferred:
+//
+//	type jobSummary struct {
+//		Name   string `json:"name"`
+//		Builds []struct {
+//			Number int `json:"number"`
+//		} `json:"builds" tree:"{0,10}"`
+//	}
+//	// GET job/pipeline/api/json?tree=name,builds[number]{0,10}
+//	summary, err := jenkins.ApiJSON[jobSummary](job)
+func ApiJSON[T any](r Requester, opts ...TreeOption) (*T, error) {
+	return ApiJSONCtx[T](context.Background(), r, opts...)
+}
+
+func ApiJSONCtx[T any](ctx context.Context, r Requester, opts ...TreeOption) (*T, error) {
+	o := newTreeOpts(opts)
+	v := new(T)
+	tree := buildTree(reflect.TypeOf(v).Elem(), o)
+	if err := unmarshalApiJson(ctx, r, v, &ApiJsonOpts{Tree: tree, Depth: o.depth}); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Tree returns the tree query derived from json tags of T. Fields tagged
+// with json:"-" or tree:"-" and _class, which jenkins always returns, are
+// skipped. A tree tag of form {m,n} selects a range of an array field.
+func Tree[T any](opts ...TreeOption) string {
+	return buildTree(reflect.TypeOf((*T)(nil)).Elem(), newTreeOpts(opts))
+}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+func buildTree(t reflect.Type, o *treeOpts) string {
+	t = indirectType(t)
+	if !isTreeStruct(t) {
+		return ""
+	}
+	// the document itself is not a level of recursion
+	fields, _ := treeFields(t, o, "", map[reflect.Type]int{t: -1})
+	return strings.Join(fields, ",")
+}
+
+// treeFields returns tree of struct t, ok is false if t has been expanded
+// as many times as allowed by depth.
+func treeFields(t reflect.Type, o *treeOpts, prefix string, seen map[reflect.Type]int) (fields []string, ok bool) {
+	if seen[t] > o.depth {
+		return nil, false
+	}
+	seen[t]++
+	defer func() { seen[t]-- }()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() && !f.Anonymous {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		tag := f.Tag.Get("tree")
+		if name == "-" || tag == "-" {
+			continue
+		}
+		ft := indirectType(f.Type)
+		// fields of embedded struct are promoted as encoding/json does
+		if f.Anonymous && name == "" && isTreeStruct(ft) {
+			sub, _ := treeFields(ft, o, prefix, seen)
+			fields = append(fields, sub...)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if name == "_class" {
+			continue
+		}
+		path := prefix + name
+		field := name
+		if elem := elemType(ft); isTreeStruct(elem) {
+			sub, ok := treeFields(elem, o, path+".", seen)
+			if !ok {
+				continue
+			}
+			if len(sub) > 0 {
+				field += "[" + strings.Join(sub, ",") + "]"
+			}
+		}
+		if r, ok := o.ranges[path]; ok {
+			field += r
+		} else if strings.HasPrefix(tag, "{") {
+			field += tag
+		}
+		fields = append(fields, field)
+	}
+	return fields, true
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+func elemType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		return indirectType(t.Elem())
+	}
+	return t
+}
+
+func isTreeStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct &&
+		!t.Implements(unmarshalerType) &&
+		!reflect.PointerTo(t).Implements(unmarshalerType)
+}
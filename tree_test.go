@@ -0,0 +1,57 @@
+package jenkins
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type treeBuild struct {
+	Class    string    `json:"_class"`
+	Number   int       `json:"number"`
+	URL      string    `json:"url"`
+	Artifact *struct{} `json:"artifact"`
+}
+
+type treeEmbedded struct {
+	Description string `json:"description"`
+}
+
+type treeJob struct {
+	treeEmbedded
+	Name     string       `json:"name"`
+	Builds   []*treeBuild `json:"builds" tree:"{0,10}"`
+	Jobs     []treeJob    `json:"jobs"`
+	Ignored  string       `json:"-"`
+	Skipped  string       `json:"skipped" tree:"-"`
+	Time     time.Time    `json:"time"`
+	Property []string     `json:"property,omitempty"`
+	internal string
+}
+
+func TestTree(t *testing.T) {
+	assert.Equal(t, "", Tree[map[string]string]())
+	assert.Equal(t, "number,url,artifact", Tree[treeBuild]())
+	assert.Equal(t, "description,name,builds[number,url,artifact]{0,10},jobs[description,name,builds[number,url,artifact]{0,10},time,property],time,property",
+		Tree[treeJob]())
+	assert.Equal(t, "description,name,builds[number,url,artifact]{5,15},jobs[description,name,builds[number,url,artifact]{0,3},time,property],time,property",
+		Tree[*treeJob](WithTreeRange("builds", 5, 15), WithTreeRange("jobs.builds", 0, 3)))
+	// same as query built by Job.List(depth) before
+	assert.Equal(t, "name,url,jobs[name,url]", Tree[jobTree]())
+	assert.Equal(t, "name,url,jobs[name,url,jobs[name,url,jobs[name,url]]]", Tree[jobTree](WithTreeDepth(2)))
+}
+
+func TestApiJSONCtx(t *testing.T) {
+	j := newFakeJenkins(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/job/pipeline/api/json", r.URL.Path)
+		assert.Equal(t, "number,url,artifact", r.URL.Query().Get("tree"))
+		assert.Equal(t, "0", r.URL.Query().Get("depth"))
+		w.Write([]byte(`{"_class":"hudson.model.FreeStyleBuild","number":3,"url":"http://x/job/pipeline/3/"}`))
+	})
+	build, err := ApiJSON[treeBuild](NewJob(j.Name2URL("pipeline"), "WorkflowJob", j))
+	assert.Nil(t, err)
+	assert.Equal(t, 3, build.Number)
+	assert.Equal(t, "hudson.model.FreeStyleBuild", build.Class)
+}
@@ -219,6 +219,24 @@ type Property struct {
 	ParameterDefinitions []*ParameterDefinition `json:"parameterDefinitions,omitempty"`
 }
 
+type PluginManager struct {
+	Class   string        `json:"_class"`
+	Plugins []*PluginJson `json:"plugins"`
+}
+
+type PluginJson struct {
+	Active    bool   `json:"active"`
+	Enabled   bool   `json:"enabled"`
+	HasUpdate bool   `json:"hasUpdate"`
+	LongName  string `json:"longName"`
+	ShortName string `json:"shortName"`
+	Version   string `json:"version"`
+}
+
+func (p PluginJson) String() string {
+	return fmt.Sprintf("<Plugin: %s@%s>", p.ShortName, p.Version)
+}
+
 type ComputerSet struct {
 	Class          string      `json:"_class"`
 	BusyExecutors  int         `json:"busyExecutors"`
@@ -1,7 +1,7 @@
 package jenkins
 
 import (
-	"fmt"
+	"context"
 	"io"
 	"net/http"
 	"net/url"
@@ -12,8 +12,12 @@ type Views struct {
 }
 
 func (v *Views) Get(name string) (*ViewJson, error) {
+	return v.GetCtx(context.Background(), name)
+}
+
+func (v *Views) GetCtx(ctx context.Context, name string) (*ViewJson, error) {
 	jobJson := &JobJson{}
-	if err := v.ApiJson(jobJson, &ApiJsonOpts{Tree: "views[name,url,description]"}); err != nil {
+	if err := v.ApiJsonCtx(ctx, jobJson, &ApiJsonOpts{Tree: "views[name,url,description]"}); err != nil {
 		return nil, err
 	}
 	for _, view := range jobJson.Views {
@@ -21,49 +25,82 @@ func (v *Views) Get(name string) (*ViewJson, error) {
 			return view, nil
 		}
 	}
-	return nil, fmt.Errorf("%s has no view [%s]", v, name)
+	return nil, newError(ErrNotFound, "%s has no view [%s]", v, name)
 }
 
 func (v *Views) Create(name string, xml io.Reader) (*http.Response, error) {
+	return v.CreateCtx(context.Background(), name, xml)
+}
+
+func (v *Views) CreateCtx(ctx context.Context, name string, xml io.Reader) (*http.Response, error) {
 	p := url.Values{}
 	p.Add("name", name)
-	return v.Request("POST", "createView?"+p.Encode(), xml)
+	return v.RequestCtx(ctx, "POST", "createView?"+p.Encode(), xml)
 }
+
 func (v *Views) Delete(name string) (*http.Response, error) {
-	return v.Request("POST", "view/"+name+"/doDelete", nil)
+	return v.DeleteCtx(context.Background(), name)
+}
+
+func (v *Views) DeleteCtx(ctx context.Context, name string) (*http.Response, error) {
+	return v.RequestCtx(ctx, "POST", "view/"+name+"/doDelete", nil)
 }
 
 func (v *Views) AddJobToView(name, jobName string) (*http.Response, error) {
+	return v.AddJobToViewCtx(context.Background(), name, jobName)
+}
+
+func (v *Views) AddJobToViewCtx(ctx context.Context, name, jobName string) (*http.Response, error) {
 	p := url.Values{}
 	p.Add("name", jobName)
-	return v.Request("POST", "view/"+name+"/addJobToView?"+p.Encode(), nil)
+	return v.RequestCtx(ctx, "POST", "view/"+name+"/addJobToView?"+p.Encode(), nil)
 }
 
 func (v *Views) RemoveJobFromView(name, jobName string) (*http.Response, error) {
+	return v.RemoveJobFromViewCtx(context.Background(), name, jobName)
+}
+
+func (v *Views) RemoveJobFromViewCtx(ctx context.Context, name, jobName string) (*http.Response, error) {
 	p := url.Values{}
 	p.Add("name", jobName)
-	return v.Request("POST", "view/"+name+"/removeJobFromView?"+p.Encode(), nil)
+	return v.RequestCtx(ctx, "POST", "view/"+name+"/removeJobFromView?"+p.Encode(), nil)
 }
 
 func (v *Views) GetConfigure(name string) (string, error) {
-	return readResponseToString(v, "GET", "view/"+name+"/config.xml", nil)
+	return v.GetConfigureCtx(context.Background(), name)
+}
+
+func (v *Views) GetConfigureCtx(ctx context.Context, name string) (string, error) {
+	return readResponseToString(ctx, v, "GET", "view/"+name+"/config.xml", nil)
 }
 
 func (v *Views) SetConfigure(name string, xml io.Reader) (*http.Response, error) {
+	return v.SetConfigureCtx(context.Background(), name, xml)
+}
+
+func (v *Views) SetConfigureCtx(ctx context.Context, name string, xml io.Reader) (*http.Response, error) {
 	p := url.Values{}
 	p.Add("name", name)
-	return v.Request("POST", "view/"+name+"/config.xml?"+p.Encode(), xml)
+	return v.RequestCtx(ctx, "POST", "view/"+name+"/config.xml?"+p.Encode(), xml)
 }
 
 func (v *Views) SetDescription(name, description string) (*http.Response, error) {
+	return v.SetDescriptionCtx(context.Background(), name, description)
+}
+
+func (v *Views) SetDescriptionCtx(ctx context.Context, name, description string) (*http.Response, error) {
 	p := url.Values{}
 	p.Add("description", description)
-	return v.Request("POST", "view/"+name+"/submitDescription?"+p.Encode(), nil)
+	return v.RequestCtx(ctx, "POST", "view/"+name+"/submitDescription?"+p.Encode(), nil)
 }
 
 func (v *Views) List() ([]*ViewJson, error) {
+	return v.ListCtx(context.Background())
+}
+
+func (v *Views) ListCtx(ctx context.Context) ([]*ViewJson, error) {
 	jobJson := &JobJson{}
-	if err := v.ApiJson(jobJson, &ApiJsonOpts{Tree: "views[name,url,description]"}); err != nil {
+	if err := v.ApiJsonCtx(ctx, jobJson, &ApiJsonOpts{Tree: "views[name,url,description]"}); err != nil {
 		return nil, err
 	}
 	return jobJson.Views, nil
@@ -8,13 +8,17 @@ import (
 )
 
 func TestViewServiceGet(t *testing.T) {
-	v, err := jenkins.Views().Get("all")
+	j := newReplayJenkins(t, "view_get")
+	v, err := j.Views().Get("all")
 	assert.Nil(t, err)
 	assert.NotNil(t, v)
 	assert.Equal(t, v.Name, "all")
 }
 
 func TestViewServiceCreate(t *testing.T) {
+	j := newReplayJenkins(t, "view_create")
+	// created by setup when the cassette is recorded
+	folder := NewJob(j.Name2URL("folder"), "Folder", j)
 	v, err := folder.Views().Get("testview")
 	assert.NotNil(t, err)
 	assert.Empty(t, v)
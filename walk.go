@@ -0,0 +1,92 @@
+package jenkins
+
+import (
+	"context"
+	"iter"
+	"slices"
+)
+
+// classes of jobs which contain other jobs
+var folderClasses = []string{"Folder", "WorkflowMultiBranchProject", "OrganizationFolder"}
+
+func isFolder(class string) bool {
+	return slices.Contains(folderClasses, class)
+}
+
+// WalkOpts controls which jobs are visited by Walk, zero value walks all jobs.
+type WalkOpts struct {
+	// levels below the start folder to walk, 1 for direct children only,
+	// unlimited if 0
+	MaxDepth int
+	// yield only jobs of these classes, e.g. "WorkflowJob", folders are
+	// still walked into. All if empty
+	Classes []string
+	// do not walk into folder if Skip returns true, the folder itself is
+	// still yielded
+	Skip func(job *Job) bool
+}
+
+func (o *WalkOpts) match(job *Job) bool {
+	return len(o.Classes) == 0 || slices.Contains(o.Classes, job.Class)
+}
+
+func (o *WalkOpts) descend(job *Job, depth int) bool {
+	return isFolder(job.Class) &&
+		(o.MaxDepth == 0 || depth < o.MaxDepth) &&
+		(o.Skip == nil || !o.Skip(job))
+}
+
+func (j *Job) Walk(opts *WalkOpts) iter.Seq2[*Job, error] {
+	return j.WalkCtx(context.Background(), opts)
+}
+
+// Walk jobs under folder depth first, each folder is fetched when the walk
+// reaches it. Failure of fetching a folder is yielded and the walk goes on
+// with its siblings unless ctx is done or the loop breaks:
+//
+//	for job, err := range folder.WalkCtx(ctx, &jenkins.WalkOpts{MaxDepth: 2}) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(job.FullName)
+//	}
+func (j *Job) WalkCtx(ctx context.Context, opts *WalkOpts) iter.Seq2[*Job, error] {
+	var o WalkOpts
+	if opts != nil {
+		o = *opts
+	}
+	return func(yield func(*Job, error) bool) {
+		if !isFolder(j.Class) {
+			yield(nil, newError(ErrNotAFolder, "%s have no jobs", j))
+			return
+		}
+		j.walk(ctx, &o, 1, yield)
+	}
+}
+
+// walk returns false once the walk should stop
+func (j *Job) walk(ctx context.Context, o *WalkOpts, depth int, yield func(*Job, error) bool) bool {
+	folder, err := ApiJSONCtx[jobTree](ctx, j)
+	if err != nil {
+		return yield(nil, err) && ctx.Err() == nil
+	}
+	for _, item := range folder.Jobs {
+		job := NewJob(item.URL, item.Class, j.jenkins)
+		if o.match(job) && !yield(job, nil) {
+			return false
+		}
+		if o.descend(job, depth) && !job.walk(ctx, o, depth+1, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Jenkins) AllJobs(opts *WalkOpts) iter.Seq2[*Job, error] {
+	return c.AllJobsCtx(context.Background(), opts)
+}
+
+// Walk all jobs of jenkins, see Job.WalkCtx.
+func (c *Jenkins) AllJobsCtx(ctx context.Context, opts *WalkOpts) iter.Seq2[*Job, error] {
+	return NewJob(c.URL, "Folder", c).WalkCtx(ctx, opts)
+}
@@ -0,0 +1,115 @@
+package jenkins
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// folder path -> children as name:class
+var walkTree = map[string][]string{
+	"/":                   {"a:Folder", "c:WorkflowJob", "d:Folder"},
+	"/job/a/":             {"b:Folder", "p0:FreeStyleProject"},
+	"/job/a/job/b/":       {"p1:WorkflowJob", "m:WorkflowMultiBranchProject"},
+	"/job/a/job/b/job/m/": {"main:WorkflowJob"},
+}
+
+func newWalkJenkins(t *testing.T, requests *atomic.Int32) *Jenkins {
+	var j *Jenkins
+	j = newFakeJenkins(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		folder := strings.TrimSuffix(r.URL.Path, "api/json")
+		children, ok := walkTree[folder]
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		var jobs []map[string]string
+		for _, child := range children {
+			name, class, _ := strings.Cut(child, ":")
+			jobs = append(jobs, map[string]string{
+				"_class": "x." + class,
+				"name":   name,
+				"url":    strings.TrimSuffix(j.URL, "/") + folder + "job/" + name + "/",
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]any{"jobs": jobs})
+	})
+	return j
+}
+
+func collectJobs(t *testing.T, seq func(func(*Job, error) bool)) (names []string, errs []error) {
+	for job, err := range seq {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		names = append(names, job.FullName)
+	}
+	return
+}
+
+func TestWalk(t *testing.T) {
+	var requests atomic.Int32
+	j := newWalkJenkins(t, &requests)
+
+	names, errs := collectJobs(t, j.AllJobs(nil))
+	assert.Equal(t, []string{"a", "a/b", "a/b/p1", "a/b/m", "a/b/m/main", "a/p0", "c", "d"}, names)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, int32(5), requests.Load())
+
+	names, _ = collectJobs(t, j.AllJobs(&WalkOpts{MaxDepth: 2}))
+	assert.Equal(t, []string{"a", "a/b", "a/p0", "c", "d"}, names)
+
+	names, _ = collectJobs(t, j.AllJobs(&WalkOpts{Classes: []string{"WorkflowJob"}}))
+	assert.Equal(t, []string{"a/b/p1", "a/b/m/main", "c"}, names)
+
+	names, errs = collectJobs(t, j.AllJobs(&WalkOpts{
+		Skip: func(job *Job) bool { return job.Name == "b" || job.Name == "d" },
+	}))
+	assert.Equal(t, []string{"a", "a/b", "a/p0", "c", "d"}, names)
+	assert.Empty(t, errs)
+
+	// walk from sub folder
+	folder := NewJob(j.Name2URL("a/b"), "Folder", j)
+	names, _ = collectJobs(t, folder.Walk(nil))
+	assert.Equal(t, []string{"a/b/p1", "a/b/m", "a/b/m/main"}, names)
+
+	// break stops fetching
+	requests.Store(0)
+	for job := range j.AllJobs(nil) {
+		if job.Name == "b" {
+			break
+		}
+	}
+	assert.Equal(t, int32(2), requests.Load())
+
+	// not a folder
+	_, errs = collectJobs(t, NewJob(j.Name2URL("c"), "WorkflowJob", j).Walk(nil))
+	assert.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrNotAFolder)
+}
+
+func TestWalkCtxCancel(t *testing.T) {
+	var requests atomic.Int32
+	j := newWalkJenkins(t, &requests)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var errs []error
+	for job, err := range j.AllJobsCtx(ctx, nil) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if job.Name == "a" {
+			cancel()
+		}
+	}
+	assert.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], context.Canceled)
+}
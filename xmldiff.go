@@ -0,0 +1,332 @@
+package jenkins
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type XMLChangeOp string
+
+const (
+	XMLAdded   XMLChangeOp = "added"
+	XMLRemoved XMLChangeOp = "removed"
+	XMLChanged XMLChangeOp = "changed"
+)
+
+// XMLChange is a difference of element, its text or an attribute of it.
+// Path addresses the element as path of ConfigEdit, e.g.
+// /project/builders/hudson.tasks.Shell[2]/command, position is in old
+// document except for added element. Old and New are text of element or
+// value of attribute, or whole element if it is added or removed.
+type XMLChange struct {
+	Op   XMLChangeOp `json:"op"`
+	Path string      `json:"path"`
+	Attr string      `json:"attr,omitempty"`
+	Old  string      `json:"old,omitempty"`
+	New  string      `json:"new,omitempty"`
+}
+
+func (c XMLChange) String() string {
+	target := c.Path
+	if c.Attr != "" {
+		target += "/@" + c.Attr
+	}
+	switch c.Op {
+	case XMLAdded:
+		return fmt.Sprintf("+ %s: %s", target, strconv.Quote(c.New))
+	case XMLRemoved:
+		return fmt.Sprintf("- %s: %s", target, strconv.Quote(c.Old))
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", target, strconv.Quote(c.Old), strconv.Quote(c.New))
+	}
+}
+
+// XMLDiff is result of DiffXML.
+type XMLDiff struct {
+	Changes []XMLChange `json:"changes"`
+	// canonical form of documents
+	old, new string
+}
+
+// Equal reports whether documents are semantically equal.
+func (d *XMLDiff) Equal() bool {
+	return len(d.Changes) == 0
+}
+
+// String returns a change per line.
+func (d *XMLDiff) String() string {
+	var b strings.Builder
+	for _, c := range d.Changes {
+		b.WriteString(c.String() + "\n")
+	}
+	return b.String()
+}
+
+// Unified renders unified diff of documents in canonical form, i.e. one
+// element per line with sorted attributes, empty if they are equal.
+func (d *XMLDiff) Unified(fromName, toName string) string {
+	if d.Equal() {
+		return ""
+	}
+	return unifiedDiff(fromName, toName, d.old, d.new)
+}
+
+// JSON renders changes as {"changes": [{"op": "changed", "path": ...}]}.
+func (d *XMLDiff) JSON() ([]byte, error) {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	// elements are kept readable
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	err := enc.Encode(d)
+	return b.Bytes(), err
+}
+
+// DiffXML compares documents semantically, whitespace between elements,
+// comments, order of attributes and declaration are ignored. So is order of
+// elements whose name appears once among their siblings, order matters only
+// among siblings of same name, moved one is reported as removed and added.
+// Changes are in document order:
+//
+//	config, _ := job.GetConfigure()
+//	local, _ := os.ReadFile("config.xml")
+//	diff, err := jenkins.DiffXML(config, string(local))
+//	fmt.Print(diff.Unified("live", "local"))
+func DiffXML(old, new string) (*XMLDiff, error) {
+	a, err := parseXMLDoc(old)
+	if err != nil {
+		return nil, fmt.Errorf("old document: %w", err)
+	}
+	b, err := parseXMLDoc(new)
+	if err != nil {
+		return nil, fmt.Errorf("new document: %w", err)
+	}
+	d := &XMLDiff{Changes: []XMLChange{}}
+	// doc is container of root element
+	d.diffChildren("", a, b)
+	d.old, d.new = canonicalDoc(a), canonicalDoc(b)
+	return d, nil
+}
+
+// text of element as canonical form keeps it, text between child elements
+// is trimmed
+func (e *xmlElement) canonicalText() string {
+	if slices.ContainsFunc(e.children, func(n *xmlNode) bool { return n.elem != nil }) {
+		var texts []string
+		for _, child := range e.children {
+			if child.isText && !isSpace(child) {
+				texts = append(texts, strings.TrimSpace(child.text))
+			}
+		}
+		return strings.Join(texts, "\n")
+	}
+	if text := e.text(); strings.TrimSpace(text) != "" {
+		return text
+	}
+	return ""
+}
+
+// child element with its path
+type xmlChild struct {
+	elem *xmlElement
+	path string
+}
+
+// child elements of e with paths, position is appended if there are more
+// than one element of the name in either of e and other
+func (e *xmlElement) childPaths(path string, other *xmlElement) []xmlChild {
+	count := func(e *xmlElement) map[string]int {
+		n := map[string]int{}
+		for _, child := range e.children {
+			if child.elem != nil {
+				n[qualifiedName(child.elem.name)]++
+			}
+		}
+		return n
+	}
+	mine, others := count(e), count(other)
+	seen := map[string]int{}
+	var children []xmlChild
+	for _, child := range e.children {
+		if child.elem == nil {
+			continue
+		}
+		name := qualifiedName(child.elem.name)
+		seen[name]++
+		p := path + "/" + name
+		if mine[name] > 1 || others[name] > 1 {
+			p += "[" + strconv.Itoa(seen[name]) + "]"
+		}
+		children = append(children, xmlChild{child.elem, p})
+	}
+	return children
+}
+
+func (d *XMLDiff) diffElement(path string, a, b *xmlElement) {
+	var names []string
+	for _, attr := range a.attrs {
+		names = append(names, qualifiedName(attr.Name))
+	}
+	for _, attr := range b.attrs {
+		if _, ok := a.attr(qualifiedName(attr.Name)); !ok {
+			names = append(names, qualifiedName(attr.Name))
+		}
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		old, inOld := a.attr(name)
+		new, inNew := b.attr(name)
+		switch {
+		case !inNew:
+			d.Changes = append(d.Changes, XMLChange{Op: XMLRemoved, Path: path, Attr: name, Old: old})
+		case !inOld:
+			d.Changes = append(d.Changes, XMLChange{Op: XMLAdded, Path: path, Attr: name, New: new})
+		case old != new:
+			d.Changes = append(d.Changes, XMLChange{Op: XMLChanged, Path: path, Attr: name, Old: old, New: new})
+		}
+	}
+	if old, new := a.canonicalText(), b.canonicalText(); old != new {
+		d.Changes = append(d.Changes, XMLChange{Op: XMLChanged, Path: path, Old: old, New: new})
+	}
+	d.diffChildren(path, a, b)
+}
+
+// pair children whose name appears at most once in a and b by name, so
+// their order does not matter. Others are aligned by longest common
+// subsequence of their canonical form, elements of same name between
+// aligned ones are paired. Paired elements are compared recursively and
+// changes are in order of children of a, added element follows the one its
+// previous sibling is paired with and elements removed after it.
+func (d *XMLDiff) diffChildren(path string, a, b *xmlElement) {
+	as, bs := a.childPaths(path, b), b.childPaths(path, a)
+	count := func(children []xmlChild) map[string]int {
+		n := map[string]int{}
+		for _, child := range children {
+			n[qualifiedName(child.elem.name)]++
+		}
+		return n
+	}
+	na, nb := count(as), count(bs)
+	single := func(name string) bool { return na[name] <= 1 && nb[name] <= 1 }
+	// index of paired child of b by index of child of a
+	pairs := map[int]int{}
+	var ra, rb []int
+	byName := map[string]int{}
+	for j, child := range bs {
+		if name := qualifiedName(child.elem.name); single(name) {
+			byName[name] = j
+		} else {
+			rb = append(rb, j)
+		}
+	}
+	for i, child := range as {
+		name := qualifiedName(child.elem.name)
+		if !single(name) {
+			ra = append(ra, i)
+		} else if j, ok := byName[name]; ok {
+			pairs[i] = j
+		}
+	}
+	alignChildren(as, bs, ra, rb, pairs)
+
+	// children of b are added after child of a which their previous
+	// sibling is paired with, -1 for the first ones
+	paired := map[int]int{}
+	for i, j := range pairs {
+		paired[j] = i
+	}
+	added := map[int][]xmlChild{}
+	after := -1
+	for j, child := range bs {
+		if i, ok := paired[j]; ok {
+			after = i
+		} else {
+			added[after] = append(added[after], child)
+		}
+	}
+	addAfter := func(i int) {
+		for _, c := range added[i] {
+			d.Changes = append(d.Changes, XMLChange{Op: XMLAdded, Path: c.path, New: c.elem.canonicalString()})
+		}
+	}
+	// added ones follow removed ones before the next paired child
+	next := -1
+	addBefore := func(i int) {
+		for ; next < i; next++ {
+			addAfter(next)
+		}
+	}
+	for i, child := range as {
+		if j, ok := pairs[i]; ok {
+			addBefore(i)
+			d.diffElement(child.path, child.elem, bs[j].elem)
+		} else {
+			d.Changes = append(d.Changes, XMLChange{Op: XMLRemoved, Path: child.path, Old: child.elem.canonicalString()})
+		}
+	}
+	addBefore(len(as))
+}
+
+// pair children of indexes ra and rb by longest common subsequence of their
+// canonical form, removed and added ones of same name between aligned ones
+// are paired as well
+func alignChildren(as, bs []xmlChild, ra, rb []int, pairs map[int]int) {
+	keys := func(children []xmlChild, indexes []int) []string {
+		var keys []string
+		for _, i := range indexes {
+			keys = append(keys, children[i].elem.canonicalString())
+		}
+		return keys
+	}
+	var removed, added []int
+	flush := func() {
+		for _, r := range removed {
+			k := slices.IndexFunc(added, func(j int) bool { return bs[j].elem.name == as[r].elem.name })
+			if k >= 0 {
+				pairs[r] = added[k]
+				added = added[k+1:]
+			}
+		}
+		removed, added = nil, nil
+	}
+	i, j := 0, 0
+	for _, op := range diffLines(keys(as, ra), keys(bs, rb)) {
+		switch op.kind {
+		case ' ':
+			flush()
+			pairs[ra[i]] = rb[j]
+			i++
+			j++
+		case '-':
+			removed = append(removed, ra[i])
+			i++
+		default:
+			added = append(added, rb[j])
+			j++
+		}
+	}
+	flush()
+}
+
+func (j *Job) DiffConfigure(other *Job) (*XMLDiff, error) {
+	return j.DiffConfigureCtx(context.Background(), other)
+}
+
+// DiffConfigure compares config.xml of job with other job, which may be on
+// another jenkins, see DiffXML.
+func (j *Job) DiffConfigureCtx(ctx context.Context, other *Job) (*XMLDiff, error) {
+	old, err := j.GetConfigureCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	new, err := other.GetConfigureCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return DiffXML(old, new)
+}
@@ -0,0 +1,156 @@
+package jenkins
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/joelee2012/go-jenkins/jenkinstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffXML(t *testing.T) {
+	// re-serialized by jenkins
+	saved := `<?xml version="1.0" encoding="UTF-8"?>
+<project><description>old &amp; busted</description>
+<properties><hudson.model.ParametersDefinitionProperty><parameterDefinitions>
+<hudson.model.StringParameterDefinition><name>ARG1</name><defaultValue>a</defaultValue></hudson.model.StringParameterDefinition>
+<hudson.model.StringParameterDefinition><name>ARG2</name><defaultValue>b</defaultValue></hudson.model.StringParameterDefinition>
+</parameterDefinitions></hudson.model.ParametersDefinitionProperty></properties>
+<scm class="hudson.scm.NullSCM"></scm>
+<builders></builders>
+</project>`
+	diff, err := DiffXML(patchConf, saved)
+	require.NoError(t, err)
+	assert.True(t, diff.Equal())
+	assert.Equal(t, "", diff.String())
+	assert.Equal(t, "", diff.Unified("a", "b"))
+	data, err := diff.JSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"changes": []}`, string(data))
+
+	changed := `<?xml version='1.1' encoding='UTF-8'?>
+<project>
+  <description>new</description>
+  <properties>
+    <hudson.model.ParametersDefinitionProperty>
+      <parameterDefinitions>
+        <hudson.model.StringParameterDefinition>
+          <name>ARG1</name>
+          <defaultValue>a</defaultValue>
+        </hudson.model.StringParameterDefinition>
+        <hudson.model.StringParameterDefinition>
+          <name>ARG2</name>
+          <defaultValue>c</defaultValue>
+        </hudson.model.StringParameterDefinition>
+        <hudson.model.StringParameterDefinition>
+          <name>ARG3</name>
+        </hudson.model.StringParameterDefinition>
+      </parameterDefinitions>
+    </hudson.model.ParametersDefinitionProperty>
+  </properties>
+  <scm class="hudson.plugins.git.GitSCM" plugin="git@5.2.0"/>
+</project>`
+	diff, err = DiffXML(patchConf, changed)
+	require.NoError(t, err)
+	params := "/project/properties/hudson.model.ParametersDefinitionProperty/parameterDefinitions/hudson.model.StringParameterDefinition"
+	assert.Equal(t, []XMLChange{
+		{Op: XMLChanged, Path: "/project/description", Old: "old & busted", New: "new"},
+		{Op: XMLChanged, Path: params + "[2]/defaultValue", Old: "b", New: "c"},
+		{Op: XMLAdded, Path: params + "[3]", New: "<hudson.model.StringParameterDefinition>\n  <name>ARG3</name>\n</hudson.model.StringParameterDefinition>"},
+		{Op: XMLChanged, Path: "/project/scm", Attr: "class", Old: "hudson.scm.NullSCM", New: "hudson.plugins.git.GitSCM"},
+		{Op: XMLAdded, Path: "/project/scm", Attr: "plugin", New: "git@5.2.0"},
+		{Op: XMLRemoved, Path: "/project/builders", Old: "<builders/>"},
+	}, diff.Changes)
+	assert.Equal(t, `~ /project/description: "old & busted" -> "new"`, strings.Split(diff.String(), "\n")[0])
+	assert.Contains(t, diff.String(), `- /project/builders: "<builders/>"`+"\n")
+	assert.Contains(t, diff.String(), `+ /project/scm/@plugin: "git@5.2.0"`+"\n")
+	assert.Contains(t, diff.Unified("a", "b"), "-  <description>old &amp; busted</description>\n+  <description>new</description>\n")
+	data, err = diff.JSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"op": "changed",
+      "path": "/project/description",
+      "old": "old & busted",
+      "new": "new"`)
+	assert.Contains(t, string(data), `"old": "<builders/>"`)
+
+	// paths of changed text are usable by ConfigEdit
+	text, err := applyEdits(patchConf, []ConfigEdit{SetText(diff.Changes[1].Path, diff.Changes[1].New)})
+	require.NoError(t, err)
+	assert.Contains(t, text, "<defaultValue>c</defaultValue>")
+
+	diff, err = DiffXML("<a/>", "<b/>")
+	require.NoError(t, err)
+	assert.Equal(t, []XMLChange{{Op: XMLRemoved, Path: "/a", Old: "<a/>"}, {Op: XMLAdded, Path: "/b", New: "<b/>"}}, diff.Changes)
+	_, err = DiffXML("<a>", "<a/>")
+	assert.Error(t, err)
+}
+
+func TestDiffXMLOrder(t *testing.T) {
+	// only order of elements is changed
+	reordered := `<?xml version='1.1' encoding='UTF-8'?>
+<project>
+  <builders/>
+  <scm class='hudson.scm.NullSCM'/>
+  <properties>
+    <hudson.model.ParametersDefinitionProperty>
+      <parameterDefinitions>
+        <hudson.model.StringParameterDefinition>
+          <defaultValue>a</defaultValue>
+          <name>ARG1</name>
+        </hudson.model.StringParameterDefinition>
+        <hudson.model.StringParameterDefinition>
+          <name>ARG2</name>
+          <defaultValue>b</defaultValue>
+        </hudson.model.StringParameterDefinition>
+      </parameterDefinitions>
+    </hudson.model.ParametersDefinitionProperty>
+  </properties>
+  <!-- managed by go-jenkins -->
+  <description>old &amp; busted</description>
+</project>`
+	diff, err := DiffXML(patchConf, reordered)
+	require.NoError(t, err)
+	assert.True(t, diff.Equal(), diff.String())
+	assert.Equal(t, "", diff.Unified("a", "b"))
+
+	// changes are in document order
+	diff, err = DiffXML("<r><a>1</a><b>1</b><x/><c>1</c></r>", "<r><c>2</c><d/><b>2</b><a>2</a></r>")
+	require.NoError(t, err)
+	var paths []string
+	for _, c := range diff.Changes {
+		paths = append(paths, string(c.Op)+" "+c.Path)
+	}
+	assert.Equal(t, []string{"changed /r/a", "changed /r/b", "removed /r/x", "changed /r/c", "added /r/d"}, paths)
+
+	// order of elements of same name matters
+	swapped := strings.NewReplacer("ARG1", "ARG2", "ARG2", "ARG1", "<defaultValue>a", "<defaultValue>b",
+		"<defaultValue>b", "<defaultValue>a").Replace(patchConf)
+	diff, err = DiffXML(patchConf, swapped)
+	require.NoError(t, err)
+	require.Len(t, diff.Changes, 2)
+	assert.Equal(t, XMLRemoved, diff.Changes[0].Op)
+	assert.Equal(t, XMLAdded, diff.Changes[1].Op)
+}
+
+func TestDiffConfigure(t *testing.T) {
+	var clients []*Jenkins
+	for _, config := range []string{syncJobConf, strings.Replace(syncJobConf, ">app<", ">new<", 1)} {
+		srv := jenkinstest.NewServer()
+		defer srv.Close()
+		require.NoError(t, srv.CreateJob("app", config))
+		client, err := New(srv.URL, "admin", "1234")
+		require.NoError(t, err)
+		clients = append(clients, client)
+	}
+	job, err := clients[0].GetJob("app")
+	require.NoError(t, err)
+	other, err := clients[1].GetJob("app")
+	require.NoError(t, err)
+	diff, err := job.DiffConfigure(other)
+	require.NoError(t, err)
+	assert.Equal(t, []XMLChange{{Op: XMLChanged, Path: "/project/description", Old: "app", New: "new"}}, diff.Changes)
+	diff, err = job.DiffConfigure(job)
+	require.NoError(t, err)
+	assert.True(t, diff.Equal())
+}
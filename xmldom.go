@@ -0,0 +1,576 @@
+package jenkins
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// xmlElement is an element of document which keeps its source, so the
+// document is written back byte for byte except edited elements.
+type xmlElement struct {
+	name     xml.Name
+	attrs    []xml.Attr
+	children []*xmlNode
+	// source of start and end tag, end is empty if element is self closing
+	rawStart, rawEnd string
+	// tags are written from name and attrs once attribute is edited
+	modified bool
+}
+
+// xmlNode is either an element or other token kept as raw source
+type xmlNode struct {
+	elem *xmlElement
+	// unescaped text if node is char data
+	text   string
+	isText bool
+	raw    string
+}
+
+// parse document, returned element is a container of top level nodes
+func parseXMLDoc(text string) (*xmlElement, error) {
+	// same length so offsets of src are offsets of text
+	src := xmlVersion.ReplaceAllString(text, "${1}1.0${2}")
+	d := xml.NewDecoder(strings.NewReader(src))
+	doc := &xmlElement{}
+	stack := []*xmlElement{doc}
+	for {
+		start := d.InputOffset()
+		token, err := d.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		raw := text[start:d.InputOffset()]
+		top := stack[len(stack)-1]
+		switch t := token.(type) {
+		case xml.StartElement:
+			e := &xmlElement{name: t.Name, attrs: t.Copy().Attr, rawStart: raw}
+			top.children = append(top.children, &xmlNode{elem: e})
+			stack = append(stack, e)
+		case xml.EndElement:
+			if len(stack) == 1 {
+				return nil, fmt.Errorf("unexpected end element %s", raw)
+			}
+			top.rawEnd = raw
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			top.children = append(top.children, &xmlNode{text: string(t), isText: true, raw: raw})
+		default:
+			top.children = append(top.children, &xmlNode{raw: raw})
+		}
+	}
+	if len(stack) != 1 {
+		return nil, fmt.Errorf("element %s is not closed", qualifiedName(stack[len(stack)-1].name))
+	}
+	return doc, nil
+}
+
+// parse fragment of elements, e.g. "<a>1</a><b/>"
+func parseXMLFragment(fragment string) ([]*xmlNode, error) {
+	doc, err := parseXMLDoc("<fragment>" + fragment + "</fragment>")
+	if err != nil {
+		return nil, err
+	}
+	return doc.children[0].elem.children, nil
+}
+
+func (e *xmlElement) String() string {
+	var b strings.Builder
+	for _, child := range e.children {
+		child.write(&b)
+	}
+	return b.String()
+}
+
+func (n *xmlNode) write(b *strings.Builder) {
+	switch {
+	case n.elem != nil:
+		n.elem.write(b)
+	case n.raw != "":
+		b.WriteString(n.raw)
+	case n.isText:
+		b.WriteString(escapeXMLText(n.text))
+	}
+}
+
+func (e *xmlElement) write(b *strings.Builder) {
+	// self closing element has no end tag for new children
+	if !e.modified && (e.rawEnd != "" || len(e.children) == 0) {
+		b.WriteString(e.rawStart)
+		for _, child := range e.children {
+			child.write(b)
+		}
+		b.WriteString(e.rawEnd)
+		return
+	}
+	name := qualifiedName(e.name)
+	b.WriteString("<" + name)
+	for _, attr := range e.attrs {
+		fmt.Fprintf(b, ` %s="%s"`, qualifiedName(attr.Name), escapeXMLAttr(attr.Value))
+	}
+	if len(e.children) == 0 {
+		b.WriteString("/>")
+		return
+	}
+	b.WriteString(">")
+	for _, child := range e.children {
+		child.write(b)
+	}
+	b.WriteString("</" + name + ">")
+}
+
+func qualifiedName(name xml.Name) string {
+	if name.Space != "" {
+		return name.Space + ":" + name.Local
+	}
+	return name.Local
+}
+
+// text is escaped as jenkins does, new lines and quotes are kept
+func escapeXMLText(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+func escapeXMLAttr(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;",
+		"\n", "&#10;", "\r", "&#13;", "\t", "&#9;").Replace(s)
+}
+
+// text of element, i.e. concatenated char data of its children
+func (e *xmlElement) text() string {
+	var b strings.Builder
+	for _, child := range e.children {
+		if child.isText {
+			b.WriteString(child.text)
+		}
+	}
+	return b.String()
+}
+
+func (e *xmlElement) attr(name string) (string, bool) {
+	for _, attr := range e.attrs {
+		if qualifiedName(attr.Name) == name {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
+
+func (e *xmlElement) setText(text string) {
+	e.children = []*xmlNode{{text: text, isText: true}}
+}
+
+func (e *xmlElement) setAttr(name, value string) {
+	e.modified = true
+	for i, attr := range e.attrs {
+		if qualifiedName(attr.Name) == name {
+			e.attrs[i].Value = value
+			return
+		}
+	}
+	space, local, found := strings.Cut(name, ":")
+	if !found {
+		space, local = "", name
+	}
+	e.attrs = append(e.attrs, xml.Attr{Name: xml.Name{Space: space, Local: local}, Value: value})
+}
+
+func isSpace(n *xmlNode) bool {
+	return n.isText && strings.TrimSpace(n.text) == ""
+}
+
+// append nodes after the last child, they are indented like existing
+// children
+func (e *xmlElement) appendNodes(nodes []*xmlNode) {
+	n := len(e.children)
+	if n == 0 || !isSpace(e.children[n-1]) {
+		e.children = append(e.children, nodes...)
+		return
+	}
+	// whitespace before the last element is the indent of children
+	indent := e.children[n-1].text + "  "
+	for i := n - 2; i >= 0; i-- {
+		if e.children[i].elem != nil {
+			if i > 0 && isSpace(e.children[i-1]) {
+				indent = e.children[i-1].text
+			}
+			break
+		}
+	}
+	var inserted []*xmlNode
+	for _, node := range nodes {
+		if node.elem != nil {
+			inserted = append(inserted, &xmlNode{text: indent, isText: true})
+		}
+		if !isSpace(node) {
+			inserted = append(inserted, node)
+		}
+	}
+	e.children = append(e.children[:n-1], append(inserted, e.children[n-1])...)
+}
+
+// remove child element with whitespace before it
+func (e *xmlElement) removeChild(child *xmlElement) {
+	for i, node := range e.children {
+		if node.elem != child {
+			continue
+		}
+		from := i
+		if i > 0 && isSpace(e.children[i-1]) {
+			from = i - 1
+		}
+		e.children = append(e.children[:from], e.children[i+1:]...)
+		return
+	}
+}
+
+func (e *xmlElement) removeAttr(name string) {
+	e.modified = true
+	e.attrs = slices.DeleteFunc(e.attrs, func(attr xml.Attr) bool { return qualifiedName(attr.Name) == name })
+}
+
+// insert node after child element or before the first one if after is nil,
+// it is indented like other children
+func (e *xmlElement) insertChild(after *xmlElement, node *xmlNode) {
+	first := slices.IndexFunc(e.children, func(n *xmlNode) bool { return n.elem != nil })
+	var indent *xmlNode
+	if first > 0 && isSpace(e.children[first-1]) {
+		indent = &xmlNode{text: e.children[first-1].text, isText: true}
+	}
+	at, nodes := first, []*xmlNode{node}
+	if indent != nil {
+		nodes = append(nodes, indent)
+	}
+	if after != nil {
+		at = slices.IndexFunc(e.children, func(n *xmlNode) bool { return n.elem == after }) + 1
+		nodes = []*xmlNode{node}
+		if indent != nil {
+			nodes = []*xmlNode{indent, node}
+		}
+	}
+	e.children = slices.Insert(e.children, at, nodes...)
+}
+
+func (e *xmlElement) hasChildElements() bool {
+	return slices.ContainsFunc(e.children, func(n *xmlNode) bool { return n.elem != nil })
+}
+
+// element without attributes, child elements and text, or with zero value
+// text if zero is true
+func (e *xmlElement) isEmpty(zero bool) bool {
+	if len(e.attrs) > 0 || e.hasChildElements() {
+		return false
+	}
+	text := e.canonicalText()
+	return text == "" || zero && (text == "false" || text == "0")
+}
+
+// merge changes of other into e, elements equal in both are kept as is, so
+// are their order and formatting. Children are paired by name and position
+// among siblings of the name. Empty element missing in other is kept and
+// zero value element missing in e is not added, they are usually omitted by
+// one of them.
+func (e *xmlElement) merge(other *xmlElement) {
+	if e.canonicalString() == other.canonicalString() {
+		return
+	}
+	for _, attr := range other.attrs {
+		name := qualifiedName(attr.Name)
+		if value, ok := e.attr(name); !ok || value != attr.Value {
+			e.setAttr(name, attr.Value)
+		}
+	}
+	for _, attr := range slices.Clone(e.attrs) {
+		if _, ok := other.attr(qualifiedName(attr.Name)); !ok {
+			e.removeAttr(qualifiedName(attr.Name))
+		}
+	}
+	switch {
+	case !e.hasChildElements() && !other.hasChildElements():
+		if e.canonicalText() != other.canonicalText() {
+			e.setText(other.text())
+		}
+		return
+	case !e.hasChildElements() || !other.hasChildElements():
+		e.children = other.children
+		return
+	}
+	type key struct {
+		name string
+		n    int
+	}
+	keys := func(e *xmlElement) map[key]*xmlElement {
+		seen := map[string]int{}
+		m := map[key]*xmlElement{}
+		for _, child := range e.children {
+			if child.elem != nil {
+				name := qualifiedName(child.elem.name)
+				seen[name]++
+				m[key{name, seen[name]}] = child.elem
+			}
+		}
+		return m
+	}
+	mine := keys(e)
+	seen := map[string]int{}
+	paired := map[*xmlElement]bool{}
+	var last *xmlElement
+	for _, child := range other.children {
+		if child.elem == nil {
+			continue
+		}
+		name := qualifiedName(child.elem.name)
+		seen[name]++
+		if elem, ok := mine[key{name, seen[name]}]; ok {
+			elem.merge(child.elem)
+			paired[elem] = true
+			last = elem
+		} else if !child.elem.isEmpty(true) {
+			e.insertChild(last, child)
+			last = child.elem
+		}
+	}
+	for _, elem := range mine {
+		if !paired[elem] && !elem.isEmpty(false) {
+			e.removeChild(elem)
+		}
+	}
+}
+
+// step of path, e.g. hudson.model.StringParameterDefinition[name='ARG1']
+type xmlStep struct {
+	name       string
+	predicates []xmlPredicate
+}
+
+// predicate selects element by position, attribute or text of child
+type xmlPredicate struct {
+	position int
+	attr     string
+	child    string
+	value    string
+}
+
+// parse path of form /root/child[n]/child[@attr='v']/child[name='v'], it is
+// relative to root element if it does not start with /, * matches any
+// element
+func parseXMLPath(path string) (absolute bool, steps []xmlStep, err error) {
+	absolute = strings.HasPrefix(path, "/")
+	rest := strings.TrimPrefix(path, "/")
+	for rest != "" {
+		// split at / which is not in predicate
+		end, depth, quote := len(rest), 0, byte(0)
+		for i := 0; i < len(rest) && end == len(rest); i++ {
+			c := rest[i]
+			switch {
+			case quote != 0:
+				if c == quote {
+					quote = 0
+				}
+			case c == '\'' || c == '"':
+				quote = c
+			case c == '[':
+				depth++
+			case c == ']':
+				depth--
+			case c == '/' && depth == 0:
+				end = i
+			}
+		}
+		step, err := parseXMLStep(rest[:end])
+		if err != nil {
+			return false, nil, fmt.Errorf("invalid path %q: %w", path, err)
+		}
+		steps = append(steps, step)
+		rest = strings.TrimPrefix(rest[end:], "/")
+	}
+	if len(steps) == 0 {
+		return false, nil, fmt.Errorf("invalid path %q: no step", path)
+	}
+	return absolute, steps, nil
+}
+
+func parseXMLStep(s string) (xmlStep, error) {
+	name, rest, _ := strings.Cut(s, "[")
+	step := xmlStep{name: strings.TrimSpace(name)}
+	if step.name == "" {
+		return step, fmt.Errorf("empty step %q", s)
+	}
+	for rest != "" {
+		expr, after, found := strings.Cut(rest, "]")
+		if !found {
+			return step, fmt.Errorf("missing ] in %q", s)
+		}
+		p, err := parseXMLPredicate(strings.TrimSpace(expr))
+		if err != nil {
+			return step, err
+		}
+		step.predicates = append(step.predicates, p)
+		rest = strings.TrimPrefix(after, "[")
+		if after != "" && !strings.HasPrefix(after, "[") {
+			return step, fmt.Errorf("unexpected %q in %q", after, s)
+		}
+	}
+	return step, nil
+}
+
+func parseXMLPredicate(expr string) (xmlPredicate, error) {
+	if n, err := strconv.Atoi(expr); err == nil {
+		if n < 1 {
+			return xmlPredicate{}, fmt.Errorf("position %d is less than 1", n)
+		}
+		return xmlPredicate{position: n}, nil
+	}
+	key, value, found := strings.Cut(expr, "=")
+	value = strings.TrimSpace(value)
+	if !found || len(value) < 2 || (value[0] != '\'' && value[0] != '"') || value[len(value)-1] != value[0] {
+		return xmlPredicate{}, fmt.Errorf("invalid predicate [%s]", expr)
+	}
+	p := xmlPredicate{value: value[1 : len(value)-1]}
+	key = strings.TrimSpace(key)
+	if attr, ok := strings.CutPrefix(key, "@"); ok {
+		p.attr = attr
+	} else {
+		p.child = key
+	}
+	return p, nil
+}
+
+func (s *xmlStep) matchName(e *xmlElement) bool {
+	return s.name == "*" || s.name == qualifiedName(e.name)
+}
+
+func (p *xmlPredicate) match(e *xmlElement) bool {
+	if p.attr != "" {
+		value, ok := e.attr(p.attr)
+		return ok && value == p.value
+	}
+	for _, child := range e.children {
+		if child.elem != nil && qualifiedName(child.elem.name) == p.child && child.elem.text() == p.value {
+			return true
+		}
+	}
+	return false
+}
+
+// xmlMatch is element matched by path with its parent
+type xmlMatch struct {
+	elem, parent *xmlElement
+}
+
+func (s *xmlStep) selectFrom(parent *xmlElement) []xmlMatch {
+	var matches []xmlMatch
+	for _, child := range parent.children {
+		if child.elem != nil && s.matchName(child.elem) {
+			matches = append(matches, xmlMatch{child.elem, parent})
+		}
+	}
+	for _, p := range s.predicates {
+		if p.position > 0 {
+			if p.position > len(matches) {
+				return nil
+			}
+			matches = matches[p.position-1 : p.position]
+			continue
+		}
+		var kept []xmlMatch
+		for _, m := range matches {
+			if p.match(m.elem) {
+				kept = append(kept, m)
+			}
+		}
+		matches = kept
+	}
+	return matches
+}
+
+// select elements of doc matched by path
+func (doc *xmlElement) selectPath(path string) ([]xmlMatch, error) {
+	absolute, steps, err := parseXMLPath(path)
+	if err != nil {
+		return nil, err
+	}
+	var current []xmlMatch
+	if absolute {
+		current = []xmlMatch{{elem: doc}}
+	} else {
+		for _, child := range doc.children {
+			if child.elem != nil {
+				current = []xmlMatch{{elem: child.elem, parent: doc}}
+				break
+			}
+		}
+	}
+	for _, step := range steps {
+		var next []xmlMatch
+		for _, m := range current {
+			next = append(next, step.selectFrom(m.elem)...)
+		}
+		current = next
+	}
+	return current, nil
+}
+
+// canonical form of document to compare configs semantically, comments,
+// declaration and whitespace between elements are dropped, attributes are
+// sorted and each element is on its own line
+func canonicalXML(text string) (string, error) {
+	doc, err := parseXMLDoc(text)
+	if err != nil {
+		return "", err
+	}
+	return canonicalDoc(doc), nil
+}
+
+func canonicalDoc(doc *xmlElement) string {
+	var b strings.Builder
+	for _, child := range doc.children {
+		if child.elem != nil {
+			child.elem.canonical(&b, "")
+		}
+	}
+	return b.String()
+}
+
+// canonical form of element without trailing new line
+func (e *xmlElement) canonicalString() string {
+	var b strings.Builder
+	e.canonical(&b, "")
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func (e *xmlElement) canonical(b *strings.Builder, indent string) {
+	name := qualifiedName(e.name)
+	b.WriteString(indent + "<" + name)
+	attrs := slices.Clone(e.attrs)
+	slices.SortFunc(attrs, func(a, b xml.Attr) int {
+		return strings.Compare(qualifiedName(a.Name), qualifiedName(b.Name))
+	})
+	for _, attr := range attrs {
+		fmt.Fprintf(b, ` %s="%s"`, qualifiedName(attr.Name), escapeXMLAttr(attr.Value))
+	}
+	if !slices.ContainsFunc(e.children, func(n *xmlNode) bool { return n.elem != nil }) {
+		if text := e.text(); strings.TrimSpace(text) != "" {
+			b.WriteString(">" + escapeXMLText(text) + "</" + name + ">\n")
+		} else {
+			b.WriteString("/>\n")
+		}
+		return
+	}
+	b.WriteString(">\n")
+	for _, child := range e.children {
+		switch {
+		case child.elem != nil:
+			child.elem.canonical(b, indent+"  ")
+		case child.isText && !isSpace(child):
+			b.WriteString(indent + "  " + escapeXMLText(strings.TrimSpace(child.text)) + "\n")
+		}
+	}
+	b.WriteString(indent + "</" + name + ">\n")
+}